@@ -4,23 +4,23 @@ package appconfig
 
 import (
 	"context"
-	"fmt"
-	"github.com/go-pg/pg/v10"
-	"github.com/kelseyhightower/envconfig"
 	"github.com/labstack/echo-contrib/prometheus"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"github.com/labstack/gommon/log"
 	middleKit "github.com/oboadagd/kit-go/middleware/echo"
-	pgKit "github.com/oboadagd/kit-go/postgresql"
-	"github.com/oboadagd/location-common/recordtype"
+	"github.com/oboadagd/location-history-mgmt/auth"
+	"github.com/oboadagd/location-history-mgmt/config"
 	"github.com/oboadagd/location-history-mgmt/controller"
+	"github.com/oboadagd/location-history-mgmt/logger"
 	"github.com/oboadagd/location-history-mgmt/migration"
+	"github.com/oboadagd/location-history-mgmt/ratelimit"
 	"github.com/oboadagd/location-history-mgmt/repository"
 	"github.com/oboadagd/location-history-mgmt/router"
 	"github.com/oboadagd/location-history-mgmt/service"
 	grpcserver "github.com/oboadagd/location-history-mgmt/userlocation/server"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 	"net/http"
 	"os"
 	"os/signal"
@@ -29,7 +29,7 @@ import (
 )
 
 // StartApp implements configuration and start-up of microservice.
-// It also runs a goroutine that starts up of grpc server.
+// It also runs a goroutine that starts up of grpc server, and the background workers.
 func StartApp() {
 
 	echoInstance := echo.New()
@@ -38,38 +38,64 @@ func StartApp() {
 	p := prometheus.NewPrometheus("echo", nil)
 	p.Use(echoInstance)
 
-	echoInstance.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-		Format: "method=${method}, uri=${uri}, status=${status}, latency_human=${latency_human}",
-	}))
+	log, err := loadConfig()
+	if err != nil {
+		// the logger is configured by the configuration, so the standard one reports it
+		logrus.Fatal(errors.Wrap(err, "load configuration"))
+	}
+
+	echoInstance.IPExtractor = newIPExtractor()
+	echoInstance.Use(logger.EchoRequestID())
+	echoInstance.Use(logger.EchoLogger(log))
 	echoInstance.Use(middleware.Recover())
 
-	if err := envconfig.Process("LIST", &recordtype.Cfg); err != nil {
-		err = errors.Wrap(err, "parse environment variables")
-		return
+	db := connectDB()
+	migration.Init(db, log)
+
+	locationRepository := repository.NewLocationRepository(db, log)
+	locationHistoryRepository := repository.NewLocationHistoryRepository(db, log)
+	apiKeyRepository := repository.NewApiKeyRepository(db, log)
+	locationFilterStateRepository := repository.NewLocationFilterStateRepository(db, log)
+	tripRepository := repository.NewTripRepository(db, log)
+	stayRepository := repository.NewStayRepository(db, log)
+	encounterRepository := repository.NewEncounterRepository(db, log)
+	jobCheckpointRepository := repository.NewJobCheckpointRepository(db, log)
+	locationService := service.NewLocationService(locationRepository, locationHistoryRepository, locationFilterStateRepository, tripRepository, stayRepository, encounterRepository, jobCheckpointRepository, log)
+	locationController := controller.NewLocationController(locationService, log)
+
+	errorHandlerMiddle := middleKit.NewErrorHandlerMiddleware()
+
+	authenticators, err := newAuthenticators(apiKeyRepository)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "initialize authentication"))
 	}
 
-	db := pgKit.NewPgDB(&pg.Options{
-		Addr:     fmt.Sprintf("%s:%d", recordtype.Cfg.DBHost, recordtype.Cfg.DBPort),
-		User:     recordtype.Cfg.DBUser,
-		Password: recordtype.Cfg.DBPass,
-		Database: recordtype.Cfg.DBName,
-	})
-	migration.Init(db)
+	var middlewares []echo.MiddlewareFunc
+	var interceptors []grpc.UnaryServerInterceptor
+	if authenticators != nil {
+		middlewares = append(middlewares, auth.EchoMiddleware(*authenticators))
+		interceptors = append(interceptors, auth.UnaryServerInterceptor(*authenticators))
+	}
 
-	locationRepository := repository.NewLocationRepository(db)
-	locationHistoryRepository := repository.NewLocationHistoryRepository(db)
-	locationService := service.NewLocationService(locationRepository, locationHistoryRepository)
-	locationController := controller.NewLocationController(locationService)
+	middlewares = append(middlewares, auth.TenantEchoMiddleware())
+	interceptors = append(interceptors, auth.TenantUnaryServerInterceptor())
 
-	errorHandlerMiddle := middleKit.NewErrorHandlerMiddleware()
+	limits := newLimits()
+	middlewares = append(middlewares, ratelimit.EchoMiddleware(limits))
+	interceptors = append(interceptors, ratelimit.UnaryServerInterceptor(limits))
 
-	r := router.NewRouter(echoInstance, locationController, errorHandlerMiddle)
+	r := router.NewRouter(echoInstance, locationController, errorHandlerMiddle, middlewares...)
 	r.Init()
 
 	go func() {
-		log.Infof(grpcserver.GrpcServe(locationService, echoInstance.AcquireContext()).Error())
+		log.Error(grpcserver.GrpcServe(locationService, echoInstance.AcquireContext(), log, interceptors...).Error())
 	}()
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go runWorker(workerCtx, "partitions", config.Cfg.PartitionInterval, log, partitionTask(locationService))
+	go runWorker(workerCtx, "retention", config.Cfg.RetentionInterval, log, pruneTask(locationService, log))
+
 	// Start server
 	go func() {
 		if err := echoInstance.Start(":8080"); err != nil && err != http.ErrServerClosed {
@@ -0,0 +1,182 @@
+package appconfig
+
+import (
+	"fmt"
+	"github.com/go-pg/pg/v10"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/labstack/echo/v4"
+	pgKit "github.com/oboadagd/kit-go/postgresql"
+	"github.com/oboadagd/location-common/recordtype"
+	"github.com/oboadagd/location-history-mgmt/auth"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/logger"
+	"github.com/oboadagd/location-history-mgmt/ratelimit"
+	"github.com/oboadagd/location-history-mgmt/repository"
+	"github.com/oboadagd/location-history-mgmt/service"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"net"
+)
+
+// loadConfig parses the shared and the microservice specific configuration from the
+// environment and returns the logger configured by them.
+func loadConfig() (*logrus.Logger, error) {
+	if err := envconfig.Process("LIST", &recordtype.Cfg); err != nil {
+		return nil, errors.Wrap(err, "parse environment variables")
+	}
+
+	if err := envconfig.Process("LIST", &config.Cfg); err != nil {
+		return nil, errors.Wrap(err, "parse environment variables")
+	}
+
+	if !service.IsCurrentLocationPolicy(config.Cfg.CurrentLocationPolicy) {
+		return nil, errors.Errorf("unknown current location policy %q", config.Cfg.CurrentLocationPolicy)
+	}
+
+	if !service.IsOutlierPolicy(config.Cfg.OutlierPolicy) {
+		return nil, errors.Errorf("unknown outlier policy %q", config.Cfg.OutlierPolicy)
+	}
+
+	for mode, policy := range config.Cfg.OutlierPolicies {
+		if !service.IsOutlierPolicy(policy) {
+			return nil, errors.Errorf("unknown outlier policy %q of transport mode %s", policy, mode)
+		}
+	}
+
+	if !service.IsDistanceAlgorithm(config.Cfg.DistanceAlgorithm) {
+		return nil, errors.Errorf("unknown distance algorithm %q", config.Cfg.DistanceAlgorithm)
+	}
+
+	for tenantId, algorithm := range config.Cfg.DistanceAlgorithms {
+		if !service.IsDistanceAlgorithm(algorithm) {
+			return nil, errors.Errorf("unknown distance algorithm %q of tenant %s", algorithm, tenantId)
+		}
+	}
+
+	if config.Cfg.TripDwellTime <= 0 || config.Cfg.TripGapTime <= 0 {
+		return nil, errors.Errorf("trip dwell time %v and gap time %v must be positive", config.Cfg.TripDwellTime, config.Cfg.TripGapTime)
+	}
+
+	if config.Cfg.StayRadius <= 0 || config.Cfg.StayMinDuration <= 0 || config.Cfg.PlaceRadius <= 0 {
+		return nil, errors.Errorf("stay radius %v, stay minimum duration %v and place radius %v must be positive",
+			config.Cfg.StayRadius, config.Cfg.StayMinDuration, config.Cfg.PlaceRadius)
+	}
+
+	if config.Cfg.EncounterDistance <= 0 || config.Cfg.EncounterMinDuration <= 0 || config.Cfg.EncounterMaxGap <= 0 {
+		return nil, errors.Errorf("encounter distance %v, encounter minimum duration %v and encounter maximum gap %v must be positive",
+			config.Cfg.EncounterDistance, config.Cfg.EncounterMinDuration, config.Cfg.EncounterMaxGap)
+	}
+
+	if config.Cfg.LocationMaxAge < 0 {
+		return nil, errors.Errorf("location maximum age %v must not be negative", config.Cfg.LocationMaxAge)
+	}
+
+	if config.Cfg.SnapshotMaxStaleness <= 0 {
+		return nil, errors.Errorf("snapshot maximum staleness %v must be positive", config.Cfg.SnapshotMaxStaleness)
+	}
+
+	if config.Cfg.RetentionPeriod < 0 || config.Cfg.RetentionInterval < 0 {
+		return nil, errors.Errorf("retention period %v and retention interval %v must not be negative",
+			config.Cfg.RetentionPeriod, config.Cfg.RetentionInterval)
+	}
+
+	for key, period := range config.Cfg.RetentionPeriods {
+		if period < 0 {
+			return nil, errors.Errorf("retention period %v of %s must not be negative", period, key)
+		}
+	}
+
+	if !service.IsRetentionMode(config.Cfg.RetentionMode) {
+		return nil, errors.Errorf("unknown retention mode %q", config.Cfg.RetentionMode)
+	}
+
+	// the location history has no default partition, so records of months without a
+	// partition can't be stored and the worker can't be disabled
+	if config.Cfg.PartitionMonthsAhead < 1 || config.Cfg.PartitionInterval <= 0 {
+		return nil, errors.Errorf("partition months ahead %d and partition interval %v must be positive",
+			config.Cfg.PartitionMonthsAhead, config.Cfg.PartitionInterval)
+	}
+
+	for _, cidr := range config.Cfg.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, errors.Wrapf(err, "parse trusted proxy %q", cidr)
+		}
+	}
+
+	log, err := logger.New(config.Cfg.LogLevel, config.Cfg.LogFormat)
+	if err != nil {
+		return nil, errors.Wrap(err, "initialize logger")
+	}
+
+	return log, nil
+}
+
+// connectDB returns the connection to the relational database.
+func connectDB() *pg.DB {
+	return pgKit.NewPgDB(&pg.Options{
+		Addr:     fmt.Sprintf("%s:%d", recordtype.Cfg.DBHost, recordtype.Cfg.DBPort),
+		User:     recordtype.Cfg.DBUser,
+		Password: recordtype.Cfg.DBPass,
+		Database: recordtype.Cfg.DBName,
+	})
+}
+
+// newAuthenticators returns the authenticators configured in the environment, or nil
+// when authentication is disabled. Bearer tokens are validated with the HMAC key if it
+// is set, otherwise with the JWKS file.
+func newAuthenticators(apiKeyRepository repository.ApiKeyRepositoryInterface) (*auth.Authenticators, error) {
+	if !config.Cfg.AuthEnabled {
+		return nil, nil
+	}
+
+	authenticators := &auth.Authenticators{
+		ApiKey: auth.NewApiKeyAuthenticator(apiKeyRepository),
+	}
+
+	switch {
+	case config.Cfg.AuthJWTHMACKey != "":
+		authenticators.Bearer = auth.NewHMACAuthenticator([]byte(config.Cfg.AuthJWTHMACKey),
+			config.Cfg.AuthJWTIssuer, config.Cfg.AuthJWTAudience)
+	case config.Cfg.AuthJWKSFile != "":
+		a, err := auth.NewJWKSAuthenticator(config.Cfg.AuthJWKSFile, config.Cfg.AuthJWTIssuer, config.Cfg.AuthJWTAudience)
+		if err != nil {
+			return nil, err
+		}
+		authenticators.Bearer = a
+	}
+
+	return authenticators, nil
+}
+
+// newLimits returns the rate limits configured in the environment. A limit with zero
+// requests per second is disabled.
+func newLimits() ratelimit.Limits {
+	var limits ratelimit.Limits
+
+	if config.Cfg.RateLimitUserRPS > 0 {
+		limits.UserName = ratelimit.NewLimiter(config.Cfg.RateLimitUserRPS, config.Cfg.RateLimitUserBurst)
+	}
+
+	if config.Cfg.RateLimitClientRPS > 0 {
+		limits.Client = ratelimit.NewLimiter(config.Cfg.RateLimitClientRPS, config.Cfg.RateLimitClientBurst)
+	}
+
+	return limits
+}
+
+// newIPExtractor returns the extractor of the remote address of http requests, which
+// keys the client rate limit of unauthenticated requests. The X-Forwarded-For header is
+// only trusted when sent by the configured proxies, so callers can't spoof their address.
+func newIPExtractor() echo.IPExtractor {
+	if len(config.Cfg.TrustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range config.Cfg.TrustedProxies {
+		_, ipNet, _ := net.ParseCIDR(cidr)
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,413 @@
+package appconfig
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-pg/pg/v10"
+	"github.com/oboadagd/location-history-mgmt/auth"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/migration"
+	"github.com/oboadagd/location-history-mgmt/repository"
+	"github.com/oboadagd/location-history-mgmt/service"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/sirupsen/logrus"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// command is a maintenance task run from the command line instead of starting the
+// microservice. It receives the arguments that follow the command name.
+type command struct {
+	usage   string                                                           // arguments description
+	minArgs int                                                              // minimum number of arguments
+	maxArgs int                                                              // maximum number of arguments
+	run     func(ctx context.Context, deps commandDeps, args []string) error // task
+}
+
+// commandDeps are the dependencies available to commands.
+type commandDeps struct {
+	db  *pg.DB         // available database
+	log *logrus.Logger // structured logger
+}
+
+// commands are the maintenance tasks by name.
+var commands = map[string]command{
+	"create-api-key": {
+		usage:   "<clientId> [scope,...] [tenantId]",
+		minArgs: 1,
+		maxArgs: 3,
+		run:     createApiKey,
+	},
+	"backfill-trips": {
+		usage:   "[tenantId] [username]",
+		minArgs: 0,
+		maxArgs: 2,
+		run:     backfillTrips,
+	},
+	"backfill-stays": {
+		usage:   "[tenantId] [username]",
+		minArgs: 0,
+		maxArgs: 2,
+		run:     backfillStays,
+	},
+	"rebuild-rollups": {
+		usage:   "[tenantId] [username]",
+		minArgs: 0,
+		maxArgs: 2,
+		run:     rebuildRollups,
+	},
+	"repair-locations": {
+		usage:   "[tenantId] [username]",
+		minArgs: 0,
+		maxArgs: 2,
+		run:     repairLocations,
+	},
+	"check-locations": {
+		usage:   "[tenantId]",
+		minArgs: 0,
+		maxArgs: 1,
+		run:     checkLocations,
+	},
+	"recompute-distances": {
+		usage:   "<dry-run|fix> [tenantId] [username]",
+		minArgs: 1,
+		maxArgs: 3,
+		run:     recomputeDistances,
+	},
+	"prune-history": {
+		usage:   "<dry-run|prune> [tenantId] [username]",
+		minArgs: 1,
+		maxArgs: 3,
+		run:     pruneHistory,
+	},
+	"create-partitions": {
+		usage:   "",
+		minArgs: 0,
+		maxArgs: 0,
+		run:     createPartitions,
+	},
+	"prune-partitions": {
+		usage:   "<dry-run|prune>",
+		minArgs: 1,
+		maxArgs: 1,
+		run:     prunePartitions,
+	},
+}
+
+// RunCommand runs the maintenance task named by args[0] with the remaining arguments.
+func RunCommand(args []string) error {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage())
+	}
+
+	if len(args)-1 < cmd.minArgs || len(args)-1 > cmd.maxArgs {
+		return fmt.Errorf("usage: %s %s", args[0], cmd.usage)
+	}
+
+	log, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	db := connectDB()
+	defer db.Close()
+	migration.Init(db, log)
+
+	return cmd.run(context.Background(), commandDeps{db: db, log: log}, args[1:])
+}
+
+// usage returns the usage of every command.
+func usage() string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("commands:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %s %s\n", name, commands[name].usage)
+	}
+
+	return b.String()
+}
+
+// createApiKey generates an api key for a calling service, optionally bound to a tenant,
+// stores its hash and prints the key. The key can't be recovered afterwards.
+func createApiKey(ctx context.Context, deps commandDeps, args []string) error {
+	var scopes []string
+	if len(args) > 1 && args[1] != "" {
+		scopes = strings.Split(args[1], ",")
+	}
+
+	var tenantId string
+	if len(args) > 2 {
+		tenantId = args[2]
+	}
+
+	key, err := auth.GenerateApiKey()
+	if err != nil {
+		return err
+	}
+
+	apiKey := dto.ApiKey{
+		ClientId:  args[0],
+		KeyHash:   auth.HashApiKey(key),
+		Scopes:    scopes,
+		TenantId:  tenantId,
+		CreatedAt: time.Now(),
+	}
+
+	if err := repository.NewApiKeyRepository(deps.db, deps.log).Create(ctx, apiKey); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, key)
+	return err
+}
+
+// backfillTrips rebuilds the trips of a username, or of every username, of a tenant from
+// their location history and prints the number of stored trips.
+func backfillTrips(ctx context.Context, deps commandDeps, args []string) error {
+	return runBackfill(ctx, deps, args, "trips", service.LocationServiceInterface.BackfillTrips)
+}
+
+// backfillStays rebuilds the stays of a username, or of every username, of a tenant from
+// their location history and prints the number of stored stays.
+func backfillStays(ctx context.Context, deps commandDeps, args []string) error {
+	return runBackfill(ctx, deps, args, "stays", service.LocationServiceInterface.BackfillStays)
+}
+
+// rebuildRollups rebuilds the hourly distance rollups of a username, or of every username,
+// of a tenant from their location history and prints the number of stored rollups.
+func rebuildRollups(ctx context.Context, deps commandDeps, args []string) error {
+	return runBackfill(ctx, deps, args, "rollups", service.LocationServiceInterface.RebuildDistanceRollups)
+}
+
+// repairLocations rebuilds the current location of a username, or of every username, of a
+// tenant from their latest location history and prints the number of stored locations.
+func repairLocations(ctx context.Context, deps commandDeps, args []string) error {
+	return runBackfill(ctx, deps, args, "locations", service.LocationServiceInterface.RepairLocations)
+}
+
+// checkLocations prints the usernames of a tenant whose current location diverges from
+// their latest location history, and fails if there is any.
+func checkLocations(ctx context.Context, deps commandDeps, args []string) error {
+	ctx, err := commandTenant(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	divergences, err := newLocationService(deps).CheckLocations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range divergences {
+		if _, err := fmt.Fprintf(os.Stdout, "%s %s location %s history %s\n", d.UserName, d.DeviceId,
+			coordinates(d.Latitude, d.Longitude), coordinates(d.HistoryLatitude, d.HistoryLongitude)); err != nil {
+			return err
+		}
+	}
+
+	if len(divergences) > 0 {
+		return fmt.Errorf("%d divergent locations", len(divergences))
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, "0 divergent locations")
+	return err
+}
+
+// recomputeDistances recomputes the location history distances of a username, or of every
+// username, of a tenant and prints the wrong distances and diverging locations found. A
+// fix run corrects them, while a dry run fails if there is any. Runs interrupted by an
+// error resume after the last completed username when run again with the same arguments.
+func recomputeDistances(ctx context.Context, deps commandDeps, args []string) error {
+	if args[0] != "dry-run" && args[0] != "fix" {
+		return fmt.Errorf("invalid mode %q, expected dry-run or fix", args[0])
+	}
+
+	ctx, err := commandTenant(ctx, args[1:])
+	if err != nil {
+		return err
+	}
+
+	request := dto.RecomputeDistancesRequest{DryRun: args[0] == "dry-run"}
+	if len(args) > 2 {
+		request.UserName = args[2]
+	}
+
+	resp, err := newLocationService(deps).RecomputeDistances(ctx, request)
+	if resp != nil {
+		for _, d := range resp.Discrepancies {
+			if _, err := fmt.Fprintf(os.Stdout, "%s %s history %d at %s distance %v recomputed %v with %s\n", d.UserName, d.DeviceId,
+				d.LocationHistoryId, d.UpdatedAt.Format(time.RFC3339), d.Stored, d.Recomputed, d.Algorithm); err != nil {
+				return err
+			}
+		}
+
+		for _, d := range resp.Divergences {
+			if _, err := fmt.Fprintf(os.Stdout, "%s %s location %s history %s\n", d.UserName, d.DeviceId,
+				coordinates(d.Latitude, d.Longitude), coordinates(d.HistoryLatitude, d.HistoryLongitude)); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(os.Stdout, "%d usernames %d records checked, %d wrong distances %d divergent locations found\n",
+			resp.UserNames, resp.Records, len(resp.Discrepancies), len(resp.Divergences)); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if request.DryRun && len(resp.Discrepancies)+len(resp.Divergences) > 0 {
+		return fmt.Errorf("%d wrong distances %d divergent locations", len(resp.Discrepancies), len(resp.Divergences))
+	}
+
+	return nil
+}
+
+// pruneHistory prunes the location history of a username, or of every username, of a
+// tenant older than their retention period and prints the pruned days. A dry run prints
+// the days to prune without pruning them.
+func pruneHistory(ctx context.Context, deps commandDeps, args []string) error {
+	if args[0] != "dry-run" && args[0] != "prune" {
+		return fmt.Errorf("invalid mode %q, expected dry-run or prune", args[0])
+	}
+
+	ctx, err := commandTenant(ctx, args[1:])
+	if err != nil {
+		return err
+	}
+
+	request := dto.PruneHistoryRequest{DryRun: args[0] == "dry-run"}
+	if len(args) > 2 {
+		request.UserName = args[2]
+	}
+
+	resp, err := newLocationService(deps).PruneHistory(ctx, request)
+	if resp != nil {
+		for _, u := range resp.UserNames {
+			for _, d := range u.Days {
+				if _, err := fmt.Fprintf(os.Stdout, "%s %s %d records\n", u.UserName, d.Day.Format("2006-01-02"), d.Records); err != nil {
+					return err
+				}
+			}
+		}
+
+		verb := "pruned"
+		if request.DryRun {
+			verb = "to prune"
+		}
+		if _, err := fmt.Fprintf(os.Stdout, "%d usernames %d records %s by %s\n", len(resp.UserNames), resp.Records, verb, resp.Mode); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// createPartitions creates the location history partitions of the current month and of
+// the months ahead configured, and prints their names.
+func createPartitions(ctx context.Context, deps commandDeps, _ []string) error {
+	names, err := newLocationService(deps).MaintainPartitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if _, err := fmt.Fprintln(os.Stdout, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// prunePartitions drops the location history partitions expired for every tenant and
+// username and prints them. A dry run prints the partitions to drop, with their estimated
+// number of records, without dropping them.
+func prunePartitions(ctx context.Context, deps commandDeps, args []string) error {
+	if args[0] != "dry-run" && args[0] != "prune" {
+		return fmt.Errorf("invalid mode %q, expected dry-run or prune", args[0])
+	}
+
+	partitions, err := newLocationService(deps).PrunePartitions(ctx, args[0] == "dry-run")
+	for _, p := range partitions {
+		if _, err := fmt.Fprintf(os.Stdout, "%s %s %d records\n", p.Name, p.Month.Format("2006-01"), p.Records); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// coordinates formats the coordinates of a divergence, which are missing from the table
+// without a record.
+func coordinates(lat *float64, lng *float64) string {
+	if lat == nil || lng == nil {
+		return "missing"
+	}
+	return fmt.Sprintf("%v,%v", *lat, *lng)
+}
+
+// runBackfill runs backfill for the tenant and the optional username of args and prints
+// the number of stored records of kind. The tenant defaults to the default tenant.
+// Locations saved while it runs may be processed twice, so it's meant to run when the
+// affected usernames don't report locations.
+func runBackfill(ctx context.Context, deps commandDeps, args []string, kind string,
+	backfill func(s service.LocationServiceInterface, ctx context.Context, userName string) (int, error)) error {
+	ctx, err := commandTenant(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	var userName string
+	if len(args) > 1 {
+		userName = args[1]
+	}
+
+	n, err := backfill(newLocationService(deps), ctx, userName)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(os.Stdout, "%d %s stored\n", n, kind)
+	return err
+}
+
+// commandTenant returns ctx carrying the tenant of args[0], which defaults to the default
+// tenant.
+func commandTenant(ctx context.Context, args []string) (context.Context, error) {
+	tenantId := config.Cfg.DefaultTenant
+	if len(args) > 0 && args[0] != "" {
+		tenantId = args[0]
+	}
+
+	if !tenant.IsValid(tenantId) {
+		return ctx, fmt.Errorf("invalid tenant %q", tenantId)
+	}
+
+	return tenant.WithTenant(ctx, tenantId), nil
+}
+
+// newLocationService initializes the Location service layer for commands.
+func newLocationService(deps commandDeps) service.LocationServiceInterface {
+	return service.NewLocationService(
+		repository.NewLocationRepository(deps.db, deps.log),
+		repository.NewLocationHistoryRepository(deps.db, deps.log),
+		repository.NewLocationFilterStateRepository(deps.db, deps.log),
+		repository.NewTripRepository(deps.db, deps.log),
+		repository.NewStayRepository(deps.db, deps.log),
+		repository.NewEncounterRepository(deps.db, deps.log),
+		repository.NewJobCheckpointRepository(deps.db, deps.log),
+		deps.log,
+	)
+}
@@ -0,0 +1,52 @@
+package appconfig
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/service"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// runWorker runs task at start and then every interval until ctx is done, logging its
+// failures. A zero interval disables the worker.
+func runWorker(ctx context.Context, name string, interval time.Duration, log *logrus.Logger, task func(ctx context.Context) error) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := task(ctx); err != nil {
+			log.WithField("worker", name).WithError(err).Error("worker run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pruneTask returns the task of the retention worker, which prunes the location history
+// of every tenant.
+func pruneTask(locationService service.LocationServiceInterface, log *logrus.Logger) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		pruned, err := locationService.PruneTenants(ctx)
+		if pruned > 0 {
+			log.WithField("worker", "retention").WithField("records", pruned).Info("history pruned")
+		}
+		return err
+	}
+}
+
+// partitionTask returns the task of the partition maintenance worker, which creates the
+// location history partitions of the coming months.
+func partitionTask(locationService service.LocationServiceInterface) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := locationService.MaintainPartitions(ctx)
+		return err
+	}
+}
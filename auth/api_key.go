@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/oboadagd/location-history-mgmt/repository"
+)
+
+// apiKeyBytes is the number of random bytes of generated api keys.
+const apiKeyBytes = 32
+
+// ApiKeyAuthenticator validates api keys of service to service calls against the
+// hashes stored by the ApiKey repository.
+type ApiKeyAuthenticator struct {
+	apiKeyRepository repository.ApiKeyRepositoryInterface // ApiKey repository interface
+}
+
+// NewApiKeyAuthenticator initializes an ApiKeyAuthenticator.
+func NewApiKeyAuthenticator(apiKeyRepository repository.ApiKeyRepositoryInterface) *ApiKeyAuthenticator {
+	return &ApiKeyAuthenticator{
+		apiKeyRepository,
+	}
+}
+
+// Authenticate returns the service that owns key. Returns an error if the key doesn't
+// exist or was revoked.
+func (a *ApiKeyAuthenticator) Authenticate(ctx context.Context, key string) (*Principal, error) {
+	k, err := a.apiKeyRepository.GetByHash(ctx, HashApiKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Principal{
+		Subject:  k.ClientId,
+		Scopes:   k.Scopes,
+		TenantId: k.TenantId,
+	}, nil
+}
+
+// GenerateApiKey returns a new random api key. Only its hash must be stored.
+func GenerateApiKey() (string, error) {
+	b := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// HashApiKey returns the hex encoded SHA-256 hash of key.
+func HashApiKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// Authenticator validates a credential and returns the caller it belongs to.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*Principal, error)
+}
+
+// Authenticators groups the authenticator of each credential type. A nil authenticator
+// rejects its credential type.
+type Authenticators struct {
+	Bearer Authenticator // validates JWT bearer tokens
+	ApiKey Authenticator // validates api keys
+}
+
+// Authenticate validates the bearer token, or the api key when there is no bearer
+// token, and returns the caller. Returns an unauthenticated error if no credential is
+// given or it is not valid.
+func (a Authenticators) Authenticate(ctx context.Context, bearer string, apiKey string) (*Principal, error) {
+	var authenticator Authenticator
+	var credential string
+
+	switch {
+	case bearer != "":
+		authenticator, credential = a.Bearer, bearer
+	case apiKey != "":
+		authenticator, credential = a.ApiKey, apiKey
+	default:
+		return nil, unauthenticatedError(errors.New("no credentials"))
+	}
+
+	if authenticator == nil {
+		return nil, unauthenticatedError(errors.New("credential type not supported"))
+	}
+
+	p, err := authenticator.Authenticate(ctx, credential)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// TenantClaim is the claim that binds a token to a tenant.
+const TenantClaim = "tenant"
+
+// JWTAuthenticator validates JWT bearer tokens. Tokens are verified either with a shared
+// HMAC key or with the public keys of a JWKS file, never with both, so a token signed
+// with HMAC can't be validated with a public key used as secret.
+type JWTAuthenticator struct {
+	hmacKey  []byte                      // key of HMAC signed tokens
+	keys     map[string]crypto.PublicKey // public keys of RSA and ECDSA signed tokens by key id
+	issuer   string                      // required iss claim. Empty skips the check
+	audience string                      // required aud claim. Empty skips the check
+}
+
+// jwk is a JSON web key of a JWKS file. Only RSA and EC public keys are supported.
+type jwk struct {
+	Kty string `json:"kty"` // key type, RSA or EC
+	Kid string `json:"kid"` // key id
+	N   string `json:"n"`   // RSA modulus
+	E   string `json:"e"`   // RSA public exponent
+	Crv string `json:"crv"` // EC curve
+	X   string `json:"x"`   // EC x coordinate
+	Y   string `json:"y"`   // EC y coordinate
+}
+
+// NewHMACAuthenticator initializes a JWTAuthenticator of HMAC signed tokens.
+func NewHMACAuthenticator(key []byte, issuer string, audience string) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		hmacKey:  key,
+		issuer:   issuer,
+		audience: audience,
+	}
+}
+
+// NewJWKSAuthenticator initializes a JWTAuthenticator of RSA and ECDSA signed tokens
+// with the public keys of the JWKS file located at path.
+func NewJWKSAuthenticator(path string, issuer string, audience string) (*JWTAuthenticator, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, fmt.Errorf("parse jwks file %s: %w", path, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		pk, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("parse jwks key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = pk
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("jwks file %s has no keys", path)
+	}
+
+	return &JWTAuthenticator{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+	}, nil
+}
+
+// Authenticate validates the signature, expiration, issuer and audience of token and
+// returns the caller identified by its sub claim. Scopes are taken from the space
+// separated scope claim or from the scp array claim, and the tenant from TenantClaim.
+func (a *JWTAuthenticator) Authenticate(_ context.Context, token string) (*Principal, error) {
+	parsed, err := jwt.Parse(token, a.key)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("unexpected claims")
+	}
+
+	now := time.Now().Unix()
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("token without exp claim")
+	}
+
+	if a.issuer != "" && !claims.VerifyIssuer(a.issuer, true) {
+		return nil, errors.New("token issuer not accepted")
+	}
+
+	if a.audience != "" && !claims.VerifyAudience(a.audience, true) {
+		return nil, errors.New("token audience not accepted")
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, errors.New("token without sub claim")
+	}
+
+	tenantId, _ := claims[TenantClaim].(string)
+
+	return &Principal{
+		Subject:  sub,
+		Scopes:   scopes(claims),
+		TenantId: tenantId,
+	}, nil
+}
+
+// key returns the key that verifies the signature of token.
+func (a *JWTAuthenticator) key(token *jwt.Token) (interface{}, error) {
+	if a.hmacKey != nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return a.hmacKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	pk, ok := a.keys[kid]
+	if !ok && kid == "" && len(a.keys) == 1 {
+		for _, k := range a.keys {
+			pk, ok = k, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	switch pk.(type) {
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+	case *ecdsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+	}
+
+	return pk, nil
+}
+
+// scopes returns the scopes granted by claims.
+func scopes(claims jwt.MapClaims) []string {
+	if s, ok := claims["scope"].(string); ok {
+		return strings.Fields(s)
+	}
+
+	var res []string
+	if scp, ok := claims["scp"].([]interface{}); ok {
+		for _, s := range scp {
+			if str, ok := s.(string); ok {
+				res = append(res, str)
+			}
+		}
+	}
+
+	return res
+}
+
+// publicKey returns the public key described by k.
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHMACAuthenticator(t *testing.T) {
+	nameTest := "TestHMACAuthenticator"
+	key := []byte("secret")
+	a := NewHMACAuthenticator(key, "issuer", "location-history-mgmt")
+
+	sign := func(method jwt.SigningMethod, k interface{}, claims jwt.MapClaims) string {
+		s, err := jwt.NewWithClaims(method, claims).SignedString(k)
+		if err != nil {
+			t.Fatalf("%s: %v", nameTest, err)
+		}
+		return s
+	}
+
+	valid := jwt.MapClaims{
+		"sub":   "usernamesample",
+		"iss":   "issuer",
+		"aud":   "location-history-mgmt",
+		"scope": "read write",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	expired := jwt.MapClaims{"sub": "usernamesample", "iss": "issuer", "aud": "location-history-mgmt", "exp": time.Now().Add(-time.Hour).Unix()}
+	noExp := jwt.MapClaims{"sub": "usernamesample", "iss": "issuer", "aud": "location-history-mgmt"}
+	otherAud := jwt.MapClaims{"sub": "usernamesample", "iss": "issuer", "aud": "other", "exp": time.Now().Add(time.Hour).Unix()}
+
+	type test struct {
+		token  string
+		answer bool
+	}
+
+	tests := []test{
+		{sign(jwt.SigningMethodHS256, key, valid), true},
+		{sign(jwt.SigningMethodHS256, []byte("other"), valid), false},
+		{sign(jwt.SigningMethodHS256, key, expired), false},
+		{sign(jwt.SigningMethodHS256, key, noExp), false},
+		{sign(jwt.SigningMethodHS256, key, otherAud), false},
+		{"not a token", false},
+	}
+
+	for i, v := range tests {
+		p, err := a.Authenticate(context.Background(), v.token)
+
+		if (err == nil) != v.answer {
+			t.Errorf("%s: test %d expected %v but got %v", nameTest, i, v.answer, err)
+			return
+		}
+
+		if err == nil && (p.Subject != "usernamesample" || !p.HasScope("write")) {
+			t.Errorf("%s: test %d unexpected principal %+v", nameTest, i, p)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestJWKSAuthenticator(t *testing.T) {
+	nameTest := "TestJWKSAuthenticator"
+
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key1",
+			"n":   base64.RawURLEncoding.EncodeToString(pk.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pk.E)).Bytes()),
+		}},
+	}
+	b, _ := json.Marshal(jwks)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	a, err := NewJWKSAuthenticator(path, "", "")
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	claims := jwt.MapClaims{"sub": "service1", "scp": []string{ScopeService}, "exp": time.Now().Add(time.Hour).Unix()}
+
+	signed := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	signed.Header["kid"] = "key1"
+	rsToken, _ := signed.SignedString(pk)
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	unknown.Header["kid"] = "key2"
+	unknownToken, _ := unknown.SignedString(pk)
+
+	// a token signed with HMAC using the public modulus as secret must be rejected
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	confused.Header["kid"] = "key1"
+	confusedToken, _ := confused.SignedString(pk.N.Bytes())
+
+	type test struct {
+		token  string
+		answer bool
+	}
+
+	tests := []test{
+		{rsToken, true},
+		{unknownToken, false},
+		{confusedToken, false},
+	}
+
+	for i, v := range tests {
+		p, err := a.Authenticate(context.Background(), v.token)
+
+		if (err == nil) != v.answer {
+			t.Errorf("%s: test %d expected %v but got %v", nameTest, i, v.answer, err)
+			return
+		}
+
+		if err == nil && (p.Subject != "service1" || !p.CanAccess("usernamesample")) {
+			t.Errorf("%s: test %d unexpected principal %+v", nameTest, i, p)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
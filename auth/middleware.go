@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// Names of the http header and grpc metadata key that carry an api key.
+const (
+	HeaderApiKey   = "X-Api-Key"
+	MetadataApiKey = "x-api-key"
+)
+
+// metadataAuthorization is the grpc metadata key that carries a bearer token.
+const metadataAuthorization = "authorization"
+
+// bearerPrefix is the prefix of bearer tokens in authorization values.
+const bearerPrefix = "bearer "
+
+// EchoMiddleware returns the echo middleware that authenticates every request with
+// authenticators and stores the caller in the request context. It must be registered
+// after the error handler middleware so rejections are rendered as error responses.
+func EchoMiddleware(authenticators Authenticators) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			p, err := authenticators.Authenticate(req.Context(),
+				bearerToken(req.Header.Get(echo.HeaderAuthorization)), req.Header.Get(HeaderApiKey))
+			if err != nil {
+				return err
+			}
+
+			c.SetRequest(req.WithContext(WithPrincipal(req.Context(), p)))
+			return next(c)
+		}
+	}
+}
+
+// UnaryServerInterceptor returns the grpc interceptor equivalent to EchoMiddleware. The
+// bearer token is read from the authorization metadata key and the api key from the
+// x-api-key metadata key.
+func UnaryServerInterceptor(authenticators Authenticators) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var bearer, apiKey string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(metadataAuthorization); len(v) > 0 {
+				bearer = bearerToken(v[0])
+			}
+			if v := md.Get(MetadataApiKey); len(v) > 0 {
+				apiKey = v[0]
+			}
+		}
+
+		p, err := authenticators.Authenticate(ctx, bearer, apiKey)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, errors.Cause(err).Error())
+		}
+
+		return handler(WithPrincipal(ctx, p), req)
+	}
+}
+
+// bearerToken returns the token of a bearer authorization value or an empty string.
+func bearerToken(authorization string) string {
+	if len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return strings.TrimSpace(authorization[len(bearerPrefix):])
+	}
+
+	return ""
+}
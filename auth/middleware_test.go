@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// apiKeyRepositoryStub is an in memory ApiKey repository.
+type apiKeyRepositoryStub map[string]dto.ApiKey
+
+func (r apiKeyRepositoryStub) Create(_ context.Context, apiKey dto.ApiKey) error {
+	r[apiKey.KeyHash] = apiKey
+	return nil
+}
+
+func (r apiKeyRepositoryStub) GetByHash(_ context.Context, keyHash string) (*dto.ApiKey, error) {
+	k, ok := r[keyHash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &k, nil
+}
+
+func TestEchoMiddleware(t *testing.T) {
+	nameTest := "TestEchoMiddleware"
+
+	key, _ := GenerateApiKey()
+	repo := apiKeyRepositoryStub{}
+	_ = repo.Create(context.Background(), dto.ApiKey{ClientId: "service1", KeyHash: HashApiKey(key), Scopes: []string{ScopeService}})
+
+	authenticators := Authenticators{
+		Bearer: NewHMACAuthenticator([]byte("secret"), "", ""),
+		ApiKey: NewApiKeyAuthenticator(repo),
+	}
+
+	type test struct {
+		header string
+		value  string
+		answer string
+	}
+
+	tests := []test{
+		{HeaderApiKey, key, "service1"},
+		{HeaderApiKey, "wrongkey", ""},
+		{echo.HeaderAuthorization, "Bearer invalid", ""},
+		{"", "", ""},
+	}
+
+	for i, v := range tests {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if v.header != "" {
+			req.Header.Set(v.header, v.value)
+		}
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		var got string
+		err := EchoMiddleware(authenticators)(func(c echo.Context) error {
+			p, _ := PrincipalFromContext(c.Request().Context())
+			got = p.Subject
+			return nil
+		})(c)
+
+		if (err == nil) != (v.answer != "") || got != v.answer {
+			t.Errorf("%s: test %d expected %v but got %v %v", nameTest, i, v.answer, got, err)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	nameTest := "TestUnaryServerInterceptor"
+	interceptor := UnaryServerInterceptor(Authenticators{Bearer: NewHMACAuthenticator([]byte("secret"), "", "")})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(metadataAuthorization, "Bearer invalid"))
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("%s: Expected %v but got %v", nameTest, codes.Unauthenticated, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,128 @@
+// Package auth implements authentication and authorization of the callers of
+// location-history-mgmt microservice. Callers authenticate with a JWT bearer token,
+// validated against an HMAC key or a local JWKS file, or with an api key whose hash is
+// stored in the relational database. A caller may only read and write the data of the
+// username that matches its subject, unless it is granted the admin or service scope.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"net/http"
+)
+
+// Scopes that grant access to the data of every username.
+const (
+	ScopeAdmin   = "admin"
+	ScopeService = "service"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject  string   // subject of the credential, the username of end users or the client id of services
+	Scopes   []string // scopes granted to the credential
+	TenantId string   // tenant the credential belongs to. Empty if the credential is not bound to a tenant
+}
+
+type principalKey struct{}
+
+// HasScope returns true if the principal was granted any of the given scopes.
+func (p *Principal) HasScope(scopes ...string) bool {
+	for _, granted := range p.Scopes {
+		for _, s := range scopes {
+			if granted == s {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// CanAccess returns true if the principal is allowed to read and write the data of
+// userName.
+func (p *Principal) CanAccess(userName string) bool {
+	return p.Subject == userName || p.HasScope(ScopeAdmin, ScopeService)
+}
+
+// WithPrincipal returns a copy of ctx that carries the authenticated caller.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the authenticated caller carried by ctx.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// Authorize returns a forbidden error if the caller carried by ctx is not allowed to
+// access the data of userName. It always succeeds when authentication is disabled.
+func Authorize(ctx context.Context, userName string) error {
+	if !config.Cfg.AuthEnabled {
+		return nil
+	}
+
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return unauthenticatedError(errors.New("no principal"))
+	}
+
+	if !p.CanAccess(userName) {
+		return respKit.NewGenericHttpError(http.StatusForbidden, enums.ErrorForbiddenCode,
+			fmt.Errorf(enums.ErrorForbiddenUserMsg, p.Subject, userName))
+	}
+
+	return nil
+}
+
+// OwnUserName returns the username whose own data the caller carried by ctx requests.
+// End users always act as their subject, whatever userName they request, while callers
+// granted the admin or service scope act on behalf of userName. It returns userName
+// when authentication is disabled.
+func OwnUserName(ctx context.Context, userName string) (string, error) {
+	if !config.Cfg.AuthEnabled {
+		return userName, nil
+	}
+
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return "", unauthenticatedError(errors.New("no principal"))
+	}
+
+	if p.HasScope(ScopeAdmin, ScopeService) {
+		return userName, nil
+	}
+
+	return p.Subject, nil
+}
+
+// AuthorizeScope returns a forbidden error if the caller carried by ctx was not granted
+// any of the given scopes. It always succeeds when authentication is disabled.
+func AuthorizeScope(ctx context.Context, scopes ...string) error {
+	if !config.Cfg.AuthEnabled {
+		return nil
+	}
+
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return unauthenticatedError(errors.New("no principal"))
+	}
+
+	if !p.HasScope(scopes...) {
+		return respKit.NewGenericHttpError(http.StatusForbidden, enums.ErrorForbiddenCode,
+			fmt.Errorf(enums.ErrorForbiddenScopeMsg, p.Subject, scopes))
+	}
+
+	return nil
+}
+
+// unauthenticatedError returns the error of a request without valid credentials.
+func unauthenticatedError(err error) error {
+	return respKit.NewGenericHttpError(http.StatusUnauthorized, enums.ErrorUnauthenticatedCode,
+		fmt.Errorf(enums.ErrorUnauthenticatedMsg, err))
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"net/http"
+	"testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	nameTest := "TestAuthorize"
+	defer func(enabled bool) { config.Cfg.AuthEnabled = enabled }(config.Cfg.AuthEnabled)
+
+	user := &Principal{Subject: "usernamesample"}
+	admin := &Principal{Subject: "operator", Scopes: []string{ScopeAdmin}}
+
+	type test struct {
+		enabled   bool
+		principal *Principal
+		userName  string
+		answer    int
+	}
+
+	tests := []test{
+		{false, nil, "usernamesample", 0},
+		{true, nil, "usernamesample", http.StatusUnauthorized},
+		{true, user, "usernamesample", 0},
+		{true, user, "otherusername", http.StatusForbidden},
+		{true, admin, "otherusername", 0},
+	}
+
+	for i, v := range tests {
+		config.Cfg.AuthEnabled = v.enabled
+		ctx := context.Background()
+		if v.principal != nil {
+			ctx = WithPrincipal(ctx, v.principal)
+		}
+
+		err := Authorize(ctx, v.userName)
+
+		status := 0
+		if httpErr, ok := err.(*respKit.GenericHttpError); ok {
+			status = httpErr.Status
+		}
+
+		if status != v.answer {
+			t.Errorf("%s: test %d expected %v but got %v", nameTest, i, v.answer, err)
+			return
+		}
+	}
+
+	config.Cfg.AuthEnabled = true
+	if err := AuthorizeScope(WithPrincipal(context.Background(), user), ScopeAdmin, ScopeService); err == nil {
+		t.Errorf("%s: Expected %v but got %v", nameTest, http.StatusForbidden, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestOwnUserName(t *testing.T) {
+	nameTest := "TestOwnUserName"
+	defer func(enabled bool) { config.Cfg.AuthEnabled = enabled }(config.Cfg.AuthEnabled)
+
+	user := &Principal{Subject: "usernamesample"}
+	service := &Principal{Subject: "client", Scopes: []string{ScopeService}}
+
+	type test struct {
+		enabled   bool
+		principal *Principal
+		userName  string
+		answer    string
+	}
+
+	tests := []test{
+		{false, nil, "otherusername", "otherusername"},
+		{true, user, "", "usernamesample"},
+		{true, user, "otherusername", "usernamesample"},
+		{true, service, "otherusername", "otherusername"},
+		{true, service, "", ""},
+	}
+
+	for i, v := range tests {
+		config.Cfg.AuthEnabled = v.enabled
+		ctx := context.Background()
+		if v.principal != nil {
+			ctx = WithPrincipal(ctx, v.principal)
+		}
+
+		userName, err := OwnUserName(ctx, v.userName)
+		if err != nil || userName != v.answer {
+			t.Errorf("%s: test %d expected %v but got %v %v", nameTest, i, v.answer, userName, err)
+			return
+		}
+	}
+
+	config.Cfg.AuthEnabled = true
+	if _, err := OwnUserName(context.Background(), "usernamesample"); err == nil {
+		t.Errorf("%s: Expected %v but got %v", nameTest, http.StatusUnauthorized, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
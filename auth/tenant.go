@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+// Names of the http header and grpc metadata key that carry the requested tenant.
+const (
+	HeaderTenant   = "X-Tenant-Id"
+	MetadataTenant = "x-tenant-id"
+)
+
+// ResolveTenant returns the tenant of a request given the requested one, which may be
+// empty. Credentials bound to a tenant always use it, and only admin callers may request
+// a different one. Authenticated callers not bound to a tenant may only request one when
+// they are granted the admin or service scope. When authentication is disabled the
+// requested tenant is trusted. Requests that don't resolve a tenant use the default one.
+func ResolveTenant(ctx context.Context, requested string) (string, error) {
+	if requested != "" && !tenant.IsValid(requested) {
+		return "", respKit.GenericBadRequestError(enums.ErrorTenantInvalidCode, fmt.Sprintf(enums.ErrorTenantInvalidMsg, requested))
+	}
+
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		if config.Cfg.AuthEnabled && requested != "" {
+			return "", unauthenticatedError(errors.New("no principal"))
+		}
+		return requested, nil
+	}
+
+	switch {
+	case requested == "" || requested == p.TenantId:
+		return p.TenantId, nil
+	case p.TenantId != "" && p.HasScope(ScopeAdmin):
+		return requested, nil
+	case p.TenantId == "" && p.HasScope(ScopeAdmin, ScopeService):
+		return requested, nil
+	}
+
+	return "", respKit.NewGenericHttpError(http.StatusForbidden, enums.ErrorTenantForbiddenCode,
+		fmt.Errorf(enums.ErrorTenantForbiddenMsg, p.Subject, requested))
+}
+
+// TenantEchoMiddleware returns the echo middleware that resolves the tenant of every
+// request from the caller credentials and the X-Tenant-Id header, and stores it in the
+// request context. It must be registered after the authentication middleware.
+func TenantEchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			tenantId, err := ResolveTenant(req.Context(), req.Header.Get(HeaderTenant))
+			if err != nil {
+				return err
+			}
+
+			if tenantId != "" {
+				c.SetRequest(req.WithContext(tenant.WithTenant(req.Context(), tenantId)))
+			}
+			return next(c)
+		}
+	}
+}
+
+// TenantUnaryServerInterceptor returns the grpc interceptor equivalent to
+// TenantEchoMiddleware. The requested tenant is read from the x-tenant-id metadata key.
+func TenantUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var requested string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(MetadataTenant); len(v) > 0 {
+				requested = v[0]
+			}
+		}
+
+		tenantId, err := ResolveTenant(ctx, requested)
+		if err != nil {
+			var code = codes.PermissionDenied
+			if httpErr, ok := errors.Cause(err).(*respKit.GenericHttpError); ok && httpErr.Status == http.StatusBadRequest {
+				code = codes.InvalidArgument
+			} else if ok && httpErr.Status == http.StatusUnauthorized {
+				code = codes.Unauthenticated
+			}
+			return nil, status.Error(code, err.Error())
+		}
+
+		if tenantId != "" {
+			ctx = tenant.WithTenant(ctx, tenantId)
+		}
+		return handler(ctx, req)
+	}
+}
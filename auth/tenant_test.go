@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"net/http"
+	"testing"
+)
+
+func TestResolveTenant(t *testing.T) {
+	nameTest := "TestResolveTenant"
+	defer func(enabled bool) { config.Cfg.AuthEnabled = enabled }(config.Cfg.AuthEnabled)
+
+	user := &Principal{Subject: "usernamesample", TenantId: "tenanta"}
+	admin := &Principal{Subject: "operator", TenantId: "tenanta", Scopes: []string{ScopeAdmin}}
+	service := &Principal{Subject: "service1", Scopes: []string{ScopeService}}
+	unbound := &Principal{Subject: "usernamesample"}
+
+	type test struct {
+		enabled   bool
+		principal *Principal
+		requested string
+		answer    string
+		status    int
+	}
+
+	tests := []test{
+		{false, nil, "", "", 0},
+		{false, nil, "tenantb", "tenantb", 0},
+		{false, nil, "tenant b", "", http.StatusBadRequest},
+		{true, nil, "tenantb", "", http.StatusUnauthorized},
+		{true, user, "", "tenanta", 0},
+		{true, user, "tenanta", "tenanta", 0},
+		{true, user, "tenantb", "", http.StatusForbidden},
+		{true, admin, "tenantb", "tenantb", 0},
+		{true, service, "tenantb", "tenantb", 0},
+		{true, unbound, "tenantb", "", http.StatusForbidden},
+	}
+
+	for i, v := range tests {
+		config.Cfg.AuthEnabled = v.enabled
+		ctx := context.Background()
+		if v.principal != nil {
+			ctx = WithPrincipal(ctx, v.principal)
+		}
+
+		got, err := ResolveTenant(ctx, v.requested)
+
+		status := 0
+		if httpErr, ok := err.(*respKit.GenericHttpError); ok {
+			status = httpErr.Status
+		}
+
+		if got != v.answer || status != v.status {
+			t.Errorf("%s: test %d expected %v %v but got %v %v", nameTest, i, v.answer, v.status, got, err)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
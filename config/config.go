@@ -0,0 +1,57 @@
+// Package config implements the configuration of location-history-mgmt microservice
+// that is not shared with other location microservices. Values are gathered from
+// the environment at start-up.
+package config
+
+import "time"
+
+// Cfg is the struct type that contains fields that stores the microservice specific
+// configuration gathered from the environment.
+var Cfg struct {
+	DBStatementTimeout    time.Duration            `envconfig:"DB_STATEMENT_TIMEOUT" default:"5s"`                                      // default maximum duration of a repository query. Zero disables it
+	DBOperationTimeouts   map[string]time.Duration `envconfig:"DB_OPERATION_TIMEOUTS"`                                                  // maximum duration by repository operation, e.g. "GetDistanceByUserNameAndDateRange:30s". Overrides DBStatementTimeout
+	LogLevel              string                   `envconfig:"LOG_LEVEL" default:"info"`                                               // minimum level of written log lines. Coordinates are only logged with full precision at debug level
+	LogFormat             string                   `envconfig:"LOG_FORMAT" default:"json"`                                              // format of log lines, json or text
+	AuthEnabled           bool                     `envconfig:"AUTH_ENABLED" default:"false"`                                           // requires callers to authenticate with a JWT bearer token or an api key
+	AuthJWTHMACKey        string                   `envconfig:"AUTH_JWT_HMAC_KEY"`                                                      // key of HS256/HS384/HS512 signed tokens. Exclusive with AuthJWKSFile
+	AuthJWKSFile          string                   `envconfig:"AUTH_JWKS_FILE"`                                                         // path of a local JWKS file with the keys of RS*/ES* signed tokens
+	AuthJWTIssuer         string                   `envconfig:"AUTH_JWT_ISSUER"`                                                        // required iss claim of tokens. Empty skips the check
+	AuthJWTAudience       string                   `envconfig:"AUTH_JWT_AUDIENCE"`                                                      // required aud claim of tokens. Empty skips the check
+	RateLimitUserRPS      float64                  `envconfig:"RATE_LIMIT_USER_RPS" default:"1"`                                        // requests per second allowed by username. Zero disables the limit
+	RateLimitUserBurst    int                      `envconfig:"RATE_LIMIT_USER_BURST" default:"10"`                                     // requests by username allowed in a burst
+	RateLimitClientRPS    float64                  `envconfig:"RATE_LIMIT_CLIENT_RPS" default:"50"`                                     // requests per second allowed by authenticated client or remote address. Zero disables the limit
+	RateLimitClientBurst  int                      `envconfig:"RATE_LIMIT_CLIENT_BURST" default:"100"`                                  // requests by client allowed in a burst
+	TrustedProxies        []string                 `envconfig:"TRUSTED_PROXIES"`                                                        // CIDRs of the proxies whose X-Forwarded-For header carries the remote address of http requests. Empty uses the address of the connection
+	DefaultTenant         string                   `envconfig:"DEFAULT_TENANT" default:"default"`                                       // tenant of requests that don't resolve one from credentials or headers
+	CurrentLocationPolicy string                   `envconfig:"CURRENT_LOCATION_POLICY" default:"most_recent"`                          // device that drives the current location of a username with several devices: most_recent, most_accurate or primary
+	MostAccurateWindow    time.Duration            `envconfig:"MOST_ACCURATE_WINDOW" default:"5m"`                                      // age after which the current location is replaced by a less accurate one under the most_accurate policy
+	MaxHorizontalAccuracy float64                  `envconfig:"MAX_HORIZONTAL_ACCURACY" default:"100"`                                  // horizontal accuracy in meters above which locations are excluded from distance accumulation. Zero disables it
+	JitterMinDisplacement float64                  `envconfig:"JITTER_MIN_DISPLACEMENT" default:"10"`                                   // displacement in meters from the previous location below which a location is flagged as jitter. Zero disables it
+	JitterAccuracyFactor  float64                  `envconfig:"JITTER_ACCURACY_FACTOR" default:"1"`                                     // multiplier of the combined horizontal accuracy of two locations that raises the jitter threshold. Zero disables it
+	KalmanEnabled         bool                     `envconfig:"KALMAN_ENABLED" default:"false"`                                         // smooths the locations of every device with a Kalman filter before accumulating distance
+	KalmanProcessNoise    float64                  `envconfig:"KALMAN_PROCESS_NOISE" default:"3"`                                       // expected speed in meters per second at which the true position drifts between locations
+	KalmanDefaultAccuracy float64                  `envconfig:"KALMAN_DEFAULT_ACCURACY" default:"20"`                                   // horizontal accuracy in meters assumed for locations that don't report one
+	OutlierMaxSpeed       float64                  `envconfig:"OUTLIER_MAX_SPEED" default:"100"`                                        // implied speed in meters per second from the previous location above which a location is an outlier. Zero disables it
+	OutlierMaxSpeeds      map[string]float64       `envconfig:"OUTLIER_MAX_SPEEDS" default:"walk:7,bike:20,car:70,train:100,plane:300"` // OutlierMaxSpeed by transport mode
+	OutlierPolicy         string                   `envconfig:"OUTLIER_POLICY" default:"flag"`                                          // action on outliers: reject, flag or accept
+	OutlierPolicies       map[string]string        `envconfig:"OUTLIER_POLICIES"`                                                       // OutlierPolicy by transport mode, e.g. "plane:accept"
+	DistanceAlgorithm     string                   `envconfig:"DISTANCE_ALGORITHM" default:"haversine"`                                 // algorithm measuring traveled distances: haversine or vincenty
+	DistanceAlgorithms    map[string]string        `envconfig:"DISTANCE_ALGORITHMS"`                                                    // DistanceAlgorithm by tenant, e.g. "tenanta:vincenty"
+	TripDwellTime         time.Duration            `envconfig:"TRIP_DWELL_TIME" default:"5m"`                                           // time a device stays without moving after which its open trip is closed
+	TripGapTime           time.Duration            `envconfig:"TRIP_GAP_TIME" default:"30m"`                                            // time without locations of a device after which its open trip is closed
+	StayRadius            float64                  `envconfig:"STAY_RADIUS" default:"100"`                                              // radius in meters around its centroid within which the locations of a device make up a stay
+	StayMinDuration       time.Duration            `envconfig:"STAY_MIN_DURATION" default:"10m"`                                        // time a device must stay within StayRadius for its locations to be a stay
+	PlaceRadius           float64                  `envconfig:"PLACE_RADIUS" default:"200"`                                             // radius in meters within which the stays of a username are clustered into a place
+	EncounterTracking     bool                     `envconfig:"ENCOUNTER_TRACKING" default:"false"`                                     // whether saved locations detect and store the encounters of their username
+	EncounterDistance     float64                  `envconfig:"ENCOUNTER_DISTANCE" default:"50"`                                        // distance in meters within which two usernames encounter each other
+	EncounterMinDuration  time.Duration            `envconfig:"ENCOUNTER_MIN_DURATION" default:"5m"`                                    // time two usernames must stay within EncounterDistance for it to be an encounter
+	EncounterMaxGap       time.Duration            `envconfig:"ENCOUNTER_MAX_GAP" default:"5m"`                                         // maximum time between two locations of a username for its position to be interpolated between them
+	LocationMaxAge        time.Duration            `envconfig:"LOCATION_MAX_AGE" default:"24h"`                                         // default maximum age of the location of a username for it to be found by area searches. Zero disables it
+	SnapshotMaxStaleness  time.Duration            `envconfig:"SNAPSHOT_MAX_STALENESS" default:"15m"`                                   // default maximum age at the snapshot date of the last location of a username for it to be in a snapshot
+	RetentionPeriod       time.Duration            `envconfig:"RETENTION_PERIOD" default:"0"`                                           // age after which location history is pruned, by whole UTC days. Zero keeps it forever
+	RetentionPeriods      map[string]time.Duration `envconfig:"RETENTION_PERIODS"`                                                      // RetentionPeriod by tenant or by tenant/username, e.g. "tenanta:2160h,tenanta/john:720h"
+	RetentionMode         string                   `envconfig:"RETENTION_MODE" default:"delete"`                                        // pruning of expired location history: delete or downsample
+	RetentionInterval     time.Duration            `envconfig:"RETENTION_INTERVAL" default:"1h"`                                        // interval between the runs of the retention worker. Zero disables it
+	PartitionMonthsAhead  int                      `envconfig:"PARTITION_MONTHS_AHEAD" default:"3"`                                     // months after the current one whose location history partitions are created ahead
+	PartitionInterval     time.Duration            `envconfig:"PARTITION_INTERVAL" default:"24h"`                                       // interval between the runs of the partition maintenance worker. It must be positive, since records of months without a partition can't be stored
+}
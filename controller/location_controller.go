@@ -5,89 +5,1098 @@
 package controller
 
 import (
-	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 	respKit "github.com/oboadagd/kit-go/middleware/responses"
-	"github.com/oboadagd/location-common/dto"
+	commonDto "github.com/oboadagd/location-common/dto"
 	"github.com/oboadagd/location-common/enums"
+	"github.com/oboadagd/location-history-mgmt/auth"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	histEnums "github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/logger"
 	"github.com/oboadagd/location-history-mgmt/service"
+	"github.com/sirupsen/logrus"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// defaultHistoryItemsLimit is the page size of location history, trip and stay requests
+// that don't set one.
+const defaultHistoryItemsLimit = 100
+
+// defaultPlacesLimit is the number of places returned by frequent places requests that
+// don't set one.
+const defaultPlacesLimit = 10
+
+// defaultTimeZone is the time zone of distance breakdown requests that don't set one.
+const defaultTimeZone = "UTC"
+
 // LocationControllerInterface is the interface of Location controller layer. Contains definition of
 // methods to manage the microservice apis.
 type LocationControllerInterface interface {
 	GetDistanceTraveled(c echo.Context) error
+	GetDistanceBreakdown(c echo.Context) error
+	GetLocationHistory(c echo.Context) error
+	GetRoute(c echo.Context) error
+	GetOutlierCount(c echo.Context) error
+	SetPrimaryDevice(c echo.Context) error
+	ListTrips(c echo.Context) error
+	GetTrip(c echo.Context) error
+	ListStays(c echo.Context) error
+	GetFrequentPlaces(c echo.Context) error
+	GetLeaderboard(c echo.Context) error
+	GetUsersByLocationAtTime(c echo.Context) error
+	ListEncounters(c echo.Context) error
+	FindEncounters(c echo.Context) error
+	GetUsersInPolygon(c echo.Context) error
+	GetUsersInBoundingBox(c echo.Context) error
 }
 
 // LocationController represents the Location controller layer.
 type LocationController struct {
 	locationService service.LocationServiceInterface // Location service interface
+	log             *logrus.Logger                   // structured logger
 }
 
 // NewLocationController initializes Location controller layer.
-func NewLocationController(locationService service.LocationServiceInterface) LocationControllerInterface {
+func NewLocationController(locationService service.LocationServiceInterface, log *logrus.Logger) LocationControllerInterface {
 	return &LocationController{
 		locationService,
+		log,
 	}
 }
 
 // GetDistanceTraveled implements validation and management of parameters, then
 // it invokes Location service layer of getting traveled distance by a username.
+// The optional deviceId query parameter selects the device whose distance is returned.
 // Returns username data not found if username doesn't exist in Location model.
 func (ctr *LocationController) GetDistanceTraveled(c echo.Context) error {
-	var id, fd time.Time
-	dateFormat := time.RFC3339
 	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
 
-	log.Infof("REST Service GetDistanceTraveled started")
+	entry.Debug("REST Service GetDistanceTraveled started")
 
-	if c.Param("initialDate") != "" {
-		d, err := time.Parse(dateFormat, c.Param("initialDate"))
-		if err != nil {
-			return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
-		}
-		id = d
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return err
 	}
 
-	if c.Param("finalDate") != "" {
-		d, err := time.Parse(dateFormat, c.Param("finalDate"))
-		if err != nil {
-			return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
-		}
-		fd = d
+	req := dto.GetDistanceTraveledRequest{
+		UserName:    un,
+		DeviceId:    c.QueryParam("deviceId"),
+		InitialDate: id,
+		FinalDate:   fd,
 	}
 
-	req := dto.GetDistanceTraveledRequest{
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service GetDistanceTraveled forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetDistanceTraveled(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetDistanceTraveled failed")
+		return err
+	}
+	entry.Debug("REST Service GetDistanceTraveled finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetDistanceBreakdown implements validation and management of parameters, then
+// it invokes Location service layer of getting the traveled distance by a username in
+// each bucket of a time range. The optional bucket, timeZone and deviceId query
+// parameters select the size of the buckets, the IANA time zone where they start and
+// the device whose distance is returned. Buckets default to days in UTC. Returns
+// username data not found if username has no locations in the range.
+func (ctr *LocationController) GetDistanceBreakdown(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
+
+	entry.Debug("REST Service GetDistanceBreakdown started")
+
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return err
+	}
+
+	req := dto.GetDistanceBreakdownRequest{
 		UserName:    un,
+		DeviceId:    c.QueryParam("deviceId"),
 		InitialDate: id,
 		FinalDate:   fd,
+		Bucket:      stringQueryParam(c, "bucket", histEnums.BucketDay),
+		TimeZone:    stringQueryParam(c, "timeZone", defaultTimeZone),
 	}
 
-	vtr := validator.New()
-	if err := vtr.RegisterValidation("patternazAZ09", dto.IsPatternUserName); err != nil {
-		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	if err := validate(req); err != nil {
+		return err
 	}
 
-	if err := vtr.RegisterValidation("maxDecimals", dto.IsMaxDecimals); err != nil {
-		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service GetDistanceBreakdown forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetDistanceBreakdown(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetDistanceBreakdown failed")
+		return err
 	}
+	entry.Debug("REST Service GetDistanceBreakdown finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
 
-	cvt := &dto.CustomValidatorSaveLoc{Validator: vtr}
+// GetLocationHistory implements validation and management of parameters, then
+// it invokes Location service layer of listing the locations of a username. The
+// optional deviceId, page and itemsLimit query parameters select the device and the
+// page of listed locations, and the optional tolerance, maxPoints and encoded query
+// parameters simplify and encode the locations of the page as GetRoute does. Returns
+// username data not found if username has no locations in the range.
+func (ctr *LocationController) GetLocationHistory(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
 
-	if err := cvt.Validate(req); err != nil {
+	entry.Debug("REST Service GetLocationHistory started")
+
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := uintQueryParam(c, "page", 1)
+	if err != nil {
+		return err
+	}
+
+	itemsLimit, err := uintQueryParam(c, "itemsLimit", defaultHistoryItemsLimit)
+	if err != nil {
+		return err
+	}
+
+	tolerance, err := floatQueryParam(c, "tolerance")
+	if err != nil {
+		return err
+	}
+
+	maxPoints, err := uintQueryParam(c, "maxPoints", 0)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := boolQueryParam(c, "encoded")
+	if err != nil {
+		return err
+	}
+
+	req := dto.GetLocationHistoryRequest{
+		UserName:    un,
+		DeviceId:    c.QueryParam("deviceId"),
+		InitialDate: id,
+		FinalDate:   fd,
+		Page:        page,
+		ItemsLimit:  itemsLimit,
+		Tolerance:   tolerance,
+		MaxPoints:   maxPoints,
+		Encoded:     encoded,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service GetLocationHistory forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetLocationHistory(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetLocationHistory failed")
+		return err
+	}
+	entry.Debug("REST Service GetLocationHistory finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetRoute implements validation and management of parameters, then it invokes Location
+// service layer of drawing the simplified route of a username. The optional deviceId,
+// tolerance, maxPoints and encoded query parameters select the device, the
+// simplification and the encoded polyline format of the route.
+func (ctr *LocationController) GetRoute(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
+
+	entry.Debug("REST Service GetRoute started")
+
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return err
+	}
+
+	tolerance, err := floatQueryParam(c, "tolerance")
+	if err != nil {
+		return err
+	}
+
+	maxPoints, err := uintQueryParam(c, "maxPoints", 0)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := boolQueryParam(c, "encoded")
+	if err != nil {
+		return err
+	}
+
+	req := dto.GetRouteRequest{
+		UserName:    un,
+		DeviceId:    c.QueryParam("deviceId"),
+		InitialDate: id,
+		FinalDate:   fd,
+		Tolerance:   tolerance,
+		MaxPoints:   maxPoints,
+		Encoded:     encoded,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service GetRoute forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetRoute(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetRoute failed")
+		return err
+	}
+	entry.Debug("REST Service GetRoute finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetOutlierCount implements validation and management of parameters, then it invokes
+// Location service layer of counting the locations of a username flagged as outliers.
+// The optional deviceId query parameter selects the device whose outliers are counted.
+func (ctr *LocationController) GetOutlierCount(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
+
+	entry.Debug("REST Service GetOutlierCount started")
+
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return err
+	}
+
+	req := dto.GetOutlierCountRequest{
+		UserName:    un,
+		DeviceId:    c.QueryParam("deviceId"),
+		InitialDate: id,
+		FinalDate:   fd,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service GetOutlierCount forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetOutlierCount(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetOutlierCount failed")
+		return err
+	}
+	entry.Debug("REST Service GetOutlierCount finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// SetPrimaryDevice implements validation and management of parameters, then it
+// invokes Location service layer of choosing the primary device of a username.
+// Returns username data not found if username doesn't exist in Location model.
+func (ctr *LocationController) SetPrimaryDevice(c echo.Context) error {
+	un := c.Param("userName")
+	deviceId := c.Param("deviceId")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).
+		WithField(logger.FieldUserName, un).
+		WithField(logger.FieldDeviceID, deviceId)
+
+	entry.Debug("REST Service SetPrimaryDevice started")
+
+	req := dto.GetLastByUserNameRequest{
+		UserName: un,
+		DeviceId: deviceId,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service SetPrimaryDevice forbidden")
+		return err
+	}
+
+	if err := ctr.locationService.SetPrimaryDevice(c.Request().Context(), un, deviceId); err != nil {
+		entry.WithError(err).Error("REST Service SetPrimaryDevice failed")
+		return err
+	}
+	entry.Debug("REST Service SetPrimaryDevice finished")
+
+	return c.JSON(http.StatusOK, commonDto.Response{Message: enums.LocationUpdated})
+}
+
+// ListTrips implements validation and management of parameters, then it invokes
+// Location service layer of listing the trips of a username. The optional deviceId,
+// page and itemsLimit query parameters select the device and the page of listed trips.
+func (ctr *LocationController) ListTrips(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
+
+	entry.Debug("REST Service ListTrips started")
+
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := uintQueryParam(c, "page", 1)
+	if err != nil {
+		return err
+	}
+
+	itemsLimit, err := uintQueryParam(c, "itemsLimit", defaultHistoryItemsLimit)
+	if err != nil {
+		return err
+	}
+
+	req := dto.ListTripsRequest{
+		UserName:    un,
+		DeviceId:    c.QueryParam("deviceId"),
+		InitialDate: id,
+		FinalDate:   fd,
+		Page:        page,
+		ItemsLimit:  itemsLimit,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service ListTrips forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.ListTrips(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service ListTrips failed")
+		return err
+	}
+	entry.Debug("REST Service ListTrips finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetTrip implements validation and management of parameters, then it invokes Location
+// service layer of getting a trip of a username. Returns trip not found if the username
+// has no such trip.
+func (ctr *LocationController) GetTrip(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).
+		WithField(logger.FieldUserName, un).
+		WithField("trip_id", c.Param("tripId"))
+
+	entry.Debug("REST Service GetTrip started")
+
+	tripId, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
 		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
 	}
 
-	resp, err := ctr.locationService.GetDistanceTraveled(context.Background(), req)
-	log.Infof("REST Service GetDistanceTraveled finished")
+	req := dto.GetTripRequest{
+		UserName: un,
+		TripId:   tripId,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service GetTrip forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetTrip(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetTrip failed")
+		return err
+	}
+	entry.Debug("REST Service GetTrip finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ListStays implements validation and management of parameters, then it invokes
+// Location service layer of listing the stays of a username. The optional deviceId,
+// page and itemsLimit query parameters select the device and the page of listed stays.
+func (ctr *LocationController) ListStays(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
+
+	entry.Debug("REST Service ListStays started")
+
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := uintQueryParam(c, "page", 1)
+	if err != nil {
+		return err
+	}
+
+	itemsLimit, err := uintQueryParam(c, "itemsLimit", defaultHistoryItemsLimit)
+	if err != nil {
+		return err
+	}
+
+	req := dto.ListStaysRequest{
+		UserName:    un,
+		DeviceId:    c.QueryParam("deviceId"),
+		InitialDate: id,
+		FinalDate:   fd,
+		Page:        page,
+		ItemsLimit:  itemsLimit,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service ListStays forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.ListStays(c.Request().Context(), req)
 	if err != nil {
-		log.Infof("err %v", err)
+		entry.WithError(err).Error("REST Service ListStays failed")
 		return err
 	}
+	entry.Debug("REST Service ListStays finished")
 
 	return c.JSON(http.StatusOK, resp)
 }
+
+// GetFrequentPlaces implements validation and management of parameters, then it invokes
+// Location service layer of getting the places a username visits most. The optional
+// deviceId and limit query parameters select the device whose stays are clustered and
+// the number of returned places.
+func (ctr *LocationController) GetFrequentPlaces(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
+
+	entry.Debug("REST Service GetFrequentPlaces started")
+
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return err
+	}
+
+	limit, err := uintQueryParam(c, "limit", defaultPlacesLimit)
+	if err != nil {
+		return err
+	}
+
+	req := dto.GetFrequentPlacesRequest{
+		UserName:    un,
+		DeviceId:    c.QueryParam("deviceId"),
+		InitialDate: id,
+		FinalDate:   fd,
+		Limit:       limit,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service GetFrequentPlaces forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetFrequentPlaces(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetFrequentPlaces failed")
+		return err
+	}
+	entry.Debug("REST Service GetFrequentPlaces finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetLeaderboard implements validation and management of parameters, then it invokes
+// Location service layer of ranking usernames by traveled distance. The optional
+// userNames query parameter, a comma separated list, restricts the ranked usernames, and
+// the optional minLatitude, minLongitude, maxLatitude and maxLongitude query parameters
+// restrict the area where the distance is accumulated. Both restrictions require the
+// admin or service scope. The own rank of the caller is returned for its subject, or for
+// the optional userName query parameter if the caller acts on behalf of usernames, and
+// page and itemsLimit select the page of ranked usernames.
+func (ctr *LocationController) GetLeaderboard(c echo.Context) error {
+	un, err := auth.OwnUserName(c.Request().Context(), c.QueryParam("userName"))
+	if err != nil {
+		return err
+	}
+
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
+
+	entry.Debug("REST Service GetLeaderboard started")
+
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return err
+	}
+
+	area, err := boundingBoxQueryParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := uintQueryParam(c, "page", 1)
+	if err != nil {
+		return err
+	}
+
+	limit, err := uintQueryParam(c, "itemsLimit", defaultHistoryItemsLimit)
+	if err != nil {
+		return err
+	}
+
+	var uns []string
+	if c.QueryParam("userNames") != "" {
+		uns = strings.Split(c.QueryParam("userNames"), ",")
+	}
+
+	req := dto.GetLeaderboardRequest{
+		UserName:    un,
+		UserNames:   uns,
+		Area:        area,
+		InitialDate: id,
+		FinalDate:   fd,
+		Page:        page,
+		ItemsLimit:  limit,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if req.Area != nil || len(req.UserNames) > 0 {
+		if err := auth.AuthorizeScope(c.Request().Context(), auth.ScopeAdmin, auth.ScopeService); err != nil {
+			entry.WithError(err).Warn("REST Service GetLeaderboard forbidden")
+			return err
+		}
+	}
+
+	resp, err := ctr.locationService.GetLeaderboard(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetLeaderboard failed")
+		return err
+	}
+	entry.Debug("REST Service GetLeaderboard finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetUsersByLocationAtTime implements validation and management of parameters, then it
+// invokes Location service layer of getting the usernames within a radius at a date. The
+// latitude, longitude, radius and at query parameters select the circle and the RFC 3339
+// date, the optional maxStaleness and tolerance query parameters, as Go durations, bound
+// the dates of the locations, and page and itemsLimit select the page of usernames. Only
+// callers granted the admin or service scope are allowed.
+func (ctr *LocationController) GetUsersByLocationAtTime(c echo.Context) error {
+	entry := logger.FromContext(c.Request().Context(), ctr.log)
+
+	entry.Debug("REST Service GetUsersByLocationAtTime started")
+
+	var coordinates [3]float64
+	for i, name := range []string{"latitude", "longitude", "radius"} {
+		v, err := floatQueryParam(c, name)
+		if err != nil {
+			return err
+		}
+		coordinates[i] = v
+	}
+
+	at, err := timeQueryParam(c, "at")
+	if err != nil {
+		return err
+	}
+
+	var durations [2]time.Duration
+	for i, name := range []string{"maxStaleness", "tolerance"} {
+		d, err := durationQueryParam(c, name)
+		if err != nil {
+			return err
+		}
+		durations[i] = d
+	}
+
+	page, err := uintQueryParam(c, "page", 1)
+	if err != nil {
+		return err
+	}
+
+	limit, err := uintQueryParam(c, "itemsLimit", defaultHistoryItemsLimit)
+	if err != nil {
+		return err
+	}
+
+	req := dto.GetUsersByLocationAtTimeRequest{
+		Latitude:     coordinates[0],
+		Longitude:    coordinates[1],
+		Radius:       coordinates[2],
+		At:           at,
+		MaxStaleness: durations[0],
+		Tolerance:    durations[1],
+		Page:         page,
+		ItemsLimit:   limit,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.AuthorizeScope(c.Request().Context(), auth.ScopeAdmin, auth.ScopeService); err != nil {
+		entry.WithError(err).Warn("REST Service GetUsersByLocationAtTime forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetUsersByLocationAtTime(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetUsersByLocationAtTime failed")
+		return err
+	}
+	entry.Debug("REST Service GetUsersByLocationAtTime finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetUsersInPolygon implements validation and management of parameters, then it invokes
+// Location service layer of getting the usernames within a polygon. The request body is a
+// GeoJSON Polygon or MultiPolygon geometry, the optional maxAge query parameter, as a Go
+// duration, skips older locations, and the optional page and itemsLimit query parameters
+// select the page of usernames. Only callers granted the admin or service scope are
+// allowed.
+func (ctr *LocationController) GetUsersInPolygon(c echo.Context) error {
+	entry := logger.FromContext(c.Request().Context(), ctr.log)
+
+	entry.Debug("REST Service GetUsersInPolygon started")
+
+	var geometry dto.GeoJSONGeometry
+	if err := c.Bind(&geometry); err != nil {
+		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	if err := validate(geometry); err != nil {
+		return err
+	}
+
+	polygons, err := geoJSONPolygons(geometry)
+	if err != nil {
+		return err
+	}
+
+	page, err := uintQueryParam(c, "page", 1)
+	if err != nil {
+		return err
+	}
+
+	limit, err := uintQueryParam(c, "itemsLimit", defaultHistoryItemsLimit)
+	if err != nil {
+		return err
+	}
+
+	maxAge, err := durationQueryParam(c, "maxAge")
+	if err != nil {
+		return err
+	}
+
+	req := dto.GetUsersInPolygonRequest{
+		Polygons:   polygons,
+		MaxAge:     maxAge,
+		Page:       page,
+		ItemsLimit: limit,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.AuthorizeScope(c.Request().Context(), auth.ScopeAdmin, auth.ScopeService); err != nil {
+		entry.WithError(err).Warn("REST Service GetUsersInPolygon forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetUsersInPolygon(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetUsersInPolygon failed")
+		return err
+	}
+	entry.Debug("REST Service GetUsersInPolygon finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetUsersInBoundingBox implements validation and management of parameters, then it
+// invokes Location service layer of getting the usernames within a bounding box. The
+// minLatitude, minLongitude, maxLatitude and maxLongitude query parameters select the box,
+// crossing the antimeridian when minLongitude is greater than maxLongitude, the optional
+// maxAge query parameter, as a Go duration, skips older locations, and the optional page
+// and itemsLimit query parameters select the page of usernames. Only callers granted the
+// admin or service scope are allowed.
+func (ctr *LocationController) GetUsersInBoundingBox(c echo.Context) error {
+	entry := logger.FromContext(c.Request().Context(), ctr.log)
+
+	entry.Debug("REST Service GetUsersInBoundingBox started")
+
+	area, err := boundingBoxQueryParams(c)
+	if err != nil {
+		return err
+	}
+
+	if area == nil {
+		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, "area is required")
+	}
+
+	page, err := uintQueryParam(c, "page", 1)
+	if err != nil {
+		return err
+	}
+
+	limit, err := uintQueryParam(c, "itemsLimit", defaultHistoryItemsLimit)
+	if err != nil {
+		return err
+	}
+
+	maxAge, err := durationQueryParam(c, "maxAge")
+	if err != nil {
+		return err
+	}
+
+	req := dto.GetUsersInBoundingBoxRequest{
+		Area:       *area,
+		MaxAge:     maxAge,
+		Page:       page,
+		ItemsLimit: limit,
+	}
+
+	if err := validate(req); err != nil {
+		return err
+	}
+
+	if err := auth.AuthorizeScope(c.Request().Context(), auth.ScopeAdmin, auth.ScopeService); err != nil {
+		entry.WithError(err).Warn("REST Service GetUsersInBoundingBox forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.GetUsersInBoundingBox(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service GetUsersInBoundingBox failed")
+		return err
+	}
+	entry.Debug("REST Service GetUsersInBoundingBox finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// dateParams returns the initialDate and finalDate path parameters parsed as RFC 3339
+// dates. Missing parameters are returned with empty value.
+func dateParams(c echo.Context) (time.Time, time.Time, error) {
+	var dates [2]time.Time
+	for i, name := range []string{"initialDate", "finalDate"} {
+		if c.Param(name) == "" {
+			continue
+		}
+
+		d, err := time.Parse(time.RFC3339, c.Param(name))
+		if err != nil {
+			return time.Time{}, time.Time{}, respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+		}
+		dates[i] = d
+	}
+
+	return dates[0], dates[1], nil
+}
+
+// uintQueryParam returns the query parameter name parsed as an unsigned integer, or
+// def if it's missing.
+func uintQueryParam(c echo.Context, name string, def uint64) (uint64, error) {
+	if c.QueryParam(name) == "" {
+		return def, nil
+	}
+
+	v, err := strconv.ParseUint(c.QueryParam(name), 10, 64)
+	if err != nil {
+		return 0, respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	return v, nil
+}
+
+// boundingBoxQueryParams returns the area of the minLatitude, minLongitude, maxLatitude
+// and maxLongitude query parameters, or nil if none of them is set.
+func boundingBoxQueryParams(c echo.Context) (*dto.BoundingBox, error) {
+	names := []string{"minLatitude", "minLongitude", "maxLatitude", "maxLongitude"}
+
+	var values [4]float64
+	set := 0
+	for i, name := range names {
+		if c.QueryParam(name) == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(c.QueryParam(name), 64)
+		if err != nil {
+			return nil, respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+		}
+		values[i] = v
+		set++
+	}
+
+	if set == 0 {
+		return nil, nil
+	}
+
+	if set < len(names) {
+		return nil, respKit.GenericBadRequestError(enums.ErrorRequestBodyCode,
+			fmt.Sprintf("area requires every one of %v", names))
+	}
+
+	return &dto.BoundingBox{
+		MinLatitude:  values[0],
+		MinLongitude: values[1],
+		MaxLatitude:  values[2],
+		MaxLongitude: values[3],
+	}, nil
+}
+
+// geoJSONPolygons returns the polygons of a GeoJSON Polygon or MultiPolygon geometry.
+func geoJSONPolygons(geometry dto.GeoJSONGeometry) ([]dto.Polygon, error) {
+	var polygons []dto.Polygon
+	var err error
+	if geometry.Type == "Polygon" {
+		var p dto.Polygon
+		err = json.Unmarshal(geometry.Coordinates, &p)
+		polygons = append(polygons, p)
+	} else {
+		err = json.Unmarshal(geometry.Coordinates, &polygons)
+	}
+
+	if err != nil {
+		return nil, respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	return polygons, nil
+}
+
+// floatQueryParam returns the query parameter name parsed as a float, or zero if it's
+// missing.
+func floatQueryParam(c echo.Context, name string) (float64, error) {
+	if c.QueryParam(name) == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseFloat(c.QueryParam(name), 64)
+	if err != nil {
+		return 0, respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	return v, nil
+}
+
+// boolQueryParam returns the query parameter name parsed as a boolean, or false if it's
+// missing.
+func boolQueryParam(c echo.Context, name string) (bool, error) {
+	if c.QueryParam(name) == "" {
+		return false, nil
+	}
+
+	v, err := strconv.ParseBool(c.QueryParam(name))
+	if err != nil {
+		return false, respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	return v, nil
+}
+
+// durationQueryParam returns the query parameter name parsed as a Go duration, or zero
+// if it's missing.
+func durationQueryParam(c echo.Context, name string) (time.Duration, error) {
+	if c.QueryParam(name) == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(c.QueryParam(name))
+	if err != nil {
+		return 0, respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	return d, nil
+}
+
+// timeQueryParam returns the query parameter name parsed as a RFC 3339 date, or the zero
+// date if it's missing.
+func timeQueryParam(c echo.Context, name string) (time.Time, error) {
+	if c.QueryParam(name) == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, c.QueryParam(name))
+	if err != nil {
+		return time.Time{}, respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	return t, nil
+}
+
+// stringQueryParam returns the query parameter name, or def if it's missing.
+func stringQueryParam(c echo.Context, name string, def string) string {
+	if c.QueryParam(name) == "" {
+		return def
+	}
+
+	return c.QueryParam(name)
+}
+
+// validate applies the validations specified in the tags of req.
+func validate(req interface{}) error {
+	vtr := validator.New()
+	if err := vtr.RegisterValidation("patternazAZ09", commonDto.IsPatternUserName); err != nil {
+		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	if err := vtr.RegisterValidation("maxDecimals", commonDto.IsMaxDecimals); err != nil {
+		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	cvt := &commonDto.CustomValidatorSaveLoc{Validator: vtr}
+
+	if err := cvt.Validate(req); err != nil {
+		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	return nil
+}
+
+// ListEncounters implements validation and management of parameters, then it invokes
+// Location service layer of listing the stored encounters of a username. The optional
+// page and itemsLimit query parameters select the page of listed encounters.
+func (ctr *LocationController) ListEncounters(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
+
+	entry.Debug("REST Service ListEncounters started")
+
+	req, err := encountersRequest(c, un)
+	if err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service ListEncounters forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.ListEncounters(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service ListEncounters failed")
+		return err
+	}
+	entry.Debug("REST Service ListEncounters finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// FindEncounters implements validation and management of parameters, then it invokes
+// Location service layer of scanning the location history of a username for encounters
+// with other usernames. The optional page and itemsLimit query parameters select the
+// page of found encounters.
+func (ctr *LocationController) FindEncounters(c echo.Context) error {
+	un := c.Param("userName")
+	entry := logger.FromContext(c.Request().Context(), ctr.log).WithField(logger.FieldUserName, un)
+
+	entry.Debug("REST Service FindEncounters started")
+
+	req, err := encountersRequest(c, un)
+	if err != nil {
+		return err
+	}
+
+	if err := auth.Authorize(c.Request().Context(), un); err != nil {
+		entry.WithError(err).Warn("REST Service FindEncounters forbidden")
+		return err
+	}
+
+	resp, err := ctr.locationService.FindEncounters(c.Request().Context(), req)
+	if err != nil {
+		entry.WithError(err).Error("REST Service FindEncounters failed")
+		return err
+	}
+	entry.Debug("REST Service FindEncounters finished")
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// encountersRequest returns the validated encounters request of username un from the
+// date path parameters and the page and itemsLimit query parameters.
+func encountersRequest(c echo.Context, un string) (dto.ListEncountersRequest, error) {
+	id, fd, err := dateParams(c)
+	if err != nil {
+		return dto.ListEncountersRequest{}, err
+	}
+
+	page, err := uintQueryParam(c, "page", 1)
+	if err != nil {
+		return dto.ListEncountersRequest{}, err
+	}
+
+	itemsLimit, err := uintQueryParam(c, "itemsLimit", defaultHistoryItemsLimit)
+	if err != nil {
+		return dto.ListEncountersRequest{}, err
+	}
+
+	req := dto.ListEncountersRequest{
+		UserName:    un,
+		InitialDate: id,
+		FinalDate:   fd,
+		Page:        page,
+		ItemsLimit:  itemsLimit,
+	}
+
+	if err := validate(req); err != nil {
+		return dto.ListEncountersRequest{}, err
+	}
+
+	return req, nil
+}
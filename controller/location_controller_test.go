@@ -2,9 +2,11 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/go-pg/pg/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/oboadagd/location-history-mgmt/dto"
 	"github.com/oboadagd/location-history-mgmt/repository"
 	"github.com/oboadagd/location-history-mgmt/service"
 	"github.com/oboadagd/location-history-mgmt/testutils"
@@ -23,10 +25,15 @@ func TestGetDistanceTraveled(t *testing.T) {
 
 	ctxBkg := context.Background()
 
-	locationRepository := repository.NewLocationRepository(db)
-	locationHistoryRepository := repository.NewLocationHistoryRepository(db)
-	locationService := service.NewLocationService(locationRepository, locationHistoryRepository)
-	locationController := NewLocationController(locationService)
+	locationRepository := repository.NewLocationRepository(db, testutils.GetLogger())
+	locationHistoryRepository := repository.NewLocationHistoryRepository(db, testutils.GetLogger())
+	locationFilterStateRepository := repository.NewLocationFilterStateRepository(db, testutils.GetLogger())
+	tripRepository := repository.NewTripRepository(db, testutils.GetLogger())
+	stayRepository := repository.NewStayRepository(db, testutils.GetLogger())
+	encounterRepository := repository.NewEncounterRepository(db, testutils.GetLogger())
+	jobCheckpointRepository := repository.NewJobCheckpointRepository(db, testutils.GetLogger())
+	locationService := service.NewLocationService(locationRepository, locationHistoryRepository, locationFilterStateRepository, tripRepository, stayRepository, encounterRepository, jobCheckpointRepository, testutils.GetLogger())
+	locationController := NewLocationController(locationService, testutils.GetLogger())
 
 	dateFormat := "%d-%02d-%02dT%02d:%02d:%02d+00:00"
 	base := time.Now()
@@ -109,3 +116,66 @@ func TestGetDistanceTraveled(t *testing.T) {
 
 	t.Logf("%s Success", nameTest)
 }
+
+func TestBoundingBoxQueryParams(t *testing.T) {
+	nameTest := "TestBoundingBoxQueryParams"
+
+	type test struct {
+		query string
+		area  string
+		fails bool
+	}
+
+	tests := []test{
+		{"", "<nil>", false},
+		{"minLatitude=1&minLongitude=170&maxLatitude=2&maxLongitude=-170", "&{1 170 2 -170}", false},
+		{"minLatitude=1&minLongitude=2", "<nil>", true},
+		{"minLatitude=a&minLongitude=2&maxLatitude=3&maxLongitude=4", "<nil>", true},
+	}
+
+	e := echo.New()
+	for _, v := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/?"+v.query, nil)
+		ctx := e.NewContext(req, httptest.NewRecorder())
+
+		area, err := boundingBoxQueryParams(ctx)
+		if (err != nil) != v.fails || fmt.Sprint(area) != v.area {
+			t.Errorf("%s: Expected %v %v but got %v %v", nameTest, v.area, v.fails, area, err)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGeoJSONPolygons(t *testing.T) {
+	nameTest := "TestGeoJSONPolygons"
+
+	type test struct {
+		geometry string
+		polygons string
+		fails    bool
+	}
+
+	tests := []test{
+		{`{"type":"Polygon","coordinates":[[[-4,40],[-3,40],[-3,41],[-4,40]]]}`, "[[[[-4 40] [-3 40] [-3 41] [-4 40]]]]", false},
+		{`{"type":"MultiPolygon","coordinates":[[[[-4,40,650],[-3,40],[-3,41]]],[[[1,2],[3,4],[5,6]]]]}`, "[[[[-4 40] [-3 40] [-3 41]]] [[[1 2] [3 4] [5 6]]]]", false},
+		{`{"type":"Polygon","coordinates":[[["a",40]]]}`, "[]", true},
+	}
+
+	for _, v := range tests {
+		var geometry dto.GeoJSONGeometry
+		if err := json.Unmarshal([]byte(v.geometry), &geometry); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+
+		polygons, err := geoJSONPolygons(geometry)
+		if (err != nil) != v.fails || fmt.Sprint(polygons) != v.polygons {
+			t.Errorf("%s: Expected %v %v but got %v %v", nameTest, v.polygons, v.fails, polygons, err)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
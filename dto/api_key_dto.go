@@ -0,0 +1,19 @@
+// Package dto implements definition of structs to store objects that represent
+// database entities and http requests and responses of location-history-mgmt
+// microservice that are not shared with other location microservices.
+package dto
+
+import "time"
+
+// ApiKey describes a database ApiKey entity. Defines the credential of a service that
+// calls this microservice. Only the SHA-256 hash of the key is stored.
+type ApiKey struct {
+	tableName struct{}   `pg:"api_key,alias:apiKey"`                       // name of the table. Control field not visible
+	Id        int64      `json:"id" pg:",pk"`                              // record identifier
+	ClientId  string     `json:"clientId" pg:"client_id, notnull, unique"` // identifier of the calling service
+	KeyHash   string     `json:"-" pg:"key_hash, notnull, unique"`         // hex encoded SHA-256 hash of the key
+	TenantId  string     `json:"tenantId,omitempty" pg:"tenant_id"`        // tenant the key is bound to. Empty for keys of every tenant
+	Scopes    []string   `json:"scopes" pg:"scopes, array"`                // scopes granted to the key
+	CreatedAt time.Time  `json:"createdAt" pg:"created_at, notnull"`       // date of creation
+	RevokedAt *time.Time `json:"revokedAt,omitempty" pg:"revoked_at"`      // date of revocation. Null while the key is valid
+}
@@ -0,0 +1,11 @@
+package dto
+
+// BoundingBox is a geographic area delimited by its south-west and north-east corners.
+// A minimum longitude greater than the maximum longitude describes an area that crosses
+// the antimeridian.
+type BoundingBox struct {
+	MinLatitude  float64 `json:"minLatitude" validate:"min=-90,max=90,ltefield=MaxLatitude"` // latitude of the southern edge. It belongs to range [-90 to 90]
+	MinLongitude float64 `json:"minLongitude" validate:"min=-180,max=180"`                   // longitude of the western edge. It belongs to range [-180 to 180]
+	MaxLatitude  float64 `json:"maxLatitude" validate:"min=-90,max=90"`                      // latitude of the northern edge. It belongs to range [-90 to 90]
+	MaxLongitude float64 `json:"maxLongitude" validate:"min=-180,max=180"`                   // longitude of the eastern edge. It belongs to range [-180 to 180]
+}
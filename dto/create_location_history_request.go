@@ -0,0 +1,16 @@
+package dto
+
+// CreateLocationHistoryRequest is a request of Create method.
+type CreateLocationHistoryRequest struct {
+	UserName          string   `json:"username" validate:"required,min=4,max=16,patternazAZ09"`            // username located in one geographic point. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	DeviceId          string   `json:"deviceId" validate:"max=64"`                                         // device that reported the location. Up to 64 characters
+	TransportMode     string   `json:"transportMode" validate:"omitempty,oneof=walk bike car train plane"` // transport mode of the username: walk, bike, car, train or plane
+	Latitude          float64  `json:"latitude" validate:"required,min=-90,max=90,maxDecimals"`            // latitude coordinate of username's location. It is required, belongs to range -90 to 90, allows 8 decimal positions
+	Longitude         float64  `json:"longitude" validate:"required,min=-180,max=180,maxDecimals"`         // longitude coordinate of username's location. It is required, belongs to range -180 to 180, allows 8 decimal positions
+	Distance          float64  `json:"distance"`                                                           // traveled distance by the device from its last to current location
+	ExcludedReason    string   `json:"excludedReason"`                                                     // reason to exclude the location from distance accumulation. Empty for included locations
+	FilteredLatitude  *float64 `json:"filteredLatitude"`                                                   // latitude coordinate smoothed by the movement filter
+	FilteredLongitude *float64 `json:"filteredLongitude"`                                                  // longitude coordinate smoothed by the movement filter
+	DistanceAlgorithm string   `json:"distanceAlgorithm"`                                                  // algorithm that measured the distance
+	Fix                        // quality and motion of the fix reported by the device
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// DistanceRollup describes a database DistanceRollup entity. Defines the distance
+// accumulated and the number of LocationHistory records reported by a device of a
+// username within an hour, maintained as records are created.
+type DistanceRollup struct {
+	tableName  struct{}  `pg:"distance_rollup,alias:distanceRollup"`                                                             // name of the table. Control field not visible
+	Id         int64     `json:"id" pg:",pk"`                                                                                    // record identifier
+	TenantId   string    `json:"tenantId" pg:"tenant_id, notnull, unique:distance_rollup_tenant_username_device_hour"`           // tenant that owns the username
+	UserName   string    `json:"userName" pg:"username, notnull, unique:distance_rollup_tenant_username_device_hour"`            // username
+	DeviceId   string    `json:"deviceId" pg:"device_id, use_zero, notnull, unique:distance_rollup_tenant_username_device_hour"` // device that reported the records
+	Hour       time.Time `json:"hour" pg:"hour, notnull, unique:distance_rollup_tenant_username_device_hour"`                    // start of the hour, truncated in UTC
+	Distance   float64   `json:"distance" pg:",use_zero, notnull"`                                                               // traveled distance within the hour
+	PointCount int       `json:"pointCount" pg:",use_zero, notnull"`                                                             // number of records within the hour
+}
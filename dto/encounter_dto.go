@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// Encounter describes a database Encounter entity. Defines an interval in which two
+// usernames were within the encounter distance of each other. Stored encounters name the
+// pair in lexical order.
+type Encounter struct {
+	tableName     struct{}  `pg:"encounter,alias:encounter"`                          // name of the table. Control field not visible
+	Id            int64     `json:"id" pg:",pk"`                                      // record identifier
+	TenantId      string    `json:"tenantId" pg:"tenant_id, notnull"`                 // tenant that owns the usernames
+	UserName      string    `json:"userName" pg:"username, notnull"`                  // username
+	OtherUserName string    `json:"otherUserName" pg:"other_username, notnull"`       // username encountered
+	Status        string    `json:"status" pg:"status, notnull"`                      // status of the encounter: candidate, open or closed
+	StartedAt     time.Time `json:"startedAt" pg:"started_at, notnull"`               // date the usernames came within the encounter distance
+	EndedAt       time.Time `json:"endedAt" pg:"ended_at, notnull"`                   // last date the usernames were within the encounter distance
+	MinDistance   float64   `json:"minDistance" pg:"min_distance, use_zero, notnull"` // minimum distance in meters between the usernames
+	Duration      float64   `json:"duration" pg:",use_zero, notnull"`                 // seconds from start to end
+}
@@ -0,0 +1,12 @@
+package dto
+
+// Fix describes the quality and motion of a location fix as reported by the device.
+// Every field is optional, devices that don't report a value leave it empty.
+type Fix struct {
+	HorizontalAccuracy *float64 `json:"horizontalAccuracy,omitempty" validate:"omitempty,min=0" pg:"horizontal_accuracy"`           // radius in meters of the horizontal position uncertainty
+	Altitude           *float64 `json:"altitude,omitempty" validate:"omitempty,min=-1000,max=100000" pg:"altitude"`                 // altitude in meters above the WGS84 ellipsoid
+	VerticalAccuracy   *float64 `json:"verticalAccuracy,omitempty" validate:"omitempty,min=0" pg:"vertical_accuracy"`               // uncertainty in meters of the altitude
+	Speed              *float64 `json:"speed,omitempty" validate:"omitempty,min=0" pg:"speed"`                                      // ground speed in meters per second
+	Heading            *float64 `json:"heading,omitempty" validate:"omitempty,min=0,lt=360" pg:"heading"`                           // course in degrees clockwise from true north. It belongs to range [0 to 360)
+	Source             string   `json:"source,omitempty" validate:"omitempty,oneof=gps network fused cell wifi manual" pg:"source"` // positioning technology of the fix: gps, network, fused, cell, wifi or manual
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// GetDistanceBreakdownRequest is a http request of GetDistanceBreakdown service.
+type GetDistanceBreakdownRequest struct {
+	UserName    string    `json:"username" validate:"required,min=4,max=16,patternazAZ09"` // username located in one geographic point. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	DeviceId    string    `json:"deviceId" validate:"max=64"`                              // device whose distance is accumulated. Empty selects the device that traveled the most
+	InitialDate time.Time `json:"initialDate"`                                             // initial date range to accumulate traveled distance
+	FinalDate   time.Time `json:"finalDate"`                                               // final date range to accumulate traveled distance
+	Bucket      string    `json:"bucket" validate:"required,oneof=hour day week month"`    // size of the buckets: hour, day, week or month
+	TimeZone    string    `json:"timeZone" validate:"required,max=64"`                     // IANA time zone where the buckets start, e.g. Europe/Madrid
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// DistanceBucket is the distance traveled within one bucket of a distance breakdown.
+type DistanceBucket struct {
+	Start    time.Time `json:"start"`    // start date of the bucket in the requested time zone, inclusive
+	End      time.Time `json:"end"`      // end date of the bucket in the requested time zone, exclusive
+	Distance float64   `json:"distance"` // traveled distance within the bucket. Zero for buckets without locations
+}
+
+// GetDistanceBreakdownResponse is http response of GetDistanceBreakdown service
+type GetDistanceBreakdownResponse struct {
+	Username      string           `json:"userName"`      // username
+	DeviceId      string           `json:"deviceId"`      // device that traveled the distance
+	Bucket        string           `json:"bucket"`        // size of the buckets
+	TimeZone      string           `json:"timeZone"`      // time zone where the buckets start
+	TotalDistance float64          `json:"totalDistance"` // accumulated total traveled distance
+	Buckets       []DistanceBucket `json:"buckets"`       // consecutive buckets covering the date range
+}
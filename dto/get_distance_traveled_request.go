@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// GetDistanceTraveledRequest is a http request of GetDistanceTraveledRequest service.
+type GetDistanceTraveledRequest struct {
+	UserName    string    `json:"username" validate:"required,min=4,max=16,patternazAZ09"` // username located in one geographic point. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	DeviceId    string    `json:"deviceId" validate:"max=64"`                              // device whose distance is accumulated. Empty selects the device that traveled the most
+	InitialDate time.Time `json:"initialDate"`                                             // initial date range to accumulate traveled distance
+	FinalDate   time.Time `json:"finalDate"`                                               // final date range to accumulate traveled distance
+}
@@ -0,0 +1,8 @@
+package dto
+
+// GetDistanceTraveledResponse is http response of GetDistanceTraveled service
+type GetDistanceTraveledResponse struct {
+	Username      string  `json:"userName"`      // username
+	DeviceId      string  `json:"deviceId"`      // device that traveled the distance
+	TotalDistance float64 `json:"totalDistance"` // accumulated total traveled distance
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// GetFrequentPlacesRequest is a http request of GetFrequentPlaces service.
+type GetFrequentPlacesRequest struct {
+	UserName    string    `json:"username" validate:"required,min=4,max=16,patternazAZ09"` // username whose places are returned. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	DeviceId    string    `json:"deviceId" validate:"max=64"`                              // device whose stays make up the places. Empty uses every device
+	InitialDate time.Time `json:"initialDate"`                                             // initial date of the range of stays
+	FinalDate   time.Time `json:"finalDate"`                                               // final date of the range of stays
+	Limit       uint64    `json:"limit" validate:"min=1,max=100"`                          // maximum number of places. It belongs to range [1 to 100]
+}
@@ -0,0 +1,7 @@
+package dto
+
+// GetFrequentPlacesResponse is a http response of GetFrequentPlaces service
+type GetFrequentPlacesResponse struct {
+	Username string  `json:"username"` // username
+	Places   []Place `json:"places"`   // places ordered from the most frequent
+}
@@ -0,0 +1,7 @@
+package dto
+
+// GetLastByUserNameRequest is a request of GetLastByUserName method
+type GetLastByUserNameRequest struct {
+	UserName string `json:"username" validate:"required,min=4,max=16,patternazAZ09"` // username. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	DeviceId string `json:"deviceId" validate:"max=64"`                              // device whose last location is requested
+}
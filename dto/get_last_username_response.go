@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// GetLastByUserNameResponse is a response of GetLastByUserName method
+type GetLastByUserNameResponse struct {
+	Username           string    `json:"userName"`           // found username
+	DeviceId           string    `json:"deviceId"`           // device that reported the location
+	Latitude           float64   `json:"latitude"`           // later latitude coordinate of found username
+	Longitude          float64   `json:"longitude"`          // later longitude coordinate of found username
+	UpdatedAt          time.Time `json:"updatedAt"`          // date of the location
+	HorizontalAccuracy *float64  `json:"horizontalAccuracy"` // radius in meters of the horizontal position uncertainty
+	FilteredLatitude   *float64  `json:"filteredLatitude"`   // latitude coordinate smoothed by the movement filter
+	FilteredLongitude  *float64  `json:"filteredLongitude"`  // longitude coordinate smoothed by the movement filter
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// GetLeaderboardRequest is a http request of GetLeaderboard service.
+type GetLeaderboardRequest struct {
+	UserName    string       `json:"username" validate:"omitempty,min=4,max=16,patternazAZ09"`     // requesting username whose own rank is returned. Empty returns no own rank
+	UserNames   []string     `json:"userNames" validate:"max=100,dive,min=4,max=16,patternazAZ09"` // usernames ranked. Empty ranks every username of the tenant
+	Area        *BoundingBox `json:"area" validate:"omitempty"`                                    // area where the distance is accumulated. Empty accumulates the distance everywhere
+	InitialDate time.Time    `json:"initialDate"`                                                  // initial date range to accumulate traveled distance
+	FinalDate   time.Time    `json:"finalDate"`                                                    // final date range to accumulate traveled distance
+	Page        uint64       `json:"page" validate:"min=1"`                                        // page number to show up. It belongs to range [1 to +infinite)
+	ItemsLimit  uint64       `json:"itemsLimit" validate:"min=1,max=1000"`                         // quantity of items per page. It belongs to range [1 to 1000]
+}
@@ -0,0 +1,16 @@
+package dto
+
+// LeaderboardEntry is the rank of a username by traveled distance.
+type LeaderboardEntry struct {
+	Rank     uint64  `json:"rank"`     // position of the username. Usernames with the same distance share the rank, and the next rank skips the tied positions
+	Username string  `json:"userName"` // username
+	Distance float64 `json:"distance"` // distance traveled by the device of the username that traveled the most
+}
+
+// GetLeaderboardResponse is http response of GetLeaderboard service
+type GetLeaderboardResponse struct {
+	Entries    []LeaderboardEntry `json:"entries"`       // ranked usernames ordered by rank and username
+	Own        *LeaderboardEntry  `json:"own,omitempty"` // rank of the requesting username. Empty if it wasn't requested or traveled no distance
+	TotalItems uint64             `json:"totalItems"`    // total number of items
+	TotalPages uint64             `json:"totalPages"`    // total number of pages
+}
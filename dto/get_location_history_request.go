@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// GetLocationHistoryRequest is a http request of GetLocationHistory service.
+type GetLocationHistoryRequest struct {
+	UserName    string    `json:"username" validate:"required,min=4,max=16,patternazAZ09"` // username whose locations are listed. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	DeviceId    string    `json:"deviceId" validate:"max=64"`                              // device whose locations are listed. Empty lists every device
+	InitialDate time.Time `json:"initialDate"`                                             // initial date of the listed range
+	FinalDate   time.Time `json:"finalDate"`                                               // final date of the listed range
+	Page        uint64    `json:"page" validate:"min=1"`                                   // page number to show up. It belongs to range [1 to +infinite)
+	ItemsLimit  uint64    `json:"itemsLimit" validate:"min=1,max=1000"`                    // quantity of items per page. It belongs to range [1 to 1000]
+	Tolerance   float64   `json:"tolerance" validate:"min=0"`                              // distance in meters from the simplified locations of the page within which locations are dropped. Zero keeps every location that isn't limited by MaxPoints. Pages to simplify must have locations of one device
+	MaxPoints   uint64    `json:"maxPoints" validate:"omitempty,min=2,max=1000"`           // maximum number of simplified locations of the page. Zero for no limit, otherwise it belongs to range 2 to 1000
+	Encoded     bool      `json:"encoded"`                                                 // returns the locations of the page as an encoded polyline instead of records
+}
@@ -0,0 +1,9 @@
+package dto
+
+// GetLocationHistoryResponse is a http response of GetLocationHistory service
+type GetLocationHistoryResponse struct {
+	Locations  []LocationHistory `json:"locations"`          // username's locations ordered by date, simplified if requested. Empty when the locations are encoded
+	Polyline   string            `json:"polyline,omitempty"` // simplified locations of the page in encoded polyline format with precision 5. Empty unless the locations are encoded
+	TotalItems uint64            `json:"totalItems"`         // total number of items, before simplification
+	TotalPages uint64            `json:"totalPages"`         // total number of pages
+}
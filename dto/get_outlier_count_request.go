@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// GetOutlierCountRequest is a http request of GetOutlierCount service.
+type GetOutlierCountRequest struct {
+	UserName    string    `json:"username" validate:"required,min=4,max=16,patternazAZ09"` // username whose outliers are counted. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	DeviceId    string    `json:"deviceId" validate:"max=64"`                              // device whose outliers are counted. Empty counts every device
+	InitialDate time.Time `json:"initialDate"`                                             // initial date range to count outliers
+	FinalDate   time.Time `json:"finalDate"`                                               // final date range to count outliers
+}
@@ -0,0 +1,8 @@
+package dto
+
+// GetOutlierCountResponse is a http response of GetOutlierCount service
+type GetOutlierCountResponse struct {
+	Username string `json:"userName"` // username
+	DeviceId string `json:"deviceId"` // device whose outliers were counted. Empty for every device
+	Outliers uint64 `json:"outliers"` // number of locations flagged as outliers
+}
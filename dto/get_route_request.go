@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// GetRouteRequest is a http request of GetRoute service.
+type GetRouteRequest struct {
+	UserName    string    `json:"username" validate:"required,min=4,max=16,patternazAZ09"` // username whose route is drawn. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	DeviceId    string    `json:"deviceId" validate:"max=64"`                              // device whose route is drawn. Empty selects the device that traveled the most
+	InitialDate time.Time `json:"initialDate"`                                             // initial date of the route
+	FinalDate   time.Time `json:"finalDate"`                                               // final date of the route
+	Tolerance   float64   `json:"tolerance" validate:"min=0"`                              // distance in meters from the simplified route within which points are dropped. Zero keeps every point that isn't limited by MaxPoints
+	MaxPoints   uint64    `json:"maxPoints" validate:"omitempty,min=2,max=100000"`         // maximum number of points of the simplified route. Zero for no limit, otherwise it belongs to range 2 to 100000
+	Encoded     bool      `json:"encoded"`                                                 // returns the route as an encoded polyline instead of points
+}
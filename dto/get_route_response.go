@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// RoutePoint is a point of a route.
+type RoutePoint struct {
+	Latitude  float64   `json:"latitude"`  // latitude coordinate of a geographic point
+	Longitude float64   `json:"longitude"` // longitude coordinate of a geographic point
+	UpdatedAt time.Time `json:"updatedAt"` // date of the location
+}
+
+// GetRouteResponse is http response of GetRoute service
+type GetRouteResponse struct {
+	UserName      string       `json:"userName"`           // username
+	DeviceId      string       `json:"deviceId"`           // device whose route is drawn
+	Points        []RoutePoint `json:"points,omitempty"`   // points of the simplified route ordered by date. Empty when the route is encoded
+	Polyline      string       `json:"polyline,omitempty"` // simplified route in encoded polyline format with precision 5. Empty unless the route is encoded
+	PointCount    uint64       `json:"pointCount"`         // number of points of the simplified route
+	TotalPoints   uint64       `json:"totalPoints"`        // number of points of the unsimplified route
+	TotalDistance float64      `json:"totalDistance"`      // traveled distance of the device in kilometers, accumulated from every location of the range
+}
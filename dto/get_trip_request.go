@@ -0,0 +1,7 @@
+package dto
+
+// GetTripRequest is a http request of GetTrip service.
+type GetTripRequest struct {
+	UserName string `json:"username" validate:"required,min=4,max=16,patternazAZ09"` // username that owns the trip. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	TripId   int64  `json:"tripId" validate:"required,min=1"`                        // trip identifier
+}
@@ -0,0 +1,9 @@
+package dto
+
+// GetUsersInAreaResponse is a http response of GetUsersInPolygon and GetUsersInBoundingBox
+// services.
+type GetUsersInAreaResponse struct {
+	Users      []Location `json:"users"`      // username's location list located in the area, ordered by username
+	TotalItems uint64     `json:"totalItems"` // total number of items
+	TotalPages uint64     `json:"totalPages"` // total number of pages
+}
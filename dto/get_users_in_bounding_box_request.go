@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// GetUsersInBoundingBoxRequest is a http request of GetUsersInBoundingBox service.
+type GetUsersInBoundingBoxRequest struct {
+	Area       BoundingBox   `json:"area"`                                 // area of the usernames
+	MaxAge     time.Duration `json:"maxAge" validate:"min=0"`              // maximum age of the location of a username. Zero uses the configured maximum
+	Page       uint64        `json:"page" validate:"min=1"`                // page number to show up. It belongs to range [1 to +infinite)
+	ItemsLimit uint64        `json:"itemsLimit" validate:"min=1,max=1000"` // quantity of items per page. It belongs to range [1 to 1000]
+}
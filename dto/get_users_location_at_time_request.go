@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// GetUsersByLocationAtTimeRequest is a http request of GetUsersByLocationAtTime service.
+type GetUsersByLocationAtTimeRequest struct {
+	Latitude     float64       `json:"latitude" validate:"min=-90,max=90,maxDecimals"`    // latitude coordinate of the center. It belongs to range -90 to 90, allows 8 decimal positions
+	Longitude    float64       `json:"longitude" validate:"min=-180,max=180,maxDecimals"` // longitude coordinate of the center. It belongs to range -180 to 180, allows 8 decimal positions
+	Radius       float64       `json:"radius" validate:"gt=0"`                            // range radius in kilometers. It belongs to range (0 to +infinite)
+	At           time.Time     `json:"at" validate:"required"`                            // date of the snapshot
+	MaxStaleness time.Duration `json:"maxStaleness" validate:"min=0"`                     // maximum age at the snapshot date of the last location of a username. Zero uses the configured maximum
+	Tolerance    time.Duration `json:"tolerance" validate:"min=0"`                        // time after the snapshot date whose locations still count as at the snapshot date
+	Page         uint64        `json:"page" validate:"min=1"`                             // page number to show up. It belongs to range [1 to +infinite)
+	ItemsLimit   uint64        `json:"itemsLimit" validate:"min=1,max=1000"`              // quantity of items per page. It belongs to range [1 to 1000]
+}
@@ -0,0 +1,8 @@
+package dto
+
+// GetUsersByLocationAtTimeResponse is a http response of GetUsersByLocationAtTime service
+type GetUsersByLocationAtTimeResponse struct {
+	Users      []Location `json:"users"`      // last location at the snapshot date of the usernames within the radius, ordered by username
+	TotalItems uint64     `json:"totalItems"` // total number of items
+	TotalPages uint64     `json:"totalPages"` // total number of pages
+}
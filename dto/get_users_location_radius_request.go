@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// GetUsersByLocationAndRadiusRequest is a http request of GetUsersByLocationAndRadius service
+type GetUsersByLocationAndRadiusRequest struct {
+	Latitude   float64       `json:"latitude" validate:"min=-90,max=90,maxDecimals"`    // latitude coordinate of username's location. It belongs to range -90 to 90, allows 8 decimal positions
+	Longitude  float64       `json:"longitude" validate:"min=-180,max=180,maxDecimals"` // longitude coordinate of username's location. It belongs to range -180 to 180, allows 8 decimal positions
+	Radius     float64       `json:"radius" validate:"gt=0"`                            // range radius in kilometers. It belongs to range (0 to +infinite)
+	MaxAge     time.Duration `json:"maxAge" validate:"min=0"`                           // maximum age of the location of a username. Zero uses the configured maximum
+	Page       uint64        `json:"page"`                                              // page number to show up. It belongs to range [1 to +infinite) unless a cursor is set
+	ItemsLimit uint64        `json:"itemsLimit" validate:"min=1,max=1000"`              // quantity of items per page. It belongs to range [1 to 1000]
+	Cursor     string        `json:"cursor"`                                            // opaque position returned as NextCursor by a previous search. When set, the page follows it and Page is ignored
+}
@@ -0,0 +1,9 @@
+package dto
+
+// GetUsersByLocationAndRadiusResponse is a http response of GetUsersByLocationAndRadius service
+type GetUsersByLocationAndRadiusResponse struct {
+	Users      []Location `json:"users"`      // username's location list located in a given radius
+	TotalItems uint64     `json:"totalItems"` // total number of items
+	TotalPages uint64     `json:"totalPages"` // total number of pages
+	NextCursor string     `json:"nextCursor"` // opaque position of the last returned item to get the following items, empty on the last page
+}
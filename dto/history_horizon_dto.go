@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// HistoryHorizon describes a database HistoryHorizon entity. Records the date before which
+// the LocationHistory records of a username were pruned by retention. Their distances are
+// kept by DistanceRollup records, which are no longer rebuilt from the history.
+type HistoryHorizon struct {
+	tableName    struct{}  `pg:"history_horizon,alias:historyHorizon"`       // name of the table. Control field not visible
+	TenantId     string    `json:"tenantId" pg:"tenant_id, pk"`              // tenant that owns the username
+	UserName     string    `json:"userName" pg:"username, pk"`               // username
+	PrunedBefore time.Time `json:"prunedBefore" pg:"pruned_before, notnull"` // date before which the history was pruned
+	UpdatedAt    time.Time `json:"updatedAt" pg:"updated_at, notnull"`       // date of the last pruning
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+// HistoryPartition is a monthly partition of the LocationHistory table.
+type HistoryPartition struct {
+	Name    string    `json:"name" pg:"name"`       // name of the partition table
+	Month   time.Time `json:"month" pg:"-"`         // start of the UTC month of the partition
+	Records int       `json:"records" pg:"records"` // number of records, estimated by the planner statistics until dropped
+}
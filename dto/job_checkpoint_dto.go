@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// JobCheckpoint describes a database JobCheckpoint entity. Records the last username
+// completed by a maintenance job that walks usernames in order, so an interrupted run
+// resumes after it.
+type JobCheckpoint struct {
+	tableName struct{}  `pg:"job_checkpoint,alias:jobCheckpoint"`   // name of the table. Control field not visible
+	TenantId  string    `json:"tenantId" pg:"tenant_id, pk"`        // tenant walked by the job
+	Job       string    `json:"job" pg:"job, pk"`                   // name of the job and its options
+	UserName  string    `json:"userName" pg:"username, notnull"`    // last username completed
+	UpdatedAt time.Time `json:"updatedAt" pg:"updated_at, notnull"` // date of the checkpoint
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// ListEncountersRequest is a http request of ListEncounters and FindEncounters services.
+type ListEncountersRequest struct {
+	UserName    string    `json:"username" validate:"required,min=4,max=16,patternazAZ09"` // username whose encounters are listed. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	InitialDate time.Time `json:"initialDate"`                                             // initial date of the listed range
+	FinalDate   time.Time `json:"finalDate"`                                               // final date of the listed range
+	Page        uint64    `json:"page" validate:"min=1"`                                   // page number to show up. It belongs to range [1 to +infinite)
+	ItemsLimit  uint64    `json:"itemsLimit" validate:"min=1,max=1000"`                    // quantity of items per page. It belongs to range [1 to 1000]
+}
@@ -0,0 +1,8 @@
+package dto
+
+// ListEncountersResponse is a http response of ListEncounters and FindEncounters services
+type ListEncountersResponse struct {
+	Encounters []Encounter `json:"encounters"` // username's encounters ordered by start date, naming the requested username first
+	TotalItems uint64      `json:"totalItems"` // total number of items
+	TotalPages uint64      `json:"totalPages"` // total number of pages
+}
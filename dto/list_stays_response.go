@@ -0,0 +1,8 @@
+package dto
+
+// ListStaysResponse is a http response of ListStays service
+type ListStaysResponse struct {
+	Stays      []Stay `json:"stays"`      // username's stays ordered by arrival date
+	TotalItems uint64 `json:"totalItems"` // total number of items
+	TotalPages uint64 `json:"totalPages"` // total number of pages
+}
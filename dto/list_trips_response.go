@@ -0,0 +1,8 @@
+package dto
+
+// ListTripsResponse is a http response of ListTrips service
+type ListTripsResponse struct {
+	Trips      []Trip `json:"trips"`      // username's trips ordered by start date
+	TotalItems uint64 `json:"totalItems"` // total number of items
+	TotalPages uint64 `json:"totalPages"` // total number of pages
+}
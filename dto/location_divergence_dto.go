@@ -0,0 +1,13 @@
+package dto
+
+// LocationDivergence is a difference between the Location record of a username and its
+// LocationHistory records. The Location record of a username must have the coordinates
+// of the latest LocationHistory record of its device that isn't an outlier.
+type LocationDivergence struct {
+	UserName         string   `json:"userName" pg:"username"`                  // username
+	DeviceId         string   `json:"deviceId" pg:"device_id"`                 // device of the Location record, or of the latest LocationHistory record if it's missing
+	Latitude         *float64 `json:"latitude" pg:"latitude"`                  // latitude coordinate of the Location record. Empty if it's missing
+	Longitude        *float64 `json:"longitude" pg:"longitude"`                // longitude coordinate of the Location record. Empty if it's missing
+	HistoryLatitude  *float64 `json:"historyLatitude" pg:"history_latitude"`   // latitude coordinate of the latest LocationHistory record. Empty if it's missing
+	HistoryLongitude *float64 `json:"historyLongitude" pg:"history_longitude"` // longitude coordinate of the latest LocationHistory record. Empty if it's missing
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// Location describes a database Location entity. Defines a geographic
+// point of a username at a given date through latitude and longitude coordinates.
+// A username is unique within its tenant. The coordinates are reported by one of the
+// username's devices, chosen by the current location policy.
+type Location struct {
+	tableName          struct{}  `pg:"location,alias:location"`                                             // name of the table. Control field not visible
+	Id                 int64     `json:"id" pg:",pk"`                                                       // record identifier
+	TenantId           string    `json:"tenantId" pg:"tenant_id, notnull, unique:location_tenant_username"` // tenant that owns the username
+	UserName           string    `json:"userName" pg:"username, notnull, unique:location_tenant_username"`  // username
+	DeviceId           string    `json:"deviceId" pg:"device_id, use_zero, notnull"`                        // device that reported the current coordinates
+	PrimaryDeviceId    string    `json:"primaryDeviceId" pg:"primary_device_id, use_zero, notnull"`         // device that drives the current coordinates under the primary policy
+	Latitude           float64   `json:"latitude" pg:",use_zero, notnull"`                                  // latitude coordinate of a geographic point
+	Longitude          float64   `json:"longitude" pg:",use_zero, notnull"`                                 // longitude coordinate of a geographic point
+	HorizontalAccuracy *float64  `json:"horizontalAccuracy,omitempty" pg:"horizontal_accuracy"`             // radius in meters of the horizontal position uncertainty of the current coordinates
+	UpdatedAt          time.Time `json:"updatedAt" pg:"updated_at, notnull"`                                // date of later update
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// LocationFilterState describes a database LocationFilterState entity. Defines the
+// state of the Kalman smoother of the locations reported by a device of a username:
+// the smoothed coordinates and their variance at the date of the last location.
+type LocationFilterState struct {
+	tableName struct{}  `pg:"location_filter_state,alias:locationFilterState"`                                                   // name of the table. Control field not visible
+	Id        int64     `json:"id" pg:",pk"`                                                                                     // record identifier
+	TenantId  string    `json:"tenantId" pg:"tenant_id, notnull, unique:location_filter_state_tenant_username_device"`           // tenant that owns the username
+	UserName  string    `json:"userName" pg:"username, notnull, unique:location_filter_state_tenant_username_device"`            // username
+	DeviceId  string    `json:"deviceId" pg:"device_id, use_zero, notnull, unique:location_filter_state_tenant_username_device"` // device whose locations are smoothed
+	Latitude  float64   `json:"latitude" pg:",use_zero, notnull"`                                                                // smoothed latitude coordinate
+	Longitude float64   `json:"longitude" pg:",use_zero, notnull"`                                                               // smoothed longitude coordinate
+	Variance  float64   `json:"variance" pg:",use_zero, notnull"`                                                                // variance in square meters of the smoothed position
+	UpdatedAt time.Time `json:"updatedAt" pg:"updated_at, notnull"`                                                              // date of the last smoothed location
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// LocationHistory describes a database LocationHistory entity. Defines a historic
+// register of geographic points of usernames by dates through latitude and longitude
+// coordinates. Distances are chained by device, skipping records with an exclusion
+// reason.
+type LocationHistory struct {
+	tableName         struct{}  `pg:"location_history,alias:locationHistory"`                // name of the table. Control field not visible
+	Id                int64     `json:"id" pg:",pk"`                                         // record identifier
+	TenantId          string    `json:"tenantId" pg:"tenant_id, notnull"`                    // tenant that owns the username
+	UserName          string    `json:"userName"  pg:"username, notnull"`                    // username
+	DeviceId          string    `json:"deviceId"  pg:"device_id, use_zero, notnull"`         // device that reported the location
+	TransportMode     string    `json:"transportMode,omitempty" pg:"transport_mode"`         // transport mode reported with the location
+	Latitude          float64   `json:"latitude"  pg:",use_zero, notnull"`                   // latitude coordinate of a geographic point
+	Longitude         float64   `json:"longitude" pg:",use_zero, notnull"`                   // longitude coordinate of a geographic point
+	UpdatedAt         time.Time `json:"updatedAt" pg:"updated_at, notnull"`                  // date of update
+	Distance          float64   `json:"distance" pg:",use_zero"`                             // traveled distance by the device from its last to current location
+	ExcludedReason    string    `json:"excludedReason,omitempty" pg:"excluded_reason"`       // reason to exclude the record from distance accumulation. Empty for included records
+	FilteredLatitude  *float64  `json:"filteredLatitude,omitempty" pg:"filtered_latitude"`   // latitude coordinate smoothed by the movement filter. Empty when smoothing is disabled
+	FilteredLongitude *float64  `json:"filteredLongitude,omitempty" pg:"filtered_longitude"` // longitude coordinate smoothed by the movement filter. Empty when smoothing is disabled
+	DistanceAlgorithm string    `json:"distanceAlgorithm,omitempty" pg:"distance_algorithm"` // algorithm that measured the distance. Empty for records measured with haversine before it was recorded
+	Fix                         // quality and motion of the fix reported by the device
+}
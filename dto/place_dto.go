@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// Place is a location frequently visited by a username, made of the stays of its
+// devices whose centroids are within the place radius.
+type Place struct {
+	Latitude       float64   `json:"latitude"`       // latitude coordinate of the centroid of the stays
+	Longitude      float64   `json:"longitude"`      // longitude coordinate of the centroid of the stays
+	Visits         uint64    `json:"visits"`         // number of stays
+	Days           uint64    `json:"days"`           // number of distinct days with stays
+	TotalDuration  float64   `json:"totalDuration"`  // seconds spent in the stays
+	FirstArrivedAt time.Time `json:"firstArrivedAt"` // date of the first arrival
+	LastDepartedAt time.Time `json:"lastDepartedAt"` // date of the last departure
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "encoding/json"
+
+// Polygon is a geographic area bounded by an exterior linear ring, with optional holes
+// bounded by further linear rings. Rings are lists of [longitude, latitude] positions,
+// as in GeoJSON, and may repeat the first position at the end. A ring with an edge
+// longer than 180 degrees of longitude crosses the antimeridian.
+type Polygon [][][2]float64
+
+// GeoJSONGeometry is a GeoJSON geometry of type Polygon or MultiPolygon.
+type GeoJSONGeometry struct {
+	Type        string          `json:"type" validate:"oneof=Polygon MultiPolygon"` // type of the geometry. It belongs to values Polygon and MultiPolygon
+	Coordinates json.RawMessage `json:"coordinates" validate:"required"`            // coordinates of the geometry, a polygon or a list of polygons by type. It is required
+}
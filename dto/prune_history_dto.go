@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// PruneHistoryRequest is a request of PruneHistory method.
+type PruneHistoryRequest struct {
+	UserName string // username whose history is pruned. Empty for every username of the tenant
+	DryRun   bool   // reports the records to prune without pruning them
+}
+
+// PrunableDay is a day, truncated in UTC, with LocationHistory records to prune.
+type PrunableDay struct {
+	Day     time.Time `json:"day" pg:"day"`         // start of the day
+	Records int       `json:"records" pg:"records"` // number of records removed by pruning the day
+}
+
+// UserPruning reports the pruning of the history of a username.
+type UserPruning struct {
+	UserName string        `json:"userName"` // username
+	Cutoff   time.Time     `json:"cutoff"`   // date before which its history is pruned
+	Days     []PrunableDay `json:"days"`     // pruned days, or the days to prune of a dry run
+	Records  int           `json:"records"`  // number of pruned records, or of records to prune of a dry run
+}
+
+// PruneHistoryResponse is a response of PruneHistory method.
+type PruneHistoryResponse struct {
+	Mode      string        `json:"mode"`      // retention mode, delete or downsample
+	UserNames []UserPruning `json:"userNames"` // usernames with records to prune
+	Records   int           `json:"records"`   // number of pruned records, or of records to prune of a dry run
+	Pruned    bool          `json:"pruned"`    // whether the records were pruned
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// RecomputeDistancesRequest is a request of RecomputeDistances method.
+type RecomputeDistancesRequest struct {
+	UserName string // username whose distances are recomputed. Empty for every username of the tenant
+	DryRun   bool   // reports discrepancies without fixing them
+}
+
+// DistanceDiscrepancy is a LocationHistory record whose stored distance differs from
+// the distance recomputed from the previous record of its device.
+type DistanceDiscrepancy struct {
+	UserName          string    `json:"userName"`          // username
+	DeviceId          string    `json:"deviceId"`          // device that reported the location
+	LocationHistoryId int64     `json:"locationHistoryId"` // identifier of the LocationHistory record
+	UpdatedAt         time.Time `json:"updatedAt"`         // date of the LocationHistory record
+	Stored            float64   `json:"stored"`            // stored distance in kilometers
+	Recomputed        float64   `json:"recomputed"`        // recomputed distance in kilometers
+	Algorithm         string    `json:"algorithm"`         // distance algorithm of the recomputed distance
+}
+
+// RecomputeDistancesResponse is a response of RecomputeDistances method.
+type RecomputeDistancesResponse struct {
+	UserNames     int                   `json:"userNames"`     // number of usernames walked, skipping the ones completed by an interrupted run
+	Records       int                   `json:"records"`       // number of LocationHistory records walked
+	Discrepancies []DistanceDiscrepancy `json:"discrepancies"` // records whose stored distance is wrong
+	Divergences   []LocationDivergence  `json:"divergences"`   // walked usernames whose Location record diverges from their history
+	Fixed         bool                  `json:"fixed"`         // whether discrepancies and divergences were fixed
+}
@@ -0,0 +1,12 @@
+package dto
+
+// SaveLocationRequest is a http request of Save service. DeviceId identifies the device
+// that reported the location, users with a single device may leave it empty.
+type SaveLocationRequest struct {
+	UserName      string  `json:"username" validate:"required,min=4,max=16,patternazAZ09"`            // username located in one geographic point. It is required, belongs to length range 4 to 16, belongs regex pattern PatternUserNameRegexString
+	DeviceId      string  `json:"deviceId" validate:"max=64"`                                         // device that reported the location. Up to 64 characters
+	TransportMode string  `json:"transportMode" validate:"omitempty,oneof=walk bike car train plane"` // transport mode of the username: walk, bike, car, train or plane. It selects the maximum plausible speed
+	Latitude      float64 `json:"latitude" validate:"required,min=-90,max=90,maxDecimals"`            // latitude coordinate of username's location. It is required, belongs to range -90 to 90, allows 8 decimal positions
+	Longitude     float64 `json:"longitude" validate:"required,min=-180,max=180,maxDecimals"`         // longitude coordinate of username's location. It is required, belongs to range -180 to 180, allows 8 decimal positions
+	Fix                   // quality and motion of the fix reported by the device
+}
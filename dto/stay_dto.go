@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// Stay describes a database Stay entity. Defines a place where a device of a username
+// stopped: the centroid of its consecutive locations within the stay radius, from the
+// first to the last of them, that lasted the minimum stay duration.
+type Stay struct {
+	tableName  struct{}  `pg:"stay,alias:stay"`                              // name of the table. Control field not visible
+	Id         int64     `json:"id" pg:",pk"`                                // record identifier
+	TenantId   string    `json:"tenantId" pg:"tenant_id, notnull"`           // tenant that owns the username
+	UserName   string    `json:"userName" pg:"username, notnull"`            // username
+	DeviceId   string    `json:"deviceId" pg:"device_id, use_zero, notnull"` // device whose locations make up the stay
+	Status     string    `json:"status" pg:"status, notnull"`                // candidate, open or closed
+	Latitude   float64   `json:"latitude" pg:",use_zero, notnull"`           // latitude coordinate of the centroid of the locations
+	Longitude  float64   `json:"longitude" pg:",use_zero, notnull"`          // longitude coordinate of the centroid of the locations
+	ArrivedAt  time.Time `json:"arrivedAt" pg:"arrived_at, notnull"`         // date of the first location of the stay
+	DepartedAt time.Time `json:"departedAt" pg:"departed_at, notnull"`       // date of the last location of the stay
+	Duration   float64   `json:"duration" pg:",use_zero, notnull"`           // seconds from arrival to departure
+	PointCount int       `json:"pointCount" pg:"point_count, use_zero"`      // number of locations of the stay
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// Trip describes a database Trip entity. Defines a continuous movement of a device of a
+// username, from the location where it started moving to the last location where it
+// moved. Trips are segmented from the LocationHistory records of the device, closing
+// them after the device stops moving or stops reporting locations.
+type Trip struct {
+	tableName      struct{}  `pg:"trip,alias:trip"`                              // name of the table. Control field not visible
+	Id             int64     `json:"id" pg:",pk"`                                // record identifier
+	TenantId       string    `json:"tenantId" pg:"tenant_id, notnull"`           // tenant that owns the username
+	UserName       string    `json:"userName" pg:"username, notnull"`            // username
+	DeviceId       string    `json:"deviceId" pg:"device_id, use_zero, notnull"` // device whose locations make up the trip
+	Status         string    `json:"status" pg:"status, notnull"`                // open or closed
+	StartedAt      time.Time `json:"startedAt" pg:"started_at, notnull"`         // date of the location where the device started moving
+	EndedAt        time.Time `json:"endedAt" pg:"ended_at, notnull"`             // date of the last location where the device moved
+	StartLatitude  float64   `json:"startLatitude" pg:",use_zero, notnull"`      // latitude coordinate where the trip started
+	StartLongitude float64   `json:"startLongitude" pg:",use_zero, notnull"`     // longitude coordinate where the trip started
+	EndLatitude    float64   `json:"endLatitude" pg:",use_zero, notnull"`        // latitude coordinate where the trip ended
+	EndLongitude   float64   `json:"endLongitude" pg:",use_zero, notnull"`       // longitude coordinate where the trip ended
+	Distance       float64   `json:"distance" pg:",use_zero, notnull"`           // traveled distance in kilometers
+	Duration       float64   `json:"duration" pg:",use_zero, notnull"`           // seconds from start to end
+	MaxSpeed       float64   `json:"maxSpeed" pg:"max_speed, use_zero, notnull"` // maximum speed in meters per second
+	PointCount     int       `json:"pointCount" pg:"point_count, use_zero"`      // number of locations of the trip, including stationary ones
+	LastPointAt    time.Time `json:"lastPointAt" pg:"last_point_at, notnull"`    // date of the last location of the trip, including stationary ones
+}
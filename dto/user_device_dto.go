@@ -0,0 +1,7 @@
+package dto
+
+// UserDevice identifies a device of a username that reported locations.
+type UserDevice struct {
+	UserName string `json:"userName" pg:"username"`  // username
+	DeviceId string `json:"deviceId" pg:"device_id"` // device of the username
+}
@@ -0,0 +1,133 @@
+// Package enums implements constants of location-history-mgmt microservice
+// that are not shared with other location microservices.
+package enums
+
+const (
+	ErrorQueryCanceledCode = "error query canceled"
+	ErrorQueryCanceledMsg  = "error query %s canceled by caller: %v"
+	ErrorQueryTimeoutCode  = "error query timeout"
+	ErrorQueryTimeoutMsg   = "error query %s exceeded its deadline: %v"
+)
+
+const (
+	ErrorUnauthenticatedCode = "error unauthenticated"
+	ErrorUnauthenticatedMsg  = "error missing or invalid credentials: %v"
+	ErrorForbiddenCode       = "error forbidden"
+	ErrorForbiddenUserMsg    = "error %s is not allowed to access username %s data"
+	ErrorForbiddenScopeMsg   = "error %s requires one of the scopes %v"
+	ErrorGetApiKeyCode       = "error getting api key"
+	ErrorApiKeyNotFoundMsg   = "error api key not found or revoked"
+	ErrorInsertApiKeyCode    = "error inserting api key"
+)
+
+const (
+	ErrorRateLimitedCode = "error rate limit exceeded"
+	ErrorRateLimitedMsg  = "error too many requests by %s %s, retry after %v"
+)
+
+const (
+	ErrorTenantInvalidCode   = "error invalid tenant"
+	ErrorTenantInvalidMsg    = "error tenant %q is not a valid tenant id"
+	ErrorTenantForbiddenCode = "error tenant forbidden"
+	ErrorTenantForbiddenMsg  = "error %s is not allowed to access tenant %s"
+)
+
+const (
+	ErrorGetLocationFilterStateCode      = "error getting location filter state"
+	ErrorSaveLocationFilterStateCode     = "error saving location filter state"
+	ErrorLocationFilterStateNotFoundCode = "error location filter state not found"
+	ErrorLocationFilterStateNotFoundMsg  = "error username %s has no filter state for device %q"
+)
+
+const (
+	ErrorImplausibleSpeedCode = "error implausible speed"
+	ErrorImplausibleSpeedMsg  = "error implied speed %.1f m/s exceeds the maximum %.1f m/s of transport mode %q"
+	ErrorCountOutliersCode    = "error counting outliers"
+)
+
+const (
+	ErrorGetTripCode      = "error getting trip"
+	ErrorSaveTripCode     = "error saving trip"
+	ErrorDeleteTripsCode  = "error deleting trips"
+	ErrorTripNotFoundCode = "error trip not found"
+	ErrorTripNotFoundMsg  = "error username %s has no trip %d"
+	ErrorListTripsCode    = "error listing trips"
+)
+
+const (
+	ErrorGetStayCode      = "error getting stay"
+	ErrorSaveStayCode     = "error saving stay"
+	ErrorDeleteStaysCode  = "error deleting stays"
+	ErrorStayNotFoundCode = "error stay not found"
+	ErrorStayNotFoundMsg  = "error username %s has no current stay for device %q"
+	ErrorListStaysCode    = "error listing stays"
+)
+
+const (
+	ErrorGetDistanceBreakdownCode   = "error getting distance breakdown"
+	ErrorRebuildDistanceRollupsCode = "error rebuilding distance rollups"
+	ErrorTimeZoneInvalidCode        = "error invalid time zone"
+	ErrorTimeZoneInvalidMsg         = "error time zone %q is not a valid IANA time zone"
+	ErrorTooManyBucketsCode         = "error too many buckets"
+	ErrorTooManyBucketsMsg          = "error the range spans more than %d %s buckets"
+)
+
+const (
+	ErrorGetLeaderboardCode = "error getting leaderboard"
+	ErrorGetSnapshotCode    = "error getting snapshot"
+)
+
+const (
+	ErrorGetEncounterCode   = "error getting encounter"
+	ErrorSaveEncounterCode  = "error saving encounter"
+	ErrorListEncountersCode = "error listing encounters"
+	ErrorEncounterRangeCode = "error encounter range too long"
+	ErrorEncounterRangeMsg  = "error encounters are scanned in ranges of up to %v"
+	ErrorGetTrackCode       = "error getting track"
+)
+
+const (
+	ErrorPolygonInvalidCode = "error polygon invalid"
+	ErrorPolygonInvalidMsg  = "error polygon %d is invalid: %s"
+)
+
+const (
+	ErrorPageInvalidCode   = "error page invalid"
+	ErrorPageInvalidMsg    = "error page must be positive and items limit must belong to range 1 to %d"
+	ErrorPageOutOfRangeMsg = "error page %d of %d items is out of range"
+	ErrorCursorInvalidCode = "error cursor invalid"
+	ErrorCursorInvalidMsg  = "error cursor %q is invalid"
+)
+
+const (
+	ErrorCheckLocationsCode   = "error checking locations"
+	ErrorRebuildLocationsCode = "error rebuilding locations"
+)
+
+const (
+	ErrorUpdateDistancesCode = "error updating distances"
+	ErrorJobCheckpointCode   = "error managing job checkpoint"
+)
+
+const (
+	ErrorRouteRangeCode = "error route range too long"
+	ErrorRouteRangeMsg  = "error routes are drawn in ranges of up to %v"
+)
+
+const (
+	ErrorHistoryDeviceRequiredCode = "error history device required"
+	ErrorHistoryDeviceRequiredMsg  = "error locations of several devices can't be simplified or encoded, select a device"
+)
+
+const (
+	ErrorGetPrunableDaysCode = "error getting prunable days"
+	ErrorPruneHistoryCode    = "error pruning history"
+	ErrorHistoryHorizonCode  = "error getting history horizon"
+	ErrorGetUserNamesCode    = "error getting usernames"
+)
+
+const (
+	ErrorCreatePartitionsCode = "error creating history partitions"
+	ErrorGetPartitionsCode    = "error getting history partitions"
+	ErrorDropPartitionCode    = "error dropping history partition"
+)
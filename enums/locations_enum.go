@@ -0,0 +1,42 @@
+package enums
+
+// Reasons to exclude a LocationHistory record from distance accumulation. Excluded
+// records are stored with zero distance and are never the start of the next distance.
+const (
+	ExcludedLowAccuracy = "low_accuracy" // horizontal accuracy worse than the configured maximum
+	ExcludedJitter      = "jitter"       // displacement from the previous location within the jitter threshold
+	ExcludedOutlier     = "outlier"      // implied speed from the previous location above the maximum of the transport mode
+)
+
+// Statuses of a Trip record. A device has at most one open trip, which is extended by
+// its moving locations until it's closed by a dwell or a gap.
+const (
+	TripOpen   = "open"   // trip still being extended by the locations of the device
+	TripClosed = "closed" // trip ended by a dwell or a gap
+)
+
+// Statuses of a Stay record. A device has at most one stay that isn't closed, which is
+// extended by its locations within the stay radius.
+const (
+	StayCandidate = "candidate" // locations within the stay radius for less than the minimum duration. Not listed
+	StayOpen      = "open"      // stay where the device still is
+	StayClosed    = "closed"    // stay the device left
+)
+
+// Statuses of an Encounter record. A pair of usernames has at most one encounter that
+// isn't closed, which is extended while their locations are within the encounter
+// distance.
+const (
+	EncounterCandidate = "candidate" // usernames within the encounter distance for less than the minimum duration. Not listed
+	EncounterOpen      = "open"      // encounter still going on
+	EncounterClosed    = "closed"    // encounter of usernames that moved apart
+)
+
+// Buckets of a distance breakdown. Buckets start at the local time of the requested time
+// zone, so days, weeks and months last as long as the wall clock says across DST changes.
+const (
+	BucketHour  = "hour"  // clock hour
+	BucketDay   = "day"   // calendar day from midnight
+	BucketWeek  = "week"  // calendar week from Monday midnight
+	BucketMonth = "month" // calendar month from midnight of its first day
+)
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// EchoRequestID returns the echo middleware that assigns the request correlation id.
+// The id is taken from the X-Request-Id header, or generated, echoed back in the
+// response and stored in the request context.
+func EchoRequestID() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, requestID string) {
+			req := c.Request()
+			c.SetRequest(req.WithContext(WithRequestID(req.Context(), requestID)))
+		},
+	})
+}
+
+// EchoLogger returns the echo middleware that writes one log line per http request.
+// It must be registered after EchoRequestID.
+func EchoLogger(l *logrus.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			req := c.Request()
+			entry := FromContext(req.Context(), l).WithFields(logrus.Fields{
+				"method":  req.Method,
+				"uri":     req.RequestURI,
+				"status":  c.Response().Status,
+				"latency": time.Since(start).String(),
+			})
+			if err != nil {
+				entry.WithError(err).Error("http request failed")
+			} else {
+				entry.Info("http request")
+			}
+
+			return nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+	"github.com/labstack/gommon/random"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// MetadataRequestID is the grpc metadata key that carries the request correlation id.
+const MetadataRequestID = "x-request-id"
+
+// requestIDLength is the length of generated request correlation ids.
+const requestIDLength = 32
+
+// UnaryServerInterceptor returns the grpc interceptor equivalent to EchoRequestID and
+// EchoLogger. It takes the correlation id from the x-request-id metadata key, or
+// generates it, sends it back as response header, stores it in the call context and
+// writes one log line per call.
+func UnaryServerInterceptor(l *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		var requestID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(MetadataRequestID)) > 0 {
+			requestID = md.Get(MetadataRequestID)[0]
+		}
+		if requestID == "" {
+			requestID = random.String(requestIDLength)
+		}
+
+		ctx = WithRequestID(ctx, requestID)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, requestID))
+
+		resp, err := handler(ctx, req)
+
+		entry := FromContext(ctx, l).WithFields(logrus.Fields{
+			"method":  info.FullMethod,
+			"code":    status.Code(err).String(),
+			"latency": time.Since(start).String(),
+		})
+		if err != nil {
+			entry.WithError(err).Error("grpc call failed")
+		} else {
+			entry.Info("grpc call")
+		}
+
+		return resp, err
+	}
+}
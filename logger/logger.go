@@ -0,0 +1,103 @@
+// Package logger implements the structured logger of location-history-mgmt microservice.
+// Every log line written through FromContext carries the correlation id of the request
+// that produced it, either taken from the X-Request-Id http header or from the
+// x-request-id grpc metadata key, or generated when the caller didn't send one.
+package logger
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/sirupsen/logrus"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// Log formats accepted by New.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Field names shared by every log line.
+const (
+	FieldRequestID = "request_id"
+	FieldTenantID  = "tenant_id"
+	FieldLatitude  = "latitude"
+	FieldLongitude = "longitude"
+	FieldUserName  = "username"
+	FieldDeviceID  = "device_id"
+)
+
+// redactedDecimals is the number of decimal positions kept on coordinates logged at info
+// level or above, roughly one kilometre of precision.
+const redactedDecimals = 2
+
+type requestIDKey struct{}
+
+// New initializes a logger writing to stdout with the given level and format. Level is
+// any logrus level name and format is FormatJSON or FormatText.
+func New(level string, format string) (*logrus.Logger, error) {
+	return NewWithWriter(os.Stdout, level, format)
+}
+
+// NewWithWriter initializes a logger writing to w with the given level and format.
+func NewWithWriter(w io.Writer, level string, format string) (*logrus.Logger, error) {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	l := logrus.New()
+	l.SetOutput(w)
+	l.SetLevel(lvl)
+
+	if strings.EqualFold(format, FormatText) {
+		l.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	} else {
+		l.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	return l, nil
+}
+
+// WithRequestID returns a copy of ctx that carries the request correlation id.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request correlation id carried by ctx or an empty string.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns a log entry of l tagged with the request correlation id and the
+// tenant carried by ctx.
+func FromContext(ctx context.Context, l *logrus.Logger) *logrus.Entry {
+	return l.WithFields(logrus.Fields{
+		FieldRequestID: RequestID(ctx),
+		FieldTenantID:  tenant.FromContext(ctx),
+	})
+}
+
+// Coordinates returns the log fields of a geographic point. Coordinates are only logged
+// with full precision when l has debug level enabled, otherwise they are rounded to
+// redactedDecimals positions.
+func Coordinates(l *logrus.Logger, latitude float64, longitude float64) logrus.Fields {
+	if !l.IsLevelEnabled(logrus.DebugLevel) {
+		p := math.Pow(10, redactedDecimals)
+		latitude = math.Round(latitude*p) / p
+		longitude = math.Round(longitude*p) / p
+	}
+
+	return logrus.Fields{
+		FieldLatitude:  latitude,
+		FieldLongitude: longitude,
+	}
+}
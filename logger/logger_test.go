@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	nameTest := "TestNew"
+
+	type test struct {
+		level  string
+		format string
+		answer bool
+	}
+
+	tests := []test{
+		{"info", FormatJSON, true},
+		{"debug", FormatText, true},
+		{"verbose", FormatJSON, false},
+	}
+
+	for _, v := range tests {
+		_, err := NewWithWriter(&bytes.Buffer{}, v.level, v.format)
+
+		if (err == nil) != v.answer {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, err)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestCoordinates(t *testing.T) {
+	nameTest := "TestCoordinates"
+
+	type test struct {
+		level  string
+		answer []float64
+	}
+
+	tests := []test{
+		{"info", []float64{40.42, -3.7}},
+		{"debug", []float64{40.416775, -3.703790}},
+	}
+
+	for _, v := range tests {
+		l, _ := NewWithWriter(&bytes.Buffer{}, v.level, FormatJSON)
+		f := Coordinates(l, 40.416775, -3.703790)
+
+		if f[FieldLatitude] != v.answer[0] || f[FieldLongitude] != v.answer[1] {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, f)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestFromContext(t *testing.T) {
+	nameTest := "TestFromContext"
+	out := &bytes.Buffer{}
+	l, _ := NewWithWriter(out, "info", FormatJSON)
+
+	FromContext(WithRequestID(context.Background(), "requestid"), l).Info("message")
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if line[FieldRequestID] != "requestid" {
+		t.Errorf("%s: Expected %v but got %v", nameTest, "requestid", line[FieldRequestID])
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestEchoRequestID(t *testing.T) {
+	nameTest := "TestEchoRequestID"
+	l, _ := NewWithWriter(&bytes.Buffer{}, "info", FormatJSON)
+
+	type test struct {
+		header string
+		answer string
+	}
+
+	tests := []test{
+		{"requestid", "requestid"},
+		{"", ""},
+	}
+
+	for _, v := range tests {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if v.header != "" {
+			req.Header.Set(echo.HeaderXRequestID, v.header)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var got string
+		h := EchoRequestID()(EchoLogger(l)(func(c echo.Context) error {
+			got = RequestID(c.Request().Context())
+			return nil
+		}))
+
+		if err := h(c); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+
+		if got == "" || (v.answer != "" && got != v.answer) || rec.Header().Get(echo.HeaderXRequestID) != got {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	nameTest := "TestUnaryServerInterceptor"
+	l, _ := NewWithWriter(&bytes.Buffer{}, "info", FormatJSON)
+	interceptor := UnaryServerInterceptor(l)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataRequestID, "requestid"))
+
+	var got string
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = RequestID(ctx)
+		return nil, nil
+	})
+
+	if err != nil || got != "requestid" {
+		t.Errorf("%s: Expected %v but got %v", nameTest, "requestid", got)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
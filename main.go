@@ -3,10 +3,25 @@
 package main
 
 import (
+	"fmt"
 	"github.com/oboadagd/location-history-mgmt/appconfig"
+	"os"
+
+	// embeds the IANA time zone database, missing in the alpine image, for distance
+	// breakdowns by time zone
+	_ "time/tzdata"
 )
 
-// main invokes method that start-up this microservice
+// main invokes method that start-up this microservice, or runs the maintenance
+// command given as argument
 func main() {
+	if len(os.Args) > 1 {
+		if err := appconfig.RunCommand(os.Args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	appconfig.StartApp()
 }
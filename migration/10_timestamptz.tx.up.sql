@@ -0,0 +1,28 @@
+DO $$
+DECLARE
+    col record;
+BEGIN
+
+    -- go-pg writes every date in UTC, so the zone-less dates are converted as UTC
+    FOR col IN
+        SELECT table_name, column_name FROM information_schema.columns
+        WHERE  table_schema = 'public'
+        AND    data_type = 'timestamp without time zone'
+        AND    (table_name, column_name) IN (
+                   ('location', 'updated_at'),
+                   ('location_history', 'updated_at'),
+                   ('location_filter_state', 'updated_at'),
+                   ('api_key', 'created_at'),
+                   ('api_key', 'revoked_at'),
+                   ('trip', 'started_at'),
+                   ('trip', 'ended_at'),
+                   ('trip', 'last_point_at'),
+                   ('stay', 'arrived_at'),
+                   ('stay', 'departed_at'))
+    LOOP
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN %I TYPE timestamptz USING %I AT TIME ZONE ''UTC''',
+                       col.table_name, col.column_name, col.column_name);
+    END LOOP;
+
+END;
+$$;
\ No newline at end of file
@@ -0,0 +1,32 @@
+DO $$
+BEGIN
+
+   IF NOT EXISTS
+   	   (SELECT * FROM pg_tables
+   		WHERE  schemaname = 'public'
+   		AND    tablename  = 'distance_rollup') THEN
+
+        CREATE TABLE "distance_rollup" (
+                            "id" SERIAL PRIMARY KEY,
+                            "tenant_id" varchar(64) NOT NULL,
+                            "username" varchar(16) NOT NULL,
+                            "device_id" varchar(64) NOT NULL DEFAULT '',
+                            "hour" timestamptz NOT NULL,
+                            "distance" float8 NOT NULL DEFAULT 0,
+                            "point_count" integer NOT NULL DEFAULT 0
+        );
+
+        CREATE UNIQUE INDEX "distance_rollup_tenant_username_device_hour"
+            ON "distance_rollup" ("tenant_id", "username", "device_id", "hour");
+
+        -- rollups of the existing history, rebuildable with the rebuild-rollups command
+        INSERT INTO "distance_rollup" ("tenant_id", "username", "device_id", "hour", "distance", "point_count")
+            SELECT "tenant_id", "username", "device_id",
+                   to_timestamp(floor(extract(epoch FROM "updated_at") / 3600) * 3600),
+                   coalesce(sum("distance"), 0), count(*)
+            FROM   "location_history"
+            GROUP  BY 1, 2, 3, 4;
+    END IF;
+
+END;
+$$;
\ No newline at end of file
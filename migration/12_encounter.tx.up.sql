@@ -0,0 +1,32 @@
+DO $$
+BEGIN
+
+   IF NOT EXISTS
+   	   (SELECT * FROM pg_tables
+   		WHERE  schemaname = 'public'
+   		AND    tablename  = 'encounter') THEN
+
+        CREATE TABLE "encounter" (
+                            "id" SERIAL PRIMARY KEY,
+                            "tenant_id" varchar(64) NOT NULL,
+                            "username" varchar(16) NOT NULL,
+                            "other_username" varchar(16) NOT NULL,
+                            "status" varchar(16) NOT NULL,
+                            "started_at" timestamptz NOT NULL,
+                            "ended_at" timestamptz NOT NULL,
+                            "min_distance" float8 NOT NULL DEFAULT 0,
+                            "duration" float8 NOT NULL DEFAULT 0
+        );
+
+        CREATE INDEX "encounter_tenant_username_started_at"
+            ON "encounter" ("tenant_id", "username", "started_at");
+
+        CREATE INDEX "encounter_tenant_other_username_started_at"
+            ON "encounter" ("tenant_id", "other_username", "started_at");
+
+        CREATE UNIQUE INDEX "encounter_tenant_pair_current"
+            ON "encounter" ("tenant_id", "username", "other_username") WHERE "status" <> 'closed';
+    END IF;
+
+END;
+$$;
\ No newline at end of file
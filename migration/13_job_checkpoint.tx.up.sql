@@ -0,0 +1,19 @@
+DO $$
+BEGIN
+
+   IF NOT EXISTS
+   	   (SELECT * FROM pg_tables
+   		WHERE  schemaname = 'public'
+   		AND    tablename  = 'job_checkpoint') THEN
+
+        CREATE TABLE "job_checkpoint" (
+                            "tenant_id" varchar(64) NOT NULL,
+                            "job" varchar(128) NOT NULL,
+                            "username" varchar(16) NOT NULL,
+                            "updated_at" timestamptz NOT NULL,
+                            PRIMARY KEY ("tenant_id", "job")
+        );
+    END IF;
+
+END;
+$$;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "distance_algorithm" varchar(16);
\ No newline at end of file
@@ -0,0 +1,19 @@
+DO $$
+BEGIN
+
+   IF NOT EXISTS
+   	   (SELECT * FROM pg_tables
+   		WHERE  schemaname = 'public'
+   		AND    tablename  = 'history_horizon') THEN
+
+        CREATE TABLE "history_horizon" (
+                            "tenant_id" varchar(64) NOT NULL,
+                            "username" varchar(16) NOT NULL,
+                            "pruned_before" timestamptz NOT NULL,
+                            "updated_at" timestamptz NOT NULL,
+                            PRIMARY KEY ("tenant_id", "username")
+        );
+    END IF;
+
+END;
+$$;
\ No newline at end of file
@@ -0,0 +1,60 @@
+CREATE OR REPLACE FUNCTION location_history_create_partition(partition_month timestamptz) RETURNS text AS $partition$
+DECLARE
+    start_at timestamp := date_trunc('month', partition_month AT TIME ZONE 'UTC');
+    part_name text := 'location_history_' || to_char(start_at, 'YYYYMM');
+BEGIN
+
+    -- monthly partitions are bounded by UTC months and named after them
+    EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF "location_history" FOR VALUES FROM (%L) TO (%L)',
+        part_name, start_at AT TIME ZONE 'UTC', (start_at + interval '1 month') AT TIME ZONE 'UTC');
+
+    RETURN part_name;
+END;
+$partition$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    partition_month timestamp;
+BEGIN
+
+   IF (SELECT relkind FROM pg_class WHERE oid = to_regclass('location_history')) = 'r' THEN
+
+        ALTER TABLE "location_history" RENAME TO "location_history_heap";
+        ALTER INDEX "location_history_pkey" RENAME TO "location_history_heap_pkey";
+        ALTER INDEX IF EXISTS "location_history_tenant_username_device_updated_at"
+            RENAME TO "location_history_heap_tenant_username_device_updated_at";
+        ALTER INDEX IF EXISTS "location_history_tenant_username_excluded_reason"
+            RENAME TO "location_history_heap_tenant_username_excluded_reason";
+        ALTER SEQUENCE "location_history_id_seq" OWNED BY NONE;
+
+        -- the partition key must be part of the primary key
+        CREATE TABLE "location_history" (LIKE "location_history_heap" INCLUDING DEFAULTS)
+            PARTITION BY RANGE ("updated_at");
+        ALTER TABLE "location_history" ADD PRIMARY KEY ("id", "updated_at");
+        ALTER SEQUENCE "location_history_id_seq" OWNED BY "location_history"."id";
+
+        CREATE INDEX "location_history_username_updated_at"
+            ON "location_history" ("username", "updated_at");
+        CREATE INDEX "location_history_tenant_username_device_updated_at"
+            ON "location_history" ("tenant_id", "username", "device_id", "updated_at");
+        CREATE INDEX "location_history_tenant_username_excluded_reason"
+            ON "location_history" ("tenant_id", "username", "excluded_reason")
+            WHERE "excluded_reason" IS NOT NULL;
+
+        -- partitions of the existing history and of the next three months
+        FOR partition_month IN
+            SELECT generate_series(
+                       date_trunc('month', coalesce(min("updated_at"), now()) AT TIME ZONE 'UTC'),
+                       (greatest(max("updated_at"), now()) AT TIME ZONE 'UTC') + interval '3 months',
+                       interval '1 month')
+            FROM "location_history_heap"
+        LOOP
+            PERFORM location_history_create_partition(partition_month AT TIME ZONE 'UTC');
+        END LOOP;
+
+        INSERT INTO "location_history" SELECT * FROM "location_history_heap";
+        DROP TABLE "location_history_heap";
+    END IF;
+
+END;
+$$;
\ No newline at end of file
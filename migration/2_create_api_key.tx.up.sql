@@ -0,0 +1,20 @@
+DO $$
+BEGIN
+
+   IF NOT EXISTS
+   	   (SELECT * FROM pg_tables
+   		WHERE  schemaname = 'public'
+   		AND    tablename  = 'api_key') THEN
+
+        CREATE TABLE "api_key" (
+                            "id" SERIAL PRIMARY KEY,
+                            "client_id" varchar(64) UNIQUE NOT NULL,
+                            "key_hash" char(64) UNIQUE NOT NULL,
+                            "scopes" text[] NOT NULL DEFAULT '{}',
+                            "created_at" timestamp NOT NULL,
+                            "revoked_at" timestamp
+        );
+    END IF;
+
+END;
+$$;
\ No newline at end of file
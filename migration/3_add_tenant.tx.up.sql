@@ -0,0 +1,6 @@
+ALTER TABLE "location" ADD COLUMN IF NOT EXISTS "tenant_id" varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "tenant_id" varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE "api_key" ADD COLUMN IF NOT EXISTS "tenant_id" varchar(64);
+
+ALTER TABLE "location" DROP CONSTRAINT IF EXISTS "location_username_key";
+CREATE UNIQUE INDEX IF NOT EXISTS "location_tenant_username" ON "location" ("tenant_id", "username");
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE "location" ADD COLUMN IF NOT EXISTS "device_id" varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE "location" ADD COLUMN IF NOT EXISTS "primary_device_id" varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "device_id" varchar(64) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS "location_history_tenant_username_device_updated_at"
+    ON "location_history" ("tenant_id", "username", "device_id", "updated_at");
\ No newline at end of file
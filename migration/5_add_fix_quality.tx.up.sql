@@ -0,0 +1,9 @@
+ALTER TABLE "location" ADD COLUMN IF NOT EXISTS "horizontal_accuracy" float8;
+
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "horizontal_accuracy" float8;
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "altitude" float8;
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "vertical_accuracy" float8;
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "speed" float8;
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "heading" float8;
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "source" varchar(16);
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "excluded_reason" varchar(32);
\ No newline at end of file
@@ -0,0 +1,28 @@
+DO $$
+BEGIN
+
+   IF NOT EXISTS
+   	   (SELECT * FROM pg_tables
+   		WHERE  schemaname = 'public'
+   		AND    tablename  = 'location_filter_state') THEN
+
+        CREATE TABLE "location_filter_state" (
+                            "id" SERIAL PRIMARY KEY,
+                            "tenant_id" varchar(64) NOT NULL,
+                            "username" varchar(16) NOT NULL,
+                            "device_id" varchar(64) NOT NULL DEFAULT '',
+                            "latitude" float8 NOT NULL,
+                            "longitude" float8 NOT NULL,
+                            "variance" float8 NOT NULL,
+                            "updated_at" timestamp NOT NULL
+        );
+
+        CREATE UNIQUE INDEX "location_filter_state_tenant_username_device"
+            ON "location_filter_state" ("tenant_id", "username", "device_id");
+    END IF;
+
+END;
+$$;
+
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "filtered_latitude" float8;
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "filtered_longitude" float8;
\ No newline at end of file
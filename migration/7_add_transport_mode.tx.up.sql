@@ -0,0 +1,5 @@
+ALTER TABLE "location_history" ADD COLUMN IF NOT EXISTS "transport_mode" varchar(16);
+
+CREATE INDEX IF NOT EXISTS "location_history_tenant_username_excluded_reason"
+    ON "location_history" ("tenant_id", "username", "excluded_reason")
+    WHERE "excluded_reason" IS NOT NULL;
\ No newline at end of file
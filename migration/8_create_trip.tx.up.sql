@@ -0,0 +1,36 @@
+DO $$
+BEGIN
+
+   IF NOT EXISTS
+   	   (SELECT * FROM pg_tables
+   		WHERE  schemaname = 'public'
+   		AND    tablename  = 'trip') THEN
+
+        CREATE TABLE "trip" (
+                            "id" SERIAL PRIMARY KEY,
+                            "tenant_id" varchar(64) NOT NULL,
+                            "username" varchar(16) NOT NULL,
+                            "device_id" varchar(64) NOT NULL DEFAULT '',
+                            "status" varchar(16) NOT NULL,
+                            "started_at" timestamp NOT NULL,
+                            "ended_at" timestamp NOT NULL,
+                            "start_latitude" float8 NOT NULL,
+                            "start_longitude" float8 NOT NULL,
+                            "end_latitude" float8 NOT NULL,
+                            "end_longitude" float8 NOT NULL,
+                            "distance" float8 NOT NULL DEFAULT 0,
+                            "duration" float8 NOT NULL DEFAULT 0,
+                            "max_speed" float8 NOT NULL DEFAULT 0,
+                            "point_count" integer NOT NULL DEFAULT 0,
+                            "last_point_at" timestamp NOT NULL
+        );
+
+        CREATE INDEX "trip_tenant_username_device_started_at"
+            ON "trip" ("tenant_id", "username", "device_id", "started_at");
+
+        CREATE UNIQUE INDEX "trip_tenant_username_device_open"
+            ON "trip" ("tenant_id", "username", "device_id") WHERE "status" = 'open';
+    END IF;
+
+END;
+$$;
\ No newline at end of file
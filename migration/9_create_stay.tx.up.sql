@@ -0,0 +1,31 @@
+DO $$
+BEGIN
+
+   IF NOT EXISTS
+   	   (SELECT * FROM pg_tables
+   		WHERE  schemaname = 'public'
+   		AND    tablename  = 'stay') THEN
+
+        CREATE TABLE "stay" (
+                            "id" SERIAL PRIMARY KEY,
+                            "tenant_id" varchar(64) NOT NULL,
+                            "username" varchar(16) NOT NULL,
+                            "device_id" varchar(64) NOT NULL DEFAULT '',
+                            "status" varchar(16) NOT NULL,
+                            "latitude" float8 NOT NULL,
+                            "longitude" float8 NOT NULL,
+                            "arrived_at" timestamp NOT NULL,
+                            "departed_at" timestamp NOT NULL,
+                            "duration" float8 NOT NULL DEFAULT 0,
+                            "point_count" integer NOT NULL DEFAULT 0
+        );
+
+        CREATE INDEX "stay_tenant_username_device_arrived_at"
+            ON "stay" ("tenant_id", "username", "device_id", "arrived_at");
+
+        CREATE UNIQUE INDEX "stay_tenant_username_device_current"
+            ON "stay" ("tenant_id", "username", "device_id") WHERE "status" <> 'closed';
+    END IF;
+
+END;
+$$;
\ No newline at end of file
@@ -6,11 +6,11 @@ import (
 
 	"github.com/go-pg/migrations/v8"
 	"github.com/go-pg/pg/v10"
-	"github.com/labstack/gommon/log"
+	"github.com/sirupsen/logrus"
 )
 
 // Init creates relational database if it is not existing
-func Init(db *pg.DB) {
+func Init(db *pg.DB, log *logrus.Logger) {
 	// create a new collection with gopg_migrations table
 	c := migrations.NewCollection()
 	c.DisableSQLAutodiscover(true)
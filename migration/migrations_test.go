@@ -0,0 +1,117 @@
+package migration
+
+import (
+	"fmt"
+	"github.com/go-pg/pg/v10"
+	"github.com/oboadagd/location-history-mgmt/testutils"
+	"os"
+	"testing"
+	"time"
+)
+
+// partitionSchema is the schema where the partitioning migration is tested, so it
+// doesn't touch the tables of the test database.
+const partitionSchema = "migration_partition_test"
+
+func TestPartitionLocationHistory(t *testing.T) {
+	nameTest := "TestPartitionLocationHistory"
+	db := testutils.GetTestDB()
+	defer db.Close()
+
+	// the search path is set on a single connection
+	conn := db.Conn()
+	defer conn.Close()
+
+	migration, err := os.ReadFile("16_partition_location_history.tx.up.sql")
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	defer conn.Exec(fmt.Sprintf(`DROP SCHEMA IF EXISTS "%s" CASCADE`, partitionSchema))
+
+	// the location history as the previous migrations left it, with existing data
+	now := time.Now().UTC()
+	for _, q := range []string{
+		fmt.Sprintf(`DROP SCHEMA IF EXISTS "%s" CASCADE`, partitionSchema),
+		fmt.Sprintf(`CREATE SCHEMA "%s"`, partitionSchema),
+		fmt.Sprintf(`SET search_path TO "%s"`, partitionSchema),
+		`CREATE TABLE "location_history" (
+			"id" SERIAL PRIMARY KEY,
+			"tenant_id" varchar(64) NOT NULL DEFAULT 'default',
+			"username" varchar(16) NOT NULL,
+			"device_id" varchar(64) NOT NULL DEFAULT '',
+			"latitude" float8 NOT NULL,
+			"longitude" float8 NOT NULL,
+			"updated_at" timestamptz NOT NULL,
+			"distance" float8,
+			"excluded_reason" varchar(16)
+		)`,
+		`CREATE INDEX "location_history_tenant_username_device_updated_at"
+			ON "location_history" ("tenant_id", "username", "device_id", "updated_at")`,
+		`CREATE INDEX "location_history_tenant_username_excluded_reason"
+			ON "location_history" ("tenant_id", "username", "excluded_reason") WHERE "excluded_reason" IS NOT NULL`,
+		`INSERT INTO "location_history" ("username", "latitude", "longitude", "updated_at", "distance") VALUES
+			('usernamesample', 10, 10, '2024-01-31 23:59:59+00', 1.5),
+			('usernamesample', 10, 10, '2024-02-01 00:00:00+00', 2.5),
+			('usernamesample', 10, 10, now(), 3)`,
+	} {
+		if _, err := conn.Exec(q); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	// migrating twice leaves the partitioned table
+	for i := 0; i < 2; i++ {
+		if _, err := conn.Exec(string(migration)); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	var relKind string
+	var records int
+	var distance float64
+	_, err = conn.QueryOne(pg.Scan(&relKind, &records, &distance), `
+		SELECT (SELECT relkind FROM pg_class WHERE oid = to_regclass('location_history')), count(*), sum(distance)
+		FROM location_history`)
+	if err != nil || relKind != "p" || records != 3 || distance != 7 {
+		t.Errorf("%s: Expected %v %v %v but got %v %v %v %v", nameTest, "p", 3, 7, relKind, records, distance, err)
+		return
+	}
+
+	type test struct {
+		partition string
+		records   int
+	}
+
+	// records are routed to the partition of their UTC month, and the coming months
+	// have empty partitions
+	tests := []test{
+		{"location_history_202401", 1},
+		{"location_history_202402", 1},
+		{"location_history_" + now.Format("200601"), 1},
+		{"location_history_" + now.AddDate(0, 3, 0).Format("200601"), 0},
+	}
+
+	for _, v := range tests {
+		var n int
+		if _, err := conn.QueryOne(pg.Scan(&n), "SELECT count(*) FROM ?", pg.Ident(v.partition)); err != nil || n != v.records {
+			t.Errorf("%s %s: Expected %v but got %v %v", nameTest, v.partition, v.records, n, err)
+			return
+		}
+	}
+
+	// new records keep the identifier sequence
+	var id int64
+	_, err = conn.QueryOne(pg.Scan(&id), `
+		INSERT INTO location_history (username, latitude, longitude, updated_at) VALUES ('usernamesample', 10, 10, now())
+		RETURNING id`)
+	if err != nil || id != 4 {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, 4, id, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
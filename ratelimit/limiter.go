@@ -0,0 +1,97 @@
+// Package ratelimit implements token bucket rate limiting of location-history-mgmt
+// microservice requests. Requests are limited by username, so a misbehaving device
+// can't flood the ingestion of its own locations, and by authenticated client, so a
+// single caller can't exhaust the database on behalf of many usernames.
+package ratelimit
+
+import (
+	"golang.org/x/time/rate"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a set of token buckets, one by key, that share the same rate and burst.
+// Buckets of keys not seen during idleTimeout are discarded.
+type Limiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket // token bucket by key
+	limit       rate.Limit         // tokens added per second
+	burst       int                // bucket size
+	idleTimeout time.Duration      // time after which an unused bucket is discarded
+	lastSweep   time.Time          // last time idle buckets were discarded
+}
+
+// bucket is the token bucket of a key.
+type bucket struct {
+	limiter  *rate.Limiter // token bucket
+	lastSeen time.Time     // last time a token was requested
+}
+
+// NewLimiter initializes a Limiter that allows perSecond requests per second by key
+// with bursts of up to burst requests.
+func NewLimiter(perSecond float64, burst int) *Limiter {
+	idle := time.Minute
+	if perSecond > 0 {
+		// a bucket idle for the time it takes to refill is equivalent to a new one
+		if refill := time.Duration(float64(burst) / perSecond * float64(time.Second)); refill > idle {
+			idle = refill
+		}
+	}
+
+	return &Limiter{
+		buckets:     map[string]*bucket{},
+		limit:       rate.Limit(perSecond),
+		burst:       burst,
+		idleTimeout: idle,
+		lastSweep:   time.Now(),
+	}
+}
+
+// Allow takes a token from the bucket of key. Returns false and the time after which a
+// token will be available when the bucket is empty.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	r, wait := l.reserve(key, time.Now())
+	return r != nil, wait
+}
+
+// reserve takes a token from the bucket of key at now. Returns the reservation of the
+// token, which gives it back when canceled, or nil and the time after which a token will
+// be available when the bucket is empty.
+func (l *Limiter) reserve(key string, now time.Time) (*rate.Reservation, time.Duration) {
+	l.mu.Lock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	l.sweep(now)
+	l.mu.Unlock()
+
+	r := b.limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return nil, time.Duration(math.MaxInt64)
+	}
+
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return nil, delay
+	}
+
+	return r, 0
+}
+
+// sweep discards idle buckets. It must be called with the lock held.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.idleTimeout {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= l.idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
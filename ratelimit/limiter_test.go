@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	nameTest := "TestLimiterAllow"
+	l := NewLimiter(1, 2)
+
+	type test struct {
+		key    string
+		answer bool
+	}
+
+	tests := []test{
+		{"usernamesample", true},
+		{"usernamesample", true},
+		{"usernamesample", false},
+		{"otherusername", true},
+	}
+
+	for i, v := range tests {
+		ok, wait := l.Allow(v.key)
+
+		if ok != v.answer {
+			t.Errorf("%s: test %d expected %v but got %v", nameTest, i, v.answer, ok)
+			return
+		}
+
+		if !ok && (wait <= 0 || wait > time.Second) {
+			t.Errorf("%s: test %d unexpected wait %v", nameTest, i, wait)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestLimiterSweep(t *testing.T) {
+	nameTest := "TestLimiterSweep"
+	l := NewLimiter(1, 1)
+	l.Allow("usernamesample")
+
+	l.sweep(time.Now().Add(2 * l.idleTimeout))
+
+	if len(l.buckets) != 0 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 0, len(l.buckets))
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
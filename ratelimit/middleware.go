@@ -0,0 +1,175 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/auth"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Key types of the limits, used as metric label.
+const (
+	KeyUserName = "username"
+	KeyClient   = "client"
+)
+
+// Transports of the requests, used as metric label.
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// MetadataRetryAfter is the grpc metadata key that carries the seconds to wait before
+// retrying a throttled call, like the http Retry-After header.
+const MetadataRetryAfter = "retry-after"
+
+// throttledRequests counts the requests rejected by the limits.
+var throttledRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "location_history_throttled_requests_total",
+	Help: "Number of requests rejected by rate limiting.",
+}, []string{"transport", "key"})
+
+// Limits groups the limiter of each key type. A nil limiter disables its key type.
+type Limits struct {
+	UserName *Limiter // limiter by username of the request
+	Client   *Limiter // limiter by authenticated client, or by remote address when unauthenticated
+}
+
+// userNameRequest is implemented by grpc requests that carry a username.
+type userNameRequest interface {
+	GetUserName() string
+}
+
+// allow takes a token of each limit, only if every limit has one, so a request
+// throttled by one limit doesn't consume the others. Returns the key type that throttled
+// the request and the time to wait before retrying, or an empty key type if the request
+// is allowed.
+func (l Limits) allow(userName string, client string) (string, time.Duration) {
+	now := time.Now()
+
+	var clientToken *rate.Reservation
+	if l.Client != nil && client != "" {
+		r, wait := l.Client.reserve(client, now)
+		if r == nil {
+			return KeyClient, wait
+		}
+		clientToken = r
+	}
+
+	if l.UserName != nil && userName != "" {
+		if r, wait := l.UserName.reserve(userName, now); r == nil {
+			if clientToken != nil {
+				clientToken.CancelAt(now)
+			}
+			return KeyUserName, wait
+		}
+	}
+
+	return "", 0
+}
+
+// EchoMiddleware returns the echo middleware that applies limits to every request. The
+// username is taken from the userName path parameter. Unauthenticated requests are
+// limited by echo.Context.RealIP, so the echo instance must set an IPExtractor that
+// only trusts forwarding headers sent by its proxies. It must be registered after the
+// error handler, the authentication and the tenant middlewares.
+func EchoMiddleware(limits Limits) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			client := c.RealIP()
+			if p, ok := auth.PrincipalFromContext(c.Request().Context()); ok {
+				client = p.Subject
+			}
+
+			key, wait := limits.allow(userNameKey(c.Request().Context(), c.Param("userName")), client)
+			if key == "" {
+				return next(c)
+			}
+
+			throttledRequests.WithLabelValues(TransportHTTP, key).Inc()
+			c.Response().Header().Set(echo.HeaderRetryAfter, retryAfterSeconds(wait))
+
+			return respKit.NewGenericHttpError(http.StatusTooManyRequests, enums.ErrorRateLimitedCode,
+				fmt.Errorf(enums.ErrorRateLimitedMsg, key, keyValue(key, c.Param("userName"), client), wait.Round(time.Millisecond)))
+		}
+	}
+}
+
+// UnaryServerInterceptor returns the grpc interceptor equivalent to EchoMiddleware. The
+// username is taken from requests that carry one. Throttled calls fail with
+// ResourceExhausted status, a RetryInfo detail and the retry-after response header.
+// It must be chained after the authentication and the tenant interceptors.
+func UnaryServerInterceptor(limits Limits) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var client, userName string
+		if p, ok := auth.PrincipalFromContext(ctx); ok {
+			client = p.Subject
+		} else if pr, ok := peer.FromContext(ctx); ok && pr.Addr != nil {
+			client = pr.Addr.String()
+			if host, _, err := net.SplitHostPort(client); err == nil {
+				client = host
+			}
+		}
+		if r, ok := req.(userNameRequest); ok {
+			userName = userNameKey(ctx, r.GetUserName())
+		}
+
+		key, wait := limits.allow(userName, client)
+		if key == "" {
+			return handler(ctx, req)
+		}
+
+		throttledRequests.WithLabelValues(TransportGRPC, key).Inc()
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataRetryAfter, retryAfterSeconds(wait)))
+
+		st := status.Newf(codes.ResourceExhausted, "%s: "+enums.ErrorRateLimitedMsg, enums.ErrorRateLimitedCode,
+			key, keyValue(key, userName, client), wait.Round(time.Millisecond))
+		if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+			st = detailed
+		}
+
+		return nil, st.Err()
+	}
+}
+
+// userNameKey returns the limiter key of userName, qualified by the tenant carried by
+// ctx because usernames are only unique within a tenant.
+func userNameKey(ctx context.Context, userName string) string {
+	if userName == "" {
+		return ""
+	}
+
+	return tenant.FromContext(ctx) + "/" + userName
+}
+
+// keyValue returns the value of the key type that throttled a request.
+func keyValue(key string, userName string, client string) string {
+	if key == KeyUserName {
+		return userName
+	}
+
+	return client
+}
+
+// retryAfterSeconds formats wait as whole seconds, rounded up.
+func retryAfterSeconds(wait time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(wait.Seconds())), 10)
+}
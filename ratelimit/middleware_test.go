@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	pb "github.com/oboadagd/location-history-mgmt/userlocation/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEchoMiddleware(t *testing.T) {
+	nameTest := "TestEchoMiddleware"
+	h := EchoMiddleware(Limits{UserName: NewLimiter(1, 1)})(func(c echo.Context) error {
+		return nil
+	})
+
+	type test struct {
+		userName string
+		answer   int
+	}
+
+	tests := []test{
+		{"usernamesample", 0},
+		{"usernamesample", http.StatusTooManyRequests},
+		{"otherusername", 0},
+	}
+
+	for i, v := range tests {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+		c.SetParamNames("userName")
+		c.SetParamValues(v.userName)
+
+		err := h(c)
+
+		status := 0
+		if httpErr, ok := err.(*respKit.GenericHttpError); ok {
+			status = httpErr.Status
+		}
+
+		if status != v.answer {
+			t.Errorf("%s: test %d expected %v but got %v", nameTest, i, v.answer, err)
+			return
+		}
+
+		if status != 0 && rec.Header().Get(echo.HeaderRetryAfter) != "1" {
+			t.Errorf("%s: test %d expected Retry-After %v but got %v", nameTest, i, 1, rec.Header().Get(echo.HeaderRetryAfter))
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	nameTest := "TestUnaryServerInterceptor"
+	interceptor := UnaryServerInterceptor(Limits{UserName: NewLimiter(1, 1)})
+	info := &grpc.UnaryServerInfo{FullMethod: "/userlocation.UserLocationService/SaveLocation"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	req := &pb.SaveLocationRequest{UserName: "usernamesample"}
+
+	if _, err := interceptor(context.Background(), req, info, handler); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	_, err := interceptor(context.Background(), req, info, handler)
+	st := status.Convert(err)
+
+	if st.Code() != codes.ResourceExhausted || len(st.Details()) != 1 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, codes.ResourceExhausted, err)
+		return
+	}
+
+	if ri, ok := st.Details()[0].(*errdetails.RetryInfo); !ok || ri.RetryDelay.AsDuration() <= 0 {
+		t.Errorf("%s: Expected retry info but got %v", nameTest, st.Details()[0])
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestLimitsAllow(t *testing.T) {
+	nameTest := "TestLimitsAllow"
+	limits := Limits{UserName: NewLimiter(1, 1), Client: NewLimiter(1, 2)}
+
+	type test struct {
+		userName string
+		answer   string
+	}
+
+	// the second request is throttled by username and keeps the client token for the third
+	tests := []test{
+		{"usernamesample", ""},
+		{"usernamesample", KeyUserName},
+		{"otherusername", ""},
+		{"thirdusername", KeyClient},
+	}
+
+	for i, v := range tests {
+		if key, _ := limits.allow(v.userName, "client"); key != v.answer {
+			t.Errorf("%s: test %d expected %v but got %v", nameTest, i, v.answer, key)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,74 @@
+// Package repository implements facade to relational database.
+// Through implementation of the ApiKeyRepositoryInterface methods,
+// it is possible to define the necessary updates and fetches to
+// manage ApiKey entity model.
+package repository
+
+import (
+	"context"
+	"github.com/go-pg/pg/v10"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/sirupsen/logrus"
+)
+
+// ApiKeyRepositoryInterface is the interface of ApiKey repository layer.
+// Contains definition of methods to manage the database representation of
+// ApiKey entity.
+type ApiKeyRepositoryInterface interface {
+	Create(ctx context.Context, apiKey dto.ApiKey) error
+	GetByHash(ctx context.Context, keyHash string) (*dto.ApiKey, error)
+}
+
+// ApiKeyRepository represents the relational database repository layer of
+// ApiKey entity. It's the registry of credentials of services that call this
+// microservice.
+type ApiKeyRepository struct {
+	db  *pg.DB         // available database
+	log *logrus.Logger // structured logger
+}
+
+// NewApiKeyRepository initializes repository of ApiKey entity.
+func NewApiKeyRepository(db *pg.DB, log *logrus.Logger) ApiKeyRepositoryInterface {
+	return &ApiKeyRepository{
+		db,
+		log,
+	}
+}
+
+// Create implements insert action of ApiKey entity.
+func (r *ApiKeyRepository) Create(ctx context.Context, apiKey dto.ApiKey) error {
+	ctx, cancel := queryContext(ctx, OpCreateApiKey)
+	defer cancel()
+
+	if _, err := r.db.ModelContext(ctx, &apiKey).Insert(); err != nil {
+		return queryError(ctx, r.log, OpCreateApiKey, enums.ErrorInsertApiKeyCode, err)
+	}
+
+	return nil
+}
+
+// GetByHash implements query select action of a not revoked ApiKey entity by the
+// hash of the key. Returns api key not found if the hash doesn't exist or the key
+// was revoked.
+func (r *ApiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*dto.ApiKey, error) {
+	ctx, cancel := queryContext(ctx, OpGetApiKeyByHash)
+	defer cancel()
+
+	var k dto.ApiKey
+	err := r.db.ModelContext(ctx, &k).
+		Where("key_hash = ?", keyHash).
+		Where("revoked_at IS NULL").
+		Select()
+
+	if err == pg.ErrNoRows {
+		return nil, respKit.GenericNotFoundError(enums.ErrorGetApiKeyCode, enums.ErrorApiKeyNotFoundMsg)
+	}
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetApiKeyByHash, enums.ErrorGetApiKeyCode, err)
+	}
+
+	return &k, nil
+}
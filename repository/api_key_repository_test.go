@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/testutils"
+	"testing"
+	"time"
+)
+
+func TestApiKeyGetByHash(t *testing.T) {
+	nameTest := "TestApiKeyGetByHash"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	apiKeyRepository := NewApiKeyRepository(db, testutils.GetLogger())
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	revokedAt := time.Now()
+	keys := []dto.ApiKey{
+		{ClientId: "service1", KeyHash: "hash1", Scopes: []string{"service"}, CreatedAt: time.Now()},
+		{ClientId: "service2", KeyHash: "hash2", CreatedAt: time.Now(), RevokedAt: &revokedAt},
+	}
+
+	for _, k := range keys {
+		if err = apiKeyRepository.Create(ctx, k); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	type test struct {
+		hash   string
+		answer string
+	}
+
+	tests := []test{
+		{"hash1", "service1"},
+		{"hash2", ""},
+		{"hash3", ""},
+	}
+
+	for _, v := range tests {
+		k, err := apiKeyRepository.GetByHash(ctx, v.hash)
+
+		if v.answer == "" && (err == nil || err.Error() != enums.ErrorApiKeyNotFoundMsg) {
+			t.Errorf("%s: Expected %v but got %v", nameTest, enums.ErrorApiKeyNotFoundMsg, err)
+			return
+		}
+
+		if v.answer != "" && (err != nil || k.ClientId != v.answer || len(k.Scopes) != 1) {
+			t.Errorf("%s: Expected %v but got %v %v", nameTest, v.answer, k, err)
+			return
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
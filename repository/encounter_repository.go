@@ -0,0 +1,121 @@
+// Package repository implements facade to relational database.
+// Through implementation of the EncounterRepositoryInterface methods,
+// it is possible to define the necessary updates and fetches
+// to manage Encounter entity model.
+package repository
+
+import (
+	"context"
+	"github.com/go-pg/pg/v10"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/sirupsen/logrus"
+)
+
+// EncounterRepositoryInterface is the interface of Encounter repository layer. Contains
+// definition of methods to manage the database representation of Encounter entity.
+type EncounterRepositoryInterface interface {
+	GetCurrentByUserName(ctx context.Context, userName string) ([]dto.Encounter, error)
+	ListByUserNameAndDateRange(ctx context.Context, request dto.ListEncountersRequest) (*dto.ListEncountersResponse, error)
+	Save(ctx context.Context, encounter *dto.Encounter) error
+}
+
+// EncounterRepository represents the relational database repository layer of Encounter
+// entity. Exists at most one candidate or open record for each pair of usernames of a
+// tenant. Every query is scoped by the tenant carried by the context.
+type EncounterRepository struct {
+	db  *pg.DB         // available database
+	log *logrus.Logger // structured logger
+}
+
+// NewEncounterRepository initializes repository of Encounter entity.
+func NewEncounterRepository(db *pg.DB, log *logrus.Logger) EncounterRepositoryInterface {
+	return &EncounterRepository{
+		db,
+		log,
+	}
+}
+
+// GetCurrentByUserName implements query select action of the candidate and open
+// Encounter entities of a username with any other username.
+func (r *EncounterRepository) GetCurrentByUserName(ctx context.Context, userName string) ([]dto.Encounter, error) {
+	ctx, cancel := queryContext(ctx, OpGetCurrentEncounters)
+	defer cancel()
+
+	var encounters []dto.Encounter
+	err := r.db.ModelContext(ctx, &encounters).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ? OR other_username = ?", userName, userName).
+		Where("status <> ?", enums.EncounterClosed).
+		Select()
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetCurrentEncounters, enums.ErrorGetEncounterCode, err)
+	}
+
+	return encounters, nil
+}
+
+// ListByUserNameAndDateRange implements query select action of Encounter entity by
+// username, on either side of the pair, and date range. Returns the requested page of
+// open and closed encounters that overlap the range ordered by start date. Returns an
+// empty page in case username has no encounters in the range.
+func (r *EncounterRepository) ListByUserNameAndDateRange(ctx context.Context, request dto.ListEncountersRequest) (*dto.ListEncountersResponse, error) {
+	ctx, cancel := queryContext(ctx, OpListEncounters)
+	defer cancel()
+
+	var encounters []dto.Encounter
+	count, err := r.db.ModelContext(ctx, &encounters).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ? OR other_username = ?", request.UserName, request.UserName).
+		Where("status <> ?", enums.EncounterCandidate).
+		Where("started_at <= ?", request.FinalDate).
+		Where("ended_at >= ?", request.InitialDate).
+		Order("started_at", "id").
+		Offset(int((request.Page - 1) * request.ItemsLimit)).
+		Limit(int(request.ItemsLimit)).
+		SelectAndCount()
+
+	if err != nil {
+		return &dto.ListEncountersResponse{}, queryError(ctx, r.log, OpListEncounters, enums.ErrorListEncountersCode, err)
+	}
+
+	totalItems := uint64(count)
+	totalPages := totalItems / request.ItemsLimit
+	if totalItems%request.ItemsLimit != 0 {
+		totalPages++
+	}
+
+	return &dto.ListEncountersResponse{
+		Encounters: encounters,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Save implements insert action of Encounter entity when encounter has no identifier,
+// and update action by identifier otherwise. The identifier of inserted encounters is
+// set in encounter.
+func (r *EncounterRepository) Save(ctx context.Context, encounter *dto.Encounter) error {
+	ctx, cancel := queryContext(ctx, OpSaveEncounter)
+	defer cancel()
+
+	encounter.TenantId = tenant.FromContext(ctx)
+
+	var err error
+	if encounter.Id == 0 {
+		_, err = r.db.ModelContext(ctx, encounter).Insert()
+	} else {
+		_, err = r.db.ModelContext(ctx, encounter).
+			WherePK().
+			Where("tenant_id = ?", encounter.TenantId).
+			Update()
+	}
+
+	if err != nil {
+		return queryError(ctx, r.log, OpSaveEncounter, enums.ErrorSaveEncounterCode, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/testutils"
+	"testing"
+	"time"
+)
+
+func TestEncounterRepository(t *testing.T) {
+	nameTest := "TestEncounterRepository"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	encounterRepository := NewEncounterRepository(db, testutils.GetLogger())
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	start := time.Now().Add(-3 * time.Hour)
+	encounters := []*dto.Encounter{
+		{UserName: "othersample", OtherUserName: "usernamesample", Status: enums.EncounterClosed, StartedAt: start, EndedAt: start.Add(time.Hour)},
+		{UserName: "usernamesample", OtherUserName: "zetasample", Status: enums.EncounterCandidate, StartedAt: start.Add(2 * time.Hour), EndedAt: start.Add(2 * time.Hour)},
+		{UserName: "othersample", OtherUserName: "zetasample", Status: enums.EncounterOpen, StartedAt: start.Add(time.Hour), EndedAt: start.Add(2 * time.Hour)},
+	}
+
+	for _, e := range encounters {
+		if err = encounterRepository.Save(ctx, e); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	current, err := encounterRepository.GetCurrentByUserName(ctx, "usernamesample")
+	if err != nil || len(current) != 1 || current[0].Id != encounters[1].Id {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, encounters[1].Id, current, err)
+		return
+	}
+
+	// the candidate becomes an open encounter in place
+	current[0].Status = enums.EncounterOpen
+	if err = encounterRepository.Save(ctx, &current[0]); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	type test struct {
+		userName    string
+		initialDate time.Time
+		answer      uint64
+	}
+
+	tests := []test{
+		{"usernamesample", start.Add(-time.Hour), 2},
+		{"zetasample", start.Add(-time.Hour), 2},
+		{"usernamesample", start.Add(90 * time.Minute), 1},
+		{"nobodysample", start.Add(-time.Hour), 0},
+	}
+
+	for _, v := range tests {
+		req := dto.ListEncountersRequest{
+			UserName:    v.userName,
+			InitialDate: v.initialDate,
+			FinalDate:   time.Now(),
+			Page:        1,
+			ItemsLimit:  10,
+		}
+
+		page, err := encounterRepository.ListByUserNameAndDateRange(ctx, req)
+		if err != nil || page.TotalItems != v.answer || uint64(len(page.Encounters)) != v.answer {
+			t.Errorf("%s: Expected %v but got %v %v", nameTest, v.answer, page.TotalItems, err)
+			return
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
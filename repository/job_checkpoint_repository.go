@@ -0,0 +1,106 @@
+// Package repository implements facade to relational database.
+// Through implementation of the JobCheckpointRepositoryInterface methods,
+// it is possible to define the necessary updates and fetches
+// to manage JobCheckpoint entity model.
+package repository
+
+import (
+	"context"
+	"github.com/go-pg/pg/v10"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// JobCheckpointRepositoryInterface is the interface of JobCheckpoint repository layer.
+// Contains definition of methods to manage the database representation of JobCheckpoint
+// entity.
+type JobCheckpointRepositoryInterface interface {
+	Get(ctx context.Context, job string) (*dto.JobCheckpoint, error)
+	Save(ctx context.Context, job string, userName string) error
+	Delete(ctx context.Context, job string) error
+}
+
+// JobCheckpointRepository represents the relational database repository layer of
+// JobCheckpoint entity. Exists at most one record for each job of a tenant. Every query
+// is scoped by the tenant carried by the context.
+type JobCheckpointRepository struct {
+	db  *pg.DB         // available database
+	log *logrus.Logger // structured logger
+}
+
+// NewJobCheckpointRepository initializes repository of JobCheckpoint entity.
+func NewJobCheckpointRepository(db *pg.DB, log *logrus.Logger) JobCheckpointRepositoryInterface {
+	return &JobCheckpointRepository{
+		db,
+		log,
+	}
+}
+
+// Get implements query select action of JobCheckpoint entity by job. Returns nil if the
+// job has no checkpoint.
+func (r *JobCheckpointRepository) Get(ctx context.Context, job string) (*dto.JobCheckpoint, error) {
+	ctx, cancel := queryContext(ctx, OpGetJobCheckpoint)
+	defer cancel()
+
+	c := dto.JobCheckpoint{}
+	err := r.db.ModelContext(ctx, &c).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("job = ?", job).
+		Select()
+
+	if err == pg.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetJobCheckpoint, enums.ErrorJobCheckpointCode, err)
+	}
+
+	return &c, nil
+}
+
+// Save implements upsert action of JobCheckpoint entity, recording userName as the last
+// username completed by job.
+func (r *JobCheckpointRepository) Save(ctx context.Context, job string, userName string) error {
+	ctx, cancel := queryContext(ctx, OpSaveJobCheckpoint)
+	defer cancel()
+
+	c := dto.JobCheckpoint{
+		TenantId:  tenant.FromContext(ctx),
+		Job:       job,
+		UserName:  userName,
+		UpdatedAt: time.Now(),
+	}
+
+	_, err := r.db.ModelContext(ctx, &c).
+		OnConflict("(tenant_id, job) DO UPDATE").
+		Set("username = EXCLUDED.username").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+
+	if err != nil {
+		return queryError(ctx, r.log, OpSaveJobCheckpoint, enums.ErrorJobCheckpointCode, err)
+	}
+
+	return nil
+}
+
+// Delete implements delete action of JobCheckpoint entity by job, once the job completes.
+func (r *JobCheckpointRepository) Delete(ctx context.Context, job string) error {
+	ctx, cancel := queryContext(ctx, OpDeleteJobCheckpoint)
+	defer cancel()
+
+	_, err := r.db.ModelContext(ctx, (*dto.JobCheckpoint)(nil)).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("job = ?", job).
+		Delete()
+
+	if err != nil {
+		return queryError(ctx, r.log, OpDeleteJobCheckpoint, enums.ErrorJobCheckpointCode, err)
+	}
+
+	return nil
+}
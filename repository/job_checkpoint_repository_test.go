@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/oboadagd/location-history-mgmt/testutils"
+	"testing"
+)
+
+func TestJobCheckpoint(t *testing.T) {
+	nameTest := "TestJobCheckpoint"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	jobCheckpointRepository := NewJobCheckpointRepository(db, testutils.GetLogger())
+	ctx := context.Background()
+	otherCtx := tenant.WithTenant(ctx, "tenantb")
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	c, err := jobCheckpointRepository.Get(ctx, "jobsample")
+	if err != nil || c != nil {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, nil, c, err)
+		return
+	}
+
+	for _, userName := range []string{"usernamea", "usernameb"} {
+		if err = jobCheckpointRepository.Save(ctx, "jobsample", userName); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	type test struct {
+		ctx    context.Context
+		job    string
+		answer string
+	}
+
+	tests := []test{
+		{ctx, "jobsample", "usernameb"},
+		{ctx, "otherjob", ""},
+		{otherCtx, "jobsample", ""},
+	}
+
+	for _, v := range tests {
+		c, err := jobCheckpointRepository.Get(v.ctx, v.job)
+		if err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+
+		if (c == nil) != (v.answer == "") || c != nil && c.UserName != v.answer {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.job, v.answer, c)
+			return
+		}
+	}
+
+	if err = jobCheckpointRepository.Delete(ctx, "jobsample"); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	c, err = jobCheckpointRepository.Get(ctx, "jobsample")
+	if err != nil || c != nil {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, nil, c, err)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,87 @@
+// Package repository implements facade to relational database.
+// Through implementation of the LocationFilterStateRepositoryInterface
+// methods, it is possible to define the necessary updates and fetches
+// to manage LocationFilterState entity model.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-pg/pg/v10"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/sirupsen/logrus"
+)
+
+// LocationFilterStateRepositoryInterface is the interface of LocationFilterState
+// repository layer. Contains definition of methods to manage the database
+// representation of LocationFilterState entity.
+type LocationFilterStateRepositoryInterface interface {
+	GetByUserNameAndDevice(ctx context.Context, userName string, deviceId string) (*dto.LocationFilterState, error)
+	Save(ctx context.Context, state dto.LocationFilterState) error
+}
+
+// LocationFilterStateRepository represents the relational database repository layer of
+// LocationFilterState entity. Exists a unique record for each device of a username of a
+// tenant. Every query is scoped by the tenant carried by the context.
+type LocationFilterStateRepository struct {
+	db  *pg.DB         // available database
+	log *logrus.Logger // structured logger
+}
+
+// NewLocationFilterStateRepository initializes repository of LocationFilterState entity.
+func NewLocationFilterStateRepository(db *pg.DB, log *logrus.Logger) LocationFilterStateRepositoryInterface {
+	return &LocationFilterStateRepository{
+		db,
+		log,
+	}
+}
+
+// GetByUserNameAndDevice implements query select action of LocationFilterState entity by
+// username and device. Returns username data not found if the device of the username
+// has no state.
+func (r *LocationFilterStateRepository) GetByUserNameAndDevice(ctx context.Context, userName string, deviceId string) (*dto.LocationFilterState, error) {
+	ctx, cancel := queryContext(ctx, OpGetLocationFilterState)
+	defer cancel()
+
+	var s dto.LocationFilterState
+	err := r.db.ModelContext(ctx, &s).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", userName).
+		Where("device_id = ?", deviceId).
+		Select()
+
+	if err == pg.ErrNoRows {
+		return &dto.LocationFilterState{}, respKit.GenericNotFoundError(enums.ErrorLocationFilterStateNotFoundCode, fmt.Sprintf(enums.ErrorLocationFilterStateNotFoundMsg, userName, deviceId))
+	}
+
+	if err != nil {
+		return &dto.LocationFilterState{}, queryError(ctx, r.log, OpGetLocationFilterState, enums.ErrorGetLocationFilterStateCode, err)
+	}
+
+	return &s, nil
+}
+
+// Save implements upsert action of LocationFilterState entity. The state of the device
+// of the username is inserted or replaced.
+func (r *LocationFilterStateRepository) Save(ctx context.Context, state dto.LocationFilterState) error {
+	ctx, cancel := queryContext(ctx, OpSaveLocationFilterState)
+	defer cancel()
+
+	state.TenantId = tenant.FromContext(ctx)
+	_, err := r.db.ModelContext(ctx, &state).
+		OnConflict("(tenant_id, username, device_id) DO UPDATE").
+		Set("latitude = EXCLUDED.latitude").
+		Set("longitude = EXCLUDED.longitude").
+		Set("variance = EXCLUDED.variance").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+
+	if err != nil {
+		return queryError(ctx, r.log, OpSaveLocationFilterState, enums.ErrorSaveLocationFilterStateCode, err)
+	}
+
+	return nil
+}
@@ -8,71 +8,162 @@ import (
 	"context"
 	"fmt"
 	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
 	respKit "github.com/oboadagd/kit-go/middleware/responses"
-	"github.com/oboadagd/location-common/dto"
 	"github.com/oboadagd/location-common/enums"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	histEnums "github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/sirupsen/logrus"
+	"strings"
 	"time"
 )
 
 // LocationHistoryRepositoryInterface is the interface of LocationHistory repository layer.
 // Contains definition of methods to manage the database representation of
-// LocationHistory entity.
+// LocationHistory entity. Queries run under the caller's context bounded by the
+// statement timeout configured for each operation.
 type LocationHistoryRepositoryInterface interface {
 	Create(ctx context.Context, request dto.CreateLocationHistoryRequest) error
 	GetDistanceByUserNameAndDateRange(ctx context.Context, request dto.GetDistanceTraveledRequest) (*dto.GetDistanceTraveledResponse, error)
+	GetDistanceByBuckets(ctx context.Context, request dto.GetDistanceBreakdownRequest, boundaries []time.Time) ([]float64, error)
 	GetLastByUserName(ctx context.Context, request dto.GetLastByUserNameRequest) (*dto.GetLastByUserNameResponse, error)
+	GetHistoryByUserNameAndDateRange(ctx context.Context, request dto.GetLocationHistoryRequest) (*dto.GetLocationHistoryResponse, error)
+	CountOutliersByUserNameAndDateRange(ctx context.Context, request dto.GetOutlierCountRequest) (*dto.GetOutlierCountResponse, error)
+	GetUserDevices(ctx context.Context, userName string) ([]dto.UserDevice, error)
+	GetPageByUserNameAndDevice(ctx context.Context, userName string, deviceId string, after dto.LocationHistory, limit int) ([]dto.LocationHistory, error)
+	RebuildDistanceRollups(ctx context.Context, userName string) (int, error)
+	UpdateDistances(ctx context.Context, discrepancies []dto.DistanceDiscrepancy) error
+	GetLeaderboard(ctx context.Context, request dto.GetLeaderboardRequest) (*dto.GetLeaderboardResponse, error)
+	GetLastInAreaByDateRange(ctx context.Context, area dto.BoundingBox, initialDate time.Time, finalDate time.Time) ([]dto.Location, error)
+	GetTrackByUserNameAndDateRange(ctx context.Context, userName string, deviceId string, initialDate time.Time, finalDate time.Time) ([]dto.LocationHistory, error)
+	GetTracksInAreaByDateRange(ctx context.Context, area dto.BoundingBox, initialDate time.Time, finalDate time.Time) ([]dto.LocationHistory, error)
+	GetPrunableDays(ctx context.Context, userName string, cutoff time.Time, downsample bool) ([]dto.PrunableDay, error)
+	PruneDay(ctx context.Context, userName string, day time.Time, downsample bool) (int, error)
+	GetHorizon(ctx context.Context, userName string) (time.Time, error)
+	CreatePartitions(ctx context.Context, from time.Time, until time.Time) ([]string, error)
+	GetPartitions(ctx context.Context) ([]dto.HistoryPartition, error)
+	DropPartition(ctx context.Context, partition dto.HistoryPartition) (int, bool, error)
 }
 
 // LocationHistoryRepository represents the relational database repository layer of
-// LocationHistory entity. It's the historic registry of Location records.
+// LocationHistory entity. It's the historic registry of Location records. Every
+// query is scoped by the tenant carried by the context.
 type LocationHistoryRepository struct {
-	db *pg.DB
+	db  *pg.DB
+	log *logrus.Logger // structured logger
 }
 
 // NewLocationHistoryRepository initializes repository of LocationHistory entity
-func NewLocationHistoryRepository(db *pg.DB) LocationHistoryRepositoryInterface {
+func NewLocationHistoryRepository(db *pg.DB, log *logrus.Logger) LocationHistoryRepositoryInterface {
 	return &LocationHistoryRepository{
 		db,
+		log,
 	}
 }
 
-// Create implements insert action of LocationHistory entity
-func (r *LocationHistoryRepository) Create(_ context.Context, request dto.CreateLocationHistoryRequest) error {
+// Create implements insert action of LocationHistory entity. The DistanceRollup of the
+// hour of the record is updated with its distance in the same transaction.
+func (r *LocationHistoryRepository) Create(ctx context.Context, request dto.CreateLocationHistoryRequest) error {
+	ctx, cancel := queryContext(ctx, OpCreateLocationHistory)
+	defer cancel()
 
 	lh := dto.LocationHistory{
-		UserName:  request.UserName,
-		Latitude:  request.Latitude,
-		Longitude: request.Longitude,
-		Distance:  request.Distance,
-		UpdatedAt: time.Now(),
+		TenantId:          tenant.FromContext(ctx),
+		UserName:          request.UserName,
+		DeviceId:          request.DeviceId,
+		Latitude:          request.Latitude,
+		Longitude:         request.Longitude,
+		Distance:          request.Distance,
+		ExcludedReason:    request.ExcludedReason,
+		FilteredLatitude:  request.FilteredLatitude,
+		FilteredLongitude: request.FilteredLongitude,
+		DistanceAlgorithm: request.DistanceAlgorithm,
+		Fix:               request.Fix,
+		UpdatedAt:         time.Now(),
 	}
 
-	_, errIns := r.db.Model(&lh).Insert()
+	rollup := dto.DistanceRollup{
+		TenantId:   lh.TenantId,
+		UserName:   lh.UserName,
+		DeviceId:   lh.DeviceId,
+		Hour:       lh.UpdatedAt.Truncate(time.Hour),
+		Distance:   lh.Distance,
+		PointCount: 1,
+	}
+
+	errIns := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.ModelContext(ctx, &lh).Insert(); err != nil {
+			return err
+		}
+
+		_, err := tx.ModelContext(ctx, &rollup).
+			OnConflict("(tenant_id, username, device_id, hour) DO UPDATE").
+			Set(`distance = "distanceRollup".distance + EXCLUDED.distance`).
+			Set(`point_count = "distanceRollup".point_count + EXCLUDED.point_count`).
+			Insert()
+		return err
+	})
 	if errIns != nil {
-		return respKit.GenericBadRequestError(enums.ErrorInsertLocationCode, errIns.Error())
+		return queryError(ctx, r.log, OpCreateLocationHistory, enums.ErrorInsertLocationCode, errIns)
 	}
 
 	return nil
 }
 
 // GetDistanceByUserNameAndDateRange implements query select action of LocationHistory
-// entity by username and date range. Returns the distance accumulated by a username across
-// multiple records within a range of start date and end date. Returns error username data
-// not found in case username doesn't exist.
-func (r *LocationHistoryRepository) GetDistanceByUserNameAndDateRange(_ context.Context, request dto.GetDistanceTraveledRequest) (*dto.GetDistanceTraveledResponse, error) {
+// entity by username and date range. Returns the distance accumulated by a device of a
+// username across multiple records within a range of start date and end date. When no
+// device is requested, the distance of the device that traveled the most is returned, so
+// devices carried together are not counted twice. The hours fully within the range are
+// summed from DistanceRollup records, and only the partial hours at its edges from
+// LocationHistory records. Hours before the HistoryHorizon of the username are only
+// summed from DistanceRollup records, since their records were deleted or downsampled,
+// so a partial hour at an edge of the range counts whole if it was pruned. Returns error
+// username data not found in case username doesn't exist.
+func (r *LocationHistoryRepository) GetDistanceByUserNameAndDateRange(ctx context.Context, request dto.GetDistanceTraveledRequest) (*dto.GetDistanceTraveledResponse, error) {
+	ctx, cancel := queryContext(ctx, OpGetDistanceByUserNameAndDateRange)
+	defer cancel()
+
+	tenantId := tenant.FromContext(ctx)
+	byDevice := func(q *orm.Query) *orm.Query {
+		q = q.Column("username", "device_id", "distance").
+			Where("tenant_id = ?", tenantId).
+			Where("username = ?", request.UserName)
+		if request.DeviceId != "" {
+			q = q.Where("device_id = ?", request.DeviceId)
+		}
+		return q
+	}
+
+	fullStart, fullEnd := fullHours(request.InitialDate, request.FinalDate)
+
+	distances := unpruned(byDevice(r.db.ModelContext(ctx, (*dto.LocationHistory)(nil))), "updated_at").
+		Where("updated_at >= ?", request.InitialDate).
+		Where("updated_at <= ?", request.FinalDate)
+	if fullStart.Before(fullEnd) {
+		distances = distances.Where("updated_at < ? OR updated_at >= ?", fullStart, fullEnd)
+	}
+
+	// full hours, and the pruned partial hours at the edges
+	rollups := byDevice(r.db.ModelContext(ctx, (*dto.DistanceRollup)(nil))).
+		Where("hour >= ?", request.InitialDate.Truncate(time.Hour)).
+		Where("hour <= ?", request.FinalDate).
+		Where("(hour >= ? AND hour < ?) OR hour < "+prunedBefore, fullStart, fullEnd)
+	distances = distances.UnionAll(rollups)
+
 	var td []dto.GetDistanceTraveledResponse
-	lh := dto.LocationHistory{}
-	err := r.db.Model(&lh).
-		Column("username").
+	err := r.db.ModelContext(ctx).
+		TableExpr("(?) AS distances", distances).
+		Column("username", "device_id").
 		ColumnExpr("sum(distance) AS total_distance").
-		Where("username = ?", request.UserName).
-		Where("updated_at >= ?", request.InitialDate).
-		Where("updated_at <= ?", request.FinalDate).
-		Group("username").
+		Group("username", "device_id").
+		OrderExpr("total_distance DESC, device_id").
+		Limit(1).
 		Select(&td)
 
 	if err != nil {
-		return &dto.GetDistanceTraveledResponse{}, respKit.GenericBadRequestError(enums.ErrorGetDistanceTraveledByUserNameCode, err.Error())
+		return &dto.GetDistanceTraveledResponse{}, queryError(ctx, r.log, OpGetDistanceByUserNameAndDateRange, enums.ErrorGetDistanceTraveledByUserNameCode, err)
 	}
 
 	if len(td) == 0 {
@@ -82,20 +173,78 @@ func (r *LocationHistoryRepository) GetDistanceByUserNameAndDateRange(_ context.
 	return &td[0], nil
 }
 
+// GetDistanceByBuckets implements query select action of LocationHistory entity by
+// username, device and date range. Returns the distance accumulated by the device within
+// each bucket delimited by consecutive boundaries, with zero distance for buckets without
+// records. Only records within the date range of the request are accumulated.
+func (r *LocationHistoryRepository) GetDistanceByBuckets(ctx context.Context, request dto.GetDistanceBreakdownRequest, boundaries []time.Time) ([]float64, error) {
+	ctx, cancel := queryContext(ctx, OpGetDistanceByBuckets)
+	defer cancel()
+
+	if len(boundaries) < 2 {
+		return []float64{}, nil
+	}
+
+	thresholds := make([]float64, len(boundaries))
+	for i, b := range boundaries {
+		thresholds[i] = float64(b.Unix())
+	}
+
+	var sums []struct {
+		Bucket   int
+		Distance float64
+	}
+	lh := dto.LocationHistory{}
+	err := r.db.ModelContext(ctx, &lh).
+		ColumnExpr("width_bucket(extract(epoch FROM updated_at)::float8, ?::float8[]) AS bucket", pg.Array(thresholds)).
+		ColumnExpr("sum(distance) AS distance").
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", request.UserName).
+		Where("device_id = ?", request.DeviceId).
+		Where("updated_at >= ?", request.InitialDate).
+		Where("updated_at <= ?", request.FinalDate).
+		Group("bucket").
+		Select(&sums)
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetDistanceByBuckets, histEnums.ErrorGetDistanceBreakdownCode, err)
+	}
+
+	// width_bucket numbers the buckets from 1, and records out of the boundaries fall
+	// in bucket 0 or len(boundaries)
+	distances := make([]float64, len(boundaries)-1)
+	for _, s := range sums {
+		if s.Bucket >= 1 && s.Bucket < len(boundaries) {
+			distances[s.Bucket-1] = s.Distance
+		}
+	}
+
+	return distances, nil
+}
+
 // GetLastByUserName implements query select action of later LocationHistory
-// entity by username. Returns later record by a username. Returns error username data
-// not found in case username doesn't exist.
-func (r *LocationHistoryRepository) GetLastByUserName(_ context.Context, request dto.GetLastByUserNameRequest) (*dto.GetLastByUserNameResponse, error) {
+// entity by username and device. Returns later record reported by the device of a
+// username that isn't excluded from distance accumulation. Returns error username data
+// not found in case the device of the username has no such records.
+func (r *LocationHistoryRepository) GetLastByUserName(ctx context.Context, request dto.GetLastByUserNameRequest) (*dto.GetLastByUserNameResponse, error) {
+	ctx, cancel := queryContext(ctx, OpGetLastByUserName)
+	defer cancel()
+
 	var td []dto.GetLastByUserNameResponse
 	lh := dto.LocationHistory{}
-	err := r.db.Model(&lh).
-		Column("username", "latitude", "longitude").
+	err := r.db.ModelContext(ctx, &lh).
+		Column("username", "device_id", "latitude", "longitude", "updated_at",
+			"horizontal_accuracy", "filtered_latitude", "filtered_longitude").
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
 		Where("username = ?", request.UserName).
-		Order("updated_at DESC").
+		Where("device_id = ?", request.DeviceId).
+		Where("excluded_reason IS NULL").
+		Order("updated_at DESC", "id DESC").
+		Limit(1).
 		Select(&td)
 
 	if err != nil {
-		return &dto.GetLastByUserNameResponse{}, respKit.GenericBadRequestError(enums.ErrorGetLastLocationHistoryByUserNameCode, err.Error())
+		return &dto.GetLastByUserNameResponse{}, queryError(ctx, r.log, OpGetLastByUserName, enums.ErrorGetLastLocationHistoryByUserNameCode, err)
 	}
 
 	if len(td) == 0 {
@@ -104,3 +253,713 @@ func (r *LocationHistoryRepository) GetLastByUserName(_ context.Context, request
 
 	return &td[0], nil
 }
+
+// GetHistoryByUserNameAndDateRange implements query select action of LocationHistory
+// entity by username, optionally by device, and date range. Returns the requested page of
+// records ordered by date. Returns error username data not found in case username has no
+// records in the range.
+func (r *LocationHistoryRepository) GetHistoryByUserNameAndDateRange(ctx context.Context, request dto.GetLocationHistoryRequest) (*dto.GetLocationHistoryResponse, error) {
+	ctx, cancel := queryContext(ctx, OpGetHistoryByUserNameAndDateRange)
+	defer cancel()
+
+	var lh []dto.LocationHistory
+	q := r.db.ModelContext(ctx, &lh).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", request.UserName).
+		Where("updated_at >= ?", request.InitialDate).
+		Where("updated_at <= ?", request.FinalDate)
+
+	if request.DeviceId != "" {
+		q = q.Where("device_id = ?", request.DeviceId)
+	}
+
+	count, err := q.Order("updated_at", "id").
+		Offset(int((request.Page - 1) * request.ItemsLimit)).
+		Limit(int(request.ItemsLimit)).
+		SelectAndCount()
+
+	if err != nil {
+		return &dto.GetLocationHistoryResponse{}, queryError(ctx, r.log, OpGetHistoryByUserNameAndDateRange, enums.ErrorGetLastLocationHistoryByUserNameCode, err)
+	}
+
+	if count == 0 {
+		return &dto.GetLocationHistoryResponse{}, respKit.GenericNotFoundError(enums.ErrorUserNameNotFoundCode, fmt.Sprintf(enums.ErrorUserNameNotFoundMsg, request.UserName))
+	}
+
+	totalItems := uint64(count)
+	totalPages := totalItems / request.ItemsLimit
+	if totalItems%request.ItemsLimit != 0 {
+		totalPages++
+	}
+
+	return &dto.GetLocationHistoryResponse{
+		Locations:  lh,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// CountOutliersByUserNameAndDateRange implements count action of LocationHistory entity
+// flagged as outliers by username, optionally by device, and date range. Returns zero
+// outliers in case username has no records.
+func (r *LocationHistoryRepository) CountOutliersByUserNameAndDateRange(ctx context.Context, request dto.GetOutlierCountRequest) (*dto.GetOutlierCountResponse, error) {
+	ctx, cancel := queryContext(ctx, OpCountOutliersByUserName)
+	defer cancel()
+
+	q := r.db.ModelContext(ctx, &dto.LocationHistory{}).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", request.UserName).
+		Where("excluded_reason = ?", histEnums.ExcludedOutlier).
+		Where("updated_at >= ?", request.InitialDate).
+		Where("updated_at <= ?", request.FinalDate)
+
+	if request.DeviceId != "" {
+		q = q.Where("device_id = ?", request.DeviceId)
+	}
+
+	count, err := q.Count()
+	if err != nil {
+		return &dto.GetOutlierCountResponse{}, queryError(ctx, r.log, OpCountOutliersByUserName, histEnums.ErrorCountOutliersCode, err)
+	}
+
+	return &dto.GetOutlierCountResponse{
+		Username: request.UserName,
+		DeviceId: request.DeviceId,
+		Outliers: uint64(count),
+	}, nil
+}
+
+// GetUserDevices implements query select action of the devices that reported
+// LocationHistory entity, optionally of a username. An empty userName returns the
+// devices of every username of the tenant.
+func (r *LocationHistoryRepository) GetUserDevices(ctx context.Context, userName string) ([]dto.UserDevice, error) {
+	ctx, cancel := queryContext(ctx, OpGetUserDevices)
+	defer cancel()
+
+	var ud []dto.UserDevice
+	q := r.db.ModelContext(ctx, &dto.LocationHistory{}).
+		ColumnExpr("DISTINCT username, device_id").
+		Where("tenant_id = ?", tenant.FromContext(ctx))
+
+	if userName != "" {
+		q = q.Where("username = ?", userName)
+	}
+
+	if err := q.Order("username", "device_id").Select(&ud); err != nil {
+		return nil, queryError(ctx, r.log, OpGetUserDevices, enums.ErrorGetLastLocationHistoryByUserNameCode, err)
+	}
+
+	return ud, nil
+}
+
+// GetPageByUserNameAndDevice implements query select action of LocationHistory entity by
+// username and device. Returns up to limit records ordered by date that follow after,
+// so every record of the device can be walked without offsets. An empty after returns
+// the first records.
+func (r *LocationHistoryRepository) GetPageByUserNameAndDevice(ctx context.Context, userName string, deviceId string, after dto.LocationHistory, limit int) ([]dto.LocationHistory, error) {
+	ctx, cancel := queryContext(ctx, OpGetHistoryPageByUserNameAndDevice)
+	defer cancel()
+
+	var lh []dto.LocationHistory
+	q := r.db.ModelContext(ctx, &lh).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", userName).
+		Where("device_id = ?", deviceId)
+
+	if after.Id != 0 {
+		q = q.Where("(updated_at, id) > (?, ?)", after.UpdatedAt, after.Id)
+	}
+
+	err := q.Order("updated_at", "id").
+		Limit(limit).
+		Select()
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetHistoryPageByUserNameAndDevice, enums.ErrorGetLastLocationHistoryByUserNameCode, err)
+	}
+
+	return lh, nil
+}
+
+// UpdateDistances implements update action of the distance of LocationHistory entities
+// by identifier, setting the recomputed distance of every discrepancy, and its algorithm
+// if set, in a single transaction. DistanceRollup entities aren't updated.
+func (r *LocationHistoryRepository) UpdateDistances(ctx context.Context, discrepancies []dto.DistanceDiscrepancy) error {
+	ctx, cancel := queryContext(ctx, OpUpdateHistoryDistances)
+	defer cancel()
+
+	tenantId := tenant.FromContext(ctx)
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		for _, d := range discrepancies {
+			q := tx.ModelContext(ctx, (*dto.LocationHistory)(nil)).
+				Set("distance = ?", d.Recomputed).
+				Where("tenant_id = ?", tenantId).
+				Where("id = ?", d.LocationHistoryId)
+			if d.Algorithm != "" {
+				q = q.Set("distance_algorithm = ?", d.Algorithm)
+			}
+
+			if _, err := q.Update(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return queryError(ctx, r.log, OpUpdateHistoryDistances, histEnums.ErrorUpdateDistancesCode, err)
+	}
+
+	return nil
+}
+
+// RebuildDistanceRollups implements replace action of the DistanceRollup entity of a
+// username, or of every username of the tenant if userName is empty, with the rollups
+// of their LocationHistory records. Rollups of the hours before the HistoryHorizon of a
+// username are kept, since its history was pruned. Returns the number of stored rollups.
+func (r *LocationHistoryRepository) RebuildDistanceRollups(ctx context.Context, userName string) (int, error) {
+	ctx, cancel := queryContext(ctx, OpRebuildDistanceRollups)
+	defer cancel()
+
+	tenantId := tenant.FromContext(ctx)
+	byUserName := func(q *orm.Query) *orm.Query {
+		q = q.Where("tenant_id = ?", tenantId)
+		if userName != "" {
+			q = q.Where("username = ?", userName)
+		}
+		return q
+	}
+
+	var stored int
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := unpruned(byUserName(tx.ModelContext(ctx, (*dto.DistanceRollup)(nil))), "hour").Delete(); err != nil {
+			return err
+		}
+
+		rollups := unpruned(byUserName(tx.ModelContext(ctx, (*dto.LocationHistory)(nil))), "updated_at").
+			Column("tenant_id", "username", "device_id").
+			ColumnExpr("to_timestamp(floor(extract(epoch FROM updated_at) / 3600) * 3600) AS hour").
+			ColumnExpr("coalesce(sum(distance), 0) AS distance").
+			ColumnExpr("count(*) AS point_count").
+			GroupExpr("1, 2, 3, 4")
+
+		res, err := tx.ExecContext(ctx, "INSERT INTO distance_rollup (tenant_id, username, device_id, hour, distance, point_count) ?", rollups)
+		if err != nil {
+			return err
+		}
+
+		stored = res.RowsAffected()
+		return nil
+	})
+	if err != nil {
+		return 0, queryError(ctx, r.log, OpRebuildDistanceRollups, histEnums.ErrorRebuildDistanceRollupsCode, err)
+	}
+
+	return stored, nil
+}
+
+// GetLeaderboard implements query select action of LocationHistory entity by date range,
+// optionally by usernames and area. Returns the requested page of usernames ranked by the
+// distance traveled by their device that traveled the most, ordered by rank and username,
+// and the rank of the requesting username if any. Usernames with the same distance share
+// the rank. Distances are summed like GetDistanceByUserNameAndDateRange, from
+// DistanceRollup records for full and pruned hours and from LocationHistory records for
+// the others, except for leaderboards by area, which only sum the LocationHistory records
+// located within it. Returns an empty page in case no username has records in the range.
+func (r *LocationHistoryRepository) GetLeaderboard(ctx context.Context, request dto.GetLeaderboardRequest) (*dto.GetLeaderboardResponse, error) {
+	ctx, cancel := queryContext(ctx, OpGetLeaderboard)
+	defer cancel()
+
+	tenantId := tenant.FromContext(ctx)
+	byUserNames := func(q *orm.Query) *orm.Query {
+		q = q.Column("username", "device_id", "distance").
+			Where("tenant_id = ?", tenantId)
+		if len(request.UserNames) > 0 {
+			q = q.Where("username IN (?)", pg.In(request.UserNames))
+		}
+		return q
+	}
+
+	distances := byUserNames(r.db.ModelContext(ctx, (*dto.LocationHistory)(nil))).
+		Where("updated_at >= ?", request.InitialDate).
+		Where("updated_at <= ?", request.FinalDate)
+
+	if request.Area != nil {
+		// rollups aren't located, so only the records within the area are summed
+		distances = inBoundingBox(distances, *request.Area)
+	} else {
+		fullStart, fullEnd := fullHours(request.InitialDate, request.FinalDate)
+
+		distances = unpruned(distances, "updated_at")
+		if fullStart.Before(fullEnd) {
+			distances = distances.Where("updated_at < ? OR updated_at >= ?", fullStart, fullEnd)
+		}
+
+		// full hours, and the pruned partial hours at the edges
+		rollups := byUserNames(r.db.ModelContext(ctx, (*dto.DistanceRollup)(nil))).
+			Where("hour >= ?", request.InitialDate.Truncate(time.Hour)).
+			Where("hour <= ?", request.FinalDate).
+			Where("(hour >= ? AND hour < ?) OR hour < "+prunedBefore, fullStart, fullEnd)
+		distances = distances.UnionAll(rollups)
+	}
+
+	devices := r.db.ModelContext(ctx).
+		TableExpr("(?) AS distances", distances).
+		Column("username", "device_id").
+		ColumnExpr("coalesce(sum(distance), 0) AS distance").
+		Group("username", "device_id")
+
+	users := r.db.ModelContext(ctx).
+		TableExpr("(?) AS devices", devices).
+		Column("username").
+		ColumnExpr("max(distance) AS distance").
+		Group("username")
+
+	ranked := r.db.ModelContext(ctx).
+		TableExpr("(?) AS users", users).
+		Column("username", "distance").
+		ColumnExpr("rank() OVER (ORDER BY distance DESC) AS rank")
+
+	var entries []dto.LeaderboardEntry
+	count, err := r.db.ModelContext(ctx).
+		TableExpr("(?) AS ranked", ranked).
+		Order("rank", "username").
+		Offset(int((request.Page - 1) * request.ItemsLimit)).
+		Limit(int(request.ItemsLimit)).
+		SelectAndCount(&entries)
+
+	if err != nil {
+		return &dto.GetLeaderboardResponse{}, queryError(ctx, r.log, OpGetLeaderboard, histEnums.ErrorGetLeaderboardCode, err)
+	}
+
+	totalItems := uint64(count)
+	totalPages := totalItems / request.ItemsLimit
+	if totalItems%request.ItemsLimit != 0 {
+		totalPages++
+	}
+
+	resp := dto.GetLeaderboardResponse{
+		Entries:    entries,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}
+
+	if request.UserName == "" {
+		return &resp, nil
+	}
+
+	var own []dto.LeaderboardEntry
+	err = r.db.ModelContext(ctx).
+		TableExpr("(?) AS ranked", ranked).
+		Where("username = ?", request.UserName).
+		Select(&own)
+
+	if err != nil {
+		return &dto.GetLeaderboardResponse{}, queryError(ctx, r.log, OpGetLeaderboard, histEnums.ErrorGetLeaderboardCode, err)
+	}
+
+	if len(own) > 0 {
+		resp.Own = &own[0]
+	}
+
+	return &resp, nil
+}
+
+// GetLastInAreaByDateRange implements query select action of the later LocationHistory
+// entity of every username of the tenant within a date range, skipping records excluded
+// as outliers or for low accuracy. Returns, ordered by username, the later records that
+// are located within area, so usernames that left the area before the final date aren't
+// returned.
+func (r *LocationHistoryRepository) GetLastInAreaByDateRange(ctx context.Context, area dto.BoundingBox, initialDate time.Time, finalDate time.Time) ([]dto.Location, error) {
+	ctx, cancel := queryContext(ctx, OpGetLastInAreaByDateRange)
+	defer cancel()
+
+	last := r.db.ModelContext(ctx, (*dto.LocationHistory)(nil)).
+		DistinctOn("username").
+		Column("tenant_id", "username", "device_id", "latitude", "longitude", "horizontal_accuracy", "updated_at").
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("updated_at >= ?", initialDate).
+		Where("updated_at <= ?", finalDate)
+	last = locatable(last).
+		Order("username", "updated_at DESC", "id DESC")
+
+	var u []dto.Location
+	q := r.db.ModelContext(ctx).TableExpr("(?) AS last", last)
+	err := inBoundingBox(q, area).
+		Order("username").
+		Select(&u)
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetLastInAreaByDateRange, histEnums.ErrorGetSnapshotCode, err)
+	}
+
+	return u, nil
+}
+
+// GetTrackByUserNameAndDateRange implements query select action of LocationHistory
+// entity by username, device and date range. Returns the records of the device, or of
+// every device of the username if deviceId is empty, ordered by date, skipping records
+// excluded as outliers or for low accuracy.
+func (r *LocationHistoryRepository) GetTrackByUserNameAndDateRange(ctx context.Context, userName string, deviceId string, initialDate time.Time, finalDate time.Time) ([]dto.LocationHistory, error) {
+	ctx, cancel := queryContext(ctx, OpGetTrackByUserNameAndDateRange)
+	defer cancel()
+
+	var lh []dto.LocationHistory
+	q := r.db.ModelContext(ctx, &lh).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", userName).
+		Where("updated_at >= ?", initialDate).
+		Where("updated_at <= ?", finalDate)
+
+	if deviceId != "" {
+		q = q.Where("device_id = ?", deviceId)
+	}
+
+	if err := locatable(q).Order("updated_at", "id").Select(); err != nil {
+		return nil, queryError(ctx, r.log, OpGetTrackByUserNameAndDateRange, histEnums.ErrorGetTrackCode, err)
+	}
+
+	return lh, nil
+}
+
+// GetTracksInAreaByDateRange implements query select action of LocationHistory entity of
+// every username of the tenant by area and date range. Returns the records located
+// within area ordered by username and date, skipping records excluded as outliers or for
+// low accuracy.
+func (r *LocationHistoryRepository) GetTracksInAreaByDateRange(ctx context.Context, area dto.BoundingBox, initialDate time.Time, finalDate time.Time) ([]dto.LocationHistory, error) {
+	ctx, cancel := queryContext(ctx, OpGetTracksInAreaByDateRange)
+	defer cancel()
+
+	var lh []dto.LocationHistory
+	q := r.db.ModelContext(ctx, &lh).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("updated_at >= ?", initialDate).
+		Where("updated_at <= ?", finalDate)
+
+	if err := inBoundingBox(locatable(q), area).Order("username", "updated_at", "id").Select(); err != nil {
+		return nil, queryError(ctx, r.log, OpGetTracksInAreaByDateRange, histEnums.ErrorGetTrackCode, err)
+	}
+
+	return lh, nil
+}
+
+// GetPrunableDays implements query select action of LocationHistory entity by username
+// before cutoff. Returns the days, truncated in UTC and in date order, with records
+// removed by pruning them. Pruning deletes every record of a day, or only the records
+// beyond the last one of every hour of each device if downsample is set.
+func (r *LocationHistoryRepository) GetPrunableDays(ctx context.Context, userName string, cutoff time.Time, downsample bool) ([]dto.PrunableDay, error) {
+	ctx, cancel := queryContext(ctx, OpGetPrunableDays)
+	defer cancel()
+
+	var days []dto.PrunableDay
+	_, err := r.db.QueryContext(ctx, &days, `
+		SELECT day, records FROM (
+			SELECT to_timestamp(floor(extract(epoch FROM updated_at) / 86400) * 86400) AS day,
+				count(*) - CASE WHEN ?3 THEN count(DISTINCT (device_id, floor(extract(epoch FROM updated_at) / 3600))) ELSE 0 END AS records
+			FROM location_history
+			WHERE tenant_id = ?0 AND username = ?1 AND updated_at < ?2
+			GROUP BY 1
+		) AS prunable
+		WHERE records > 0
+		ORDER BY day`,
+		tenant.FromContext(ctx), userName, cutoff, downsample)
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetPrunableDays, histEnums.ErrorGetPrunableDaysCode, err)
+	}
+
+	return days, nil
+}
+
+// PruneDay implements delete action of the LocationHistory entities of a username within
+// the day starting at day, in a single transaction. Downsampling keeps the last record of
+// every hour of each device, preferring records included in distance accumulation, and
+// carries the distance of the hour on it, so later distances still chain from it. Before
+// pruning, the DistanceRollup records of the hours of the day are stored from the
+// records, unless the day was already pruned, so distances remain available. The
+// HistoryHorizon of the username advances to the end of the day. Returns the number of
+// removed records.
+func (r *LocationHistoryRepository) PruneDay(ctx context.Context, userName string, day time.Time, downsample bool) (int, error) {
+	ctx, cancel := queryContext(ctx, OpPruneHistoryDay)
+	defer cancel()
+
+	tenantId := tenant.FromContext(ctx)
+	end := day.Add(24 * time.Hour)
+
+	var removed int
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO distance_rollup (tenant_id, username, device_id, hour, distance, point_count)
+			SELECT tenant_id, username, device_id, to_timestamp(floor(extract(epoch FROM updated_at) / 3600) * 3600),
+				coalesce(sum(distance), 0), count(*)
+			FROM location_history
+			WHERE tenant_id = ?0 AND username = ?1 AND updated_at >= ?2 AND updated_at < ?3
+				AND NOT EXISTS (SELECT 1 FROM history_horizon AS h WHERE h.tenant_id = ?0 AND h.username = ?1 AND h.pruned_before > ?2)
+			GROUP BY 1, 2, 3, 4
+			ON CONFLICT (tenant_id, username, device_id, hour) DO UPDATE SET
+				distance = EXCLUDED.distance,
+				point_count = EXCLUDED.point_count`,
+			tenantId, userName, day, end)
+		if err != nil {
+			return err
+		}
+
+		var res pg.Result
+		if downsample {
+			res, err = tx.ExecContext(ctx, `
+				WITH ranked AS (
+					SELECT id, sum(distance) OVER device_hour AS distance,
+						row_number() OVER (device_hour ORDER BY excluded_reason IS NULL DESC, updated_at DESC, id DESC) AS n
+					FROM location_history
+					WHERE tenant_id = ?0 AND username = ?1 AND updated_at >= ?2 AND updated_at < ?3
+					WINDOW device_hour AS (PARTITION BY device_id, floor(extract(epoch FROM updated_at) / 3600))
+				), kept AS (
+					UPDATE location_history AS lh SET distance = ranked.distance
+					FROM ranked
+					WHERE lh.id = ranked.id AND ranked.n = 1
+				)
+				DELETE FROM location_history AS lh
+				USING ranked
+				WHERE lh.id = ranked.id AND ranked.n > 1`,
+				tenantId, userName, day, end)
+		} else {
+			res, err = tx.ModelContext(ctx, (*dto.LocationHistory)(nil)).
+				Where("tenant_id = ?", tenantId).
+				Where("username = ?", userName).
+				Where("updated_at >= ?", day).
+				Where("updated_at < ?", end).
+				Delete()
+		}
+		if err != nil {
+			return err
+		}
+		removed = res.RowsAffected()
+
+		horizon := dto.HistoryHorizon{
+			TenantId:     tenantId,
+			UserName:     userName,
+			PrunedBefore: end,
+			UpdatedAt:    time.Now(),
+		}
+		_, err = tx.ModelContext(ctx, &horizon).
+			OnConflict("(tenant_id, username) DO UPDATE").
+			Set(`pruned_before = greatest("historyHorizon".pruned_before, EXCLUDED.pruned_before)`).
+			Set("updated_at = EXCLUDED.updated_at").
+			Insert()
+		return err
+	})
+	if err != nil {
+		return 0, queryError(ctx, r.log, OpPruneHistoryDay, histEnums.ErrorPruneHistoryCode, err)
+	}
+
+	return removed, nil
+}
+
+// GetHorizon implements query select action of HistoryHorizon entity by username.
+// Returns the date before which the history of the username was pruned, or the zero date
+// if it was never pruned.
+func (r *LocationHistoryRepository) GetHorizon(ctx context.Context, userName string) (time.Time, error) {
+	ctx, cancel := queryContext(ctx, OpGetHistoryHorizon)
+	defer cancel()
+
+	h := dto.HistoryHorizon{}
+	err := r.db.ModelContext(ctx, &h).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", userName).
+		Select()
+
+	if err == pg.ErrNoRows {
+		return time.Time{}, nil
+	}
+
+	if err != nil {
+		return time.Time{}, queryError(ctx, r.log, OpGetHistoryHorizon, histEnums.ErrorHistoryHorizonCode, err)
+	}
+
+	return h.PrunedBefore, nil
+}
+
+// CreatePartitions implements create action of the monthly partitions of LocationHistory
+// entity for the UTC months from the month of from to the month of until. Existing
+// partitions are kept. Returns the names of the partitions, or none if the table isn't
+// partitioned. Partitions span every tenant.
+func (r *LocationHistoryRepository) CreatePartitions(ctx context.Context, from time.Time, until time.Time) ([]string, error) {
+	ctx, cancel := queryContext(ctx, OpCreateHistoryPartitions)
+	defer cancel()
+
+	var names []string
+	_, err := r.db.QueryContext(ctx, &names, `
+		SELECT location_history_create_partition(partition_month AT TIME ZONE 'UTC')
+		FROM generate_series(date_trunc('month', ?0::timestamptz AT TIME ZONE 'UTC'), ?1::timestamptz AT TIME ZONE 'UTC', interval '1 month') AS partition_month
+		WHERE (SELECT relkind FROM pg_class WHERE oid = to_regclass('location_history')) = 'p'`,
+		from, until)
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpCreateHistoryPartitions, histEnums.ErrorCreatePartitionsCode, err)
+	}
+
+	return names, nil
+}
+
+// GetPartitions implements query select action of the monthly partitions of
+// LocationHistory entity, in month order, with the number of records estimated by the
+// planner statistics. Returns none if the table isn't partitioned.
+func (r *LocationHistoryRepository) GetPartitions(ctx context.Context) ([]dto.HistoryPartition, error) {
+	ctx, cancel := queryContext(ctx, OpGetHistoryPartitions)
+	defer cancel()
+
+	var partitions []dto.HistoryPartition
+	_, err := r.db.QueryContext(ctx, &partitions, `
+		SELECT c.relname AS name, greatest(c.reltuples, 0)::bigint AS records
+		FROM pg_inherits AS i
+		JOIN pg_class AS c ON c.oid = i.inhrelid
+		WHERE i.inhparent = to_regclass('location_history')
+		ORDER BY c.relname`)
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetHistoryPartitions, histEnums.ErrorGetPartitionsCode, err)
+	}
+
+	// partitions are named after their month, and others aren't managed
+	monthly := partitions[:0]
+	for _, p := range partitions {
+		if month, ok := partitionMonth(p.Name); ok {
+			p.Month = month
+			monthly = append(monthly, p)
+		}
+	}
+
+	return monthly, nil
+}
+
+// DropPartition implements drop action of a monthly partition of LocationHistory entity
+// in a single transaction. Before dropping it, the DistanceRollup records of the hours of
+// its records are stored from them, skipping records already pruned, and the
+// HistoryHorizon of every username with records advances to the end of the month, as
+// PruneDay does. Replicas dropping the same partition take turns, and the later ones
+// find it already dropped. Returns the number of dropped records, and false if the
+// partition was already dropped.
+func (r *LocationHistoryRepository) DropPartition(ctx context.Context, partition dto.HistoryPartition) (int, bool, error) {
+	ctx, cancel := queryContext(ctx, OpDropHistoryPartition)
+	defer cancel()
+
+	table := pg.Ident(partition.Name)
+	end := partition.Month.AddDate(0, 1, 0)
+
+	var dropped int
+	var attached bool
+	err := r.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", partition.Name); err != nil {
+			return err
+		}
+
+		_, err := tx.QueryOneContext(ctx, pg.Scan(&attached), `
+			SELECT EXISTS (SELECT 1 FROM pg_inherits
+				WHERE inhrelid = to_regclass(?) AND inhparent = to_regclass('location_history'))`,
+			partition.Name)
+		if err != nil || !attached {
+			return err
+		}
+
+		if _, err := tx.QueryOneContext(ctx, pg.Scan(&dropped), "SELECT count(*) FROM ?", table); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO distance_rollup (tenant_id, username, device_id, hour, distance, point_count)
+			SELECT tenant_id, username, device_id, to_timestamp(floor(extract(epoch FROM updated_at) / 3600) * 3600),
+				coalesce(sum(distance), 0), count(*)
+			FROM ?0 AS lh
+			WHERE NOT EXISTS (SELECT 1 FROM history_horizon AS h
+				WHERE h.tenant_id = lh.tenant_id AND h.username = lh.username AND h.pruned_before > lh.updated_at)
+			GROUP BY 1, 2, 3, 4
+			ON CONFLICT (tenant_id, username, device_id, hour) DO UPDATE SET
+				distance = EXCLUDED.distance,
+				point_count = EXCLUDED.point_count`,
+			table)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO history_horizon (tenant_id, username, pruned_before, updated_at)
+			SELECT DISTINCT tenant_id, username, ?1::timestamptz, now()
+			FROM ?0
+			ON CONFLICT (tenant_id, username) DO UPDATE SET
+				pruned_before = greatest(history_horizon.pruned_before, EXCLUDED.pruned_before),
+				updated_at = EXCLUDED.updated_at`,
+			table, end)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "ALTER TABLE location_history DETACH PARTITION ?", table); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "DROP TABLE ?", table)
+		return err
+	})
+	if err != nil {
+		return 0, false, queryError(ctx, r.log, OpDropHistoryPartition, histEnums.ErrorDropPartitionCode, err)
+	}
+
+	return dropped, attached, nil
+}
+
+// prunedBefore is the expression of the HistoryHorizon of the username of the records of
+// a query, or -infinity if its history was never pruned.
+const prunedBefore = `coalesce((SELECT h.pruned_before FROM history_horizon AS h
+	WHERE h.tenant_id = ?TableAlias.tenant_id AND h.username = ?TableAlias.username), '-infinity')`
+
+// unpruned filters q by the records whose date column isn't before the HistoryHorizon of
+// their username. Every record of usernames whose history was never pruned is kept.
+func unpruned(q *orm.Query, column string) *orm.Query {
+	return q.Where("? >= "+prunedBefore, pg.Ident(column))
+}
+
+// locatable filters q by the records that locate their username, skipping records
+// excluded as outliers or for low accuracy. Jitter is a displacement too small to count
+// as distance, but still locates the username.
+func locatable(q *orm.Query) *orm.Query {
+	return q.Where("excluded_reason IS NULL OR excluded_reason = ?", histEnums.ExcludedJitter)
+}
+
+// inBoundingBox filters q by the records whose coordinates are within box, which may
+// cross the antimeridian.
+func inBoundingBox(q *orm.Query, box dto.BoundingBox) *orm.Query {
+	q = q.Where("latitude BETWEEN ? AND ?", box.MinLatitude, box.MaxLatitude)
+	if box.MinLongitude <= box.MaxLongitude {
+		return q.Where("longitude BETWEEN ? AND ?", box.MinLongitude, box.MaxLongitude)
+	}
+
+	return q.Where("longitude >= ? OR longitude <= ?", box.MinLongitude, box.MaxLongitude)
+}
+
+// Monthly partitions of LocationHistory entity are named with partitionPrefix followed
+// by their UTC month formatted with partitionMonthLayout.
+const (
+	partitionPrefix      = "location_history_"
+	partitionMonthLayout = "200601"
+)
+
+// partitionMonth returns the UTC month of the monthly partition named name, and false if
+// name isn't the name of a monthly partition.
+func partitionMonth(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, partitionPrefix) {
+		return time.Time{}, false
+	}
+
+	month, err := time.Parse(partitionMonthLayout, strings.TrimPrefix(name, partitionPrefix))
+	return month, err == nil
+}
+
+// fullHours returns the range of the hours, truncated in UTC, fully within the range of
+// initial and final dates. The range is empty if the start isn't before the end.
+func fullHours(initialDate time.Time, finalDate time.Time) (time.Time, time.Time) {
+	start := initialDate.Truncate(time.Hour)
+	if start.Before(initialDate) {
+		start = start.Add(time.Hour)
+	}
+
+	return start, finalDate.Truncate(time.Hour)
+}
@@ -3,9 +3,16 @@ package repository
 import (
 	"context"
 	"fmt"
-	"github.com/oboadagd/location-common/dto"
+	"github.com/go-pg/pg/v10"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
 	"github.com/oboadagd/location-common/enums"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	histEnums "github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
 	"github.com/oboadagd/location-history-mgmt/testutils"
+	"math"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -15,7 +22,7 @@ func TestCreateLocationHistory(t *testing.T) {
 	db = testutils.GetTestDB()
 	defer db.Close()
 
-	locationHistoryRepository := NewLocationHistoryRepository(db)
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
 	ctx := context.Background()
 
 	err := testutils.CreateSchema(db)
@@ -47,7 +54,7 @@ func TestCreateLocationHistory_ErrorInsertLocationCode(t *testing.T) {
 	db = testutils.GetTestDB()
 	defer db.Close()
 
-	locationHistoryRepository := NewLocationHistoryRepository(db)
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
 	ctx := context.Background()
 
 	err := testutils.CreateSchema(db)
@@ -88,7 +95,7 @@ func TestGetDistanceByUserNameAndDateRange(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.Background()
-	locationHistoryRepository := NewLocationHistoryRepository(db)
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
 
 	err := testutils.CreateSchema(db)
 	if err != nil {
@@ -141,7 +148,7 @@ func TestGetDistanceByUserNameAndDateRange_ErrorUserNameNotFoundCode(t *testing.
 	defer db.Close()
 
 	ctx := context.Background()
-	locationHistoryRepository := NewLocationHistoryRepository(db)
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
 
 	err := testutils.CreateSchema(db)
 	if err != nil {
@@ -184,7 +191,7 @@ func TestGetLastByUserName(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.Background()
-	locationHistoryRepository := NewLocationHistoryRepository(db)
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
 
 	err := testutils.CreateSchema(db)
 	if err != nil {
@@ -227,7 +234,7 @@ func TestGetLastByUserName_ErrorUserNameNotFoundCode(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.Background()
-	locationHistoryRepository := NewLocationHistoryRepository(db)
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
 
 	err := testutils.CreateSchema(db)
 	if err != nil {
@@ -263,3 +270,697 @@ func TestGetLastByUserName_ErrorUserNameNotFoundCode(t *testing.T) {
 
 	t.Logf("%s Success", nameTest)
 }
+
+// slowQuerySeconds is how long the location history queries of the query cancellation
+// tests run before returning, unless they are canceled.
+const slowQuerySeconds = 5
+
+// slowLocationHistory replaces the temporary location history table by a view of its
+// records that sleeps slowQuerySeconds before returning them, so queries of the location
+// history are still running when they are canceled. The connection of a canceled query
+// is closed, dropping the temporary tables with it.
+func slowLocationHistory(db *pg.DB) error {
+	if _, err := db.Exec("ALTER TABLE location_history RENAME TO location_history_records"); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`CREATE TEMP VIEW location_history AS
+		SELECT r.* FROM location_history_records AS r, pg_sleep(?)`, slowQuerySeconds)
+	return err
+}
+
+func TestGetDistanceByUserNameAndDateRange_ErrorQueryCanceledCode(t *testing.T) {
+	nameTest := "TestGetDistanceByUserNameAndDateRange_ErrorQueryCanceledCode"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	lh := testutils.GetLocationHistory()
+
+	err = locationHistoryRepository.Create(context.Background(), *lh)
+
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if err := slowLocationHistory(db); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	dtr := dto.GetDistanceTraveledRequest{
+		UserName:    lh.UserName,
+		InitialDate: time.Now().Add(-24 * time.Hour),
+		FinalDate:   time.Now(),
+	}
+
+	started := time.Now()
+	_, err = locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctx, dtr)
+
+	httpErr, ok := err.(*respKit.GenericHttpError)
+	if !ok || httpErr.ErrorCode != histEnums.ErrorQueryCanceledCode || httpErr.Status != StatusClientClosedRequest {
+		t.Errorf("%s: Expected %v %v but got %v", nameTest, histEnums.ErrorQueryCanceledCode, StatusClientClosedRequest, err)
+		return
+	}
+
+	if elapsed := time.Since(started); elapsed >= slowQuerySeconds*time.Second {
+		t.Errorf("%s: Expected the query to be canceled mid-flight but it took %v", nameTest, elapsed)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGetDistanceByUserNameAndDateRange_ErrorQueryTimeoutCode(t *testing.T) {
+	nameTest := "TestGetDistanceByUserNameAndDateRange_ErrorQueryTimeoutCode"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	lh := testutils.GetLocationHistory()
+
+	err = locationHistoryRepository.Create(ctx, *lh)
+
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if err := slowLocationHistory(db); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	defer func(o map[string]time.Duration) { config.Cfg.DBOperationTimeouts = o }(config.Cfg.DBOperationTimeouts)
+	config.Cfg.DBOperationTimeouts = map[string]time.Duration{OpGetDistanceByUserNameAndDateRange: 100 * time.Millisecond}
+
+	dtr := dto.GetDistanceTraveledRequest{
+		UserName:    lh.UserName,
+		InitialDate: time.Now().Add(-24 * time.Hour),
+		FinalDate:   time.Now(),
+	}
+
+	started := time.Now()
+	_, err = locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctx, dtr)
+
+	httpErr, ok := err.(*respKit.GenericHttpError)
+	if !ok || httpErr.ErrorCode != histEnums.ErrorQueryTimeoutCode || httpErr.Status != http.StatusGatewayTimeout {
+		t.Errorf("%s: Expected %v %v but got %v", nameTest, histEnums.ErrorQueryTimeoutCode, http.StatusGatewayTimeout, err)
+		return
+	}
+
+	if elapsed := time.Since(started); elapsed >= slowQuerySeconds*time.Second {
+		t.Errorf("%s: Expected the query to time out mid-flight but it took %v", nameTest, elapsed)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestLocationHistoryTenantIsolation(t *testing.T) {
+	nameTest := "TestLocationHistoryTenantIsolation"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+	ctxA := tenant.WithTenant(context.Background(), "tenanta")
+	ctxB := tenant.WithTenant(context.Background(), "tenantb")
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	lh := testutils.GetLocationHistory()
+
+	if err = locationHistoryRepository.Create(ctxA, *lh); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	dtr := dto.GetDistanceTraveledRequest{
+		UserName:    lh.UserName,
+		InitialDate: time.Now().Add(-24 * time.Hour),
+		FinalDate:   time.Now().Add(time.Hour),
+	}
+
+	if _, err = locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctxB, dtr); err == nil ||
+		err.Error() != fmt.Sprintf(enums.ErrorUserNameNotFoundMsg, lh.UserName) {
+		t.Errorf("%s: Expected %v but got %v", nameTest, enums.ErrorUserNameNotFoundCode, err)
+		return
+	}
+
+	llh := dto.GetLastByUserNameRequest{
+		UserName: lh.UserName,
+	}
+
+	if _, err = locationHistoryRepository.GetLastByUserName(ctxB, llh); err == nil {
+		t.Errorf("%s: Expected %v but got %v", nameTest, enums.ErrorUserNameNotFoundCode, err)
+		return
+	}
+
+	resp, err := locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctxA, dtr)
+	if err != nil || resp.TotalDistance != lh.Distance {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, lh.Distance, resp.TotalDistance, err)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGetLastByUserName_Device(t *testing.T) {
+	nameTest := "TestGetLastByUserName_Device"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	for i, deviceId := range []string{"phone", "watch", "phone"} {
+		lh := testutils.GetLocationHistory()
+		lh.DeviceId = deviceId
+		lh.Latitude = float64(10 + i)
+		if err := locationHistoryRepository.Create(ctx, *lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	type test struct {
+		deviceId string
+		answer   float64
+		found    bool
+	}
+
+	tests := []test{
+		{"phone", 12, true},
+		{"watch", 11, true},
+		{"tablet", 0, false},
+	}
+
+	for _, v := range tests {
+		llh := dto.GetLastByUserNameRequest{
+			UserName: testutils.GetLocationHistory().UserName,
+			DeviceId: v.deviceId,
+		}
+
+		resp, err := locationHistoryRepository.GetLastByUserName(ctx, llh)
+		if (err == nil) != v.found {
+			t.Errorf("%s: %s Expected found %v but got %v", nameTest, v.deviceId, v.found, err)
+			return
+		}
+
+		if resp.Latitude != v.answer {
+			t.Errorf("%s: %s Expected %v but got %v", nameTest, v.deviceId, v.answer, resp.Latitude)
+			return
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGetDistanceByBuckets(t *testing.T) {
+	nameTest := "TestGetDistanceByBuckets"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	lh := testutils.GetLocationHistory()
+	for i := 0; i < 2; i++ {
+		if err = locationHistoryRepository.Create(ctx, *lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	now := time.Now().Truncate(time.Second)
+	req := dto.GetDistanceBreakdownRequest{
+		UserName:    lh.UserName,
+		DeviceId:    lh.DeviceId,
+		InitialDate: now.Add(-3 * time.Hour),
+		FinalDate:   now.Add(time.Hour),
+	}
+	boundaries := []time.Time{now.Add(-3 * time.Hour), now.Add(-time.Hour), now.Add(2 * time.Hour)}
+
+	distances, err := locationHistoryRepository.GetDistanceByBuckets(ctx, req, boundaries)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	answer := []float64{0, 2 * lh.Distance}
+	if fmt.Sprint(distances) != fmt.Sprint(answer) {
+		t.Errorf("%s: Expected %v but got %v", nameTest, answer, distances)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGetDistanceByUserNameAndDateRange_Rollups(t *testing.T) {
+	nameTest := "TestGetDistanceByUserNameAndDateRange_Rollups"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	lh := testutils.GetLocationHistory()
+	for i := 0; i < 3; i++ {
+		if err = locationHistoryRepository.Create(ctx, *lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	now := time.Now()
+
+	type test struct {
+		name        string
+		initialDate time.Time
+		finalDate   time.Time
+		rebuild     bool // rollups are removed and rebuilt first
+	}
+
+	// records within full hours are summed from the rollups, and the others raw
+	tests := []test{
+		{"full hours", now.Add(-3 * time.Hour), now.Add(3 * time.Hour), false},
+		{"partial hour", now.Add(-time.Minute), now.Add(time.Minute), false},
+		{"rebuilt", now.Add(-3 * time.Hour), now.Add(3 * time.Hour), true},
+	}
+
+	for _, v := range tests {
+		if v.rebuild {
+			if _, err = db.Model((*dto.DistanceRollup)(nil)).Where("username = ?", lh.UserName).Delete(); err != nil {
+				t.Errorf("%s %s: %v", nameTest, v.name, err)
+				return
+			}
+
+			n, err := locationHistoryRepository.RebuildDistanceRollups(ctx, lh.UserName)
+			if err != nil || n != 1 {
+				t.Errorf("%s %s: Expected %v but got %v %v", nameTest, v.name, 1, n, err)
+				return
+			}
+		}
+
+		resp, err := locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctx, dto.GetDistanceTraveledRequest{
+			UserName:    lh.UserName,
+			InitialDate: v.initialDate,
+			FinalDate:   v.finalDate,
+		})
+		if err != nil || resp.TotalDistance != 3*lh.Distance {
+			t.Errorf("%s %s: Expected %v but got %v %v", nameTest, v.name, 3*lh.Distance, resp.TotalDistance, err)
+			return
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestPruneDay(t *testing.T) {
+	nameTest := "TestPruneDay"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	lh := testutils.GetLocationHistory()
+	for i := 0; i < 3; i++ {
+		if err = locationHistoryRepository.Create(ctx, *lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	now := time.Now()
+	day := now.UTC().Truncate(24 * time.Hour)
+
+	type test struct {
+		name       string
+		downsample bool
+		prunable   int // records to prune before pruning the day
+		removed    int
+	}
+
+	// downsampling keeps one record of the hour, and deleting the day removes it
+	tests := []test{
+		{"downsample", true, 2, 2},
+		{"delete", false, 1, 1},
+	}
+
+	for _, v := range tests {
+		days, err := locationHistoryRepository.GetPrunableDays(ctx, lh.UserName, day.Add(24*time.Hour), v.downsample)
+		if err != nil || len(days) != 1 || !days[0].Day.Equal(day) || days[0].Records != v.prunable {
+			t.Errorf("%s %s: Expected %v but got %v %v", nameTest, v.name, v.prunable, days, err)
+			return
+		}
+
+		n, err := locationHistoryRepository.PruneDay(ctx, lh.UserName, day, v.downsample)
+		if err != nil || n != v.removed {
+			t.Errorf("%s %s: Expected %v but got %v %v", nameTest, v.name, v.removed, n, err)
+			return
+		}
+
+		horizon, err := locationHistoryRepository.GetHorizon(ctx, lh.UserName)
+		if err != nil || !horizon.Equal(day.Add(24*time.Hour)) {
+			t.Errorf("%s %s: Expected %v but got %v %v", nameTest, v.name, day.Add(24*time.Hour), horizon, err)
+			return
+		}
+
+		// rollups of pruned hours are kept by rebuilds
+		if n, err = locationHistoryRepository.RebuildDistanceRollups(ctx, lh.UserName); err != nil || n != 0 {
+			t.Errorf("%s %s: Expected %v but got %v %v", nameTest, v.name, 0, n, err)
+			return
+		}
+
+		resp, err := locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctx, dto.GetDistanceTraveledRequest{
+			UserName:    lh.UserName,
+			InitialDate: now.Add(-3 * time.Hour),
+			FinalDate:   now.Add(3 * time.Hour),
+		})
+		if err != nil || math.Abs(resp.TotalDistance-3*lh.Distance) > 1e-9 {
+			t.Errorf("%s %s: Expected %v but got %v %v", nameTest, v.name, 3*lh.Distance, resp.TotalDistance, err)
+			return
+		}
+
+		// a pruned hour counts whole, even if the range ends before its records
+		resp, err = locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctx, dto.GetDistanceTraveledRequest{
+			UserName:    lh.UserName,
+			InitialDate: now.Add(-3 * time.Hour),
+			FinalDate:   now.Truncate(time.Hour),
+		})
+		if err != nil || math.Abs(resp.TotalDistance-3*lh.Distance) > 1e-9 {
+			t.Errorf("%s %s partial hour: Expected %v but got %v %v", nameTest, v.name, 3*lh.Distance, resp.TotalDistance, err)
+			return
+		}
+
+		// leaderboards sum pruned hours from the rollups too
+		leaderboard, err := locationHistoryRepository.GetLeaderboard(ctx, dto.GetLeaderboardRequest{
+			InitialDate: now.Add(-3 * time.Hour),
+			FinalDate:   now.Truncate(time.Hour),
+			Page:        1,
+			ItemsLimit:  10,
+		})
+		if err != nil || len(leaderboard.Entries) != 1 || math.Abs(leaderboard.Entries[0].Distance-3*lh.Distance) > 1e-9 {
+			t.Errorf("%s %s leaderboard: Expected %v but got %v %v", nameTest, v.name, 3*lh.Distance, leaderboard, err)
+			return
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestPartitionMonth(t *testing.T) {
+	nameTest := "TestPartitionMonth"
+
+	type test struct {
+		name   string
+		month  time.Time
+		answer bool
+	}
+
+	tests := []test{
+		{"location_history_202401", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"location_history_202412", time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), true},
+		{"location_history_default", time.Time{}, false},
+		{"location_history_202413", time.Time{}, false},
+		{"trip_202401", time.Time{}, false},
+	}
+
+	for _, v := range tests {
+		month, ok := partitionMonth(v.name)
+		if ok != v.answer || !month.Equal(v.month) {
+			t.Errorf("%s %s: Expected %v %v but got %v %v", nameTest, v.name, v.month, v.answer, month, ok)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestFullHours(t *testing.T) {
+	nameTest := "TestFullHours"
+
+	hour := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	type test struct {
+		initialDate time.Time
+		finalDate   time.Time
+		start       time.Time
+		end         time.Time
+	}
+
+	tests := []test{
+		{hour, hour.Add(2 * time.Hour), hour, hour.Add(2 * time.Hour)},
+		{hour.Add(time.Minute), hour.Add(150 * time.Minute), hour.Add(time.Hour), hour.Add(2 * time.Hour)},
+		{hour.Add(time.Minute), hour.Add(59 * time.Minute), hour.Add(time.Hour), hour},
+	}
+
+	for _, v := range tests {
+		start, end := fullHours(v.initialDate, v.finalDate)
+		if !start.Equal(v.start) || !end.Equal(v.end) {
+			t.Errorf("%s: Expected %v %v but got %v %v", nameTest, v.start, v.end, start, end)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	nameTest := "TestGetLeaderboard"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// usernamea ranks by its phone only, tying with usernameb
+	records := []dto.CreateLocationHistoryRequest{
+		{UserName: "usernamea", DeviceId: "phone", Latitude: 10, Longitude: 10, Distance: 3},
+		{UserName: "usernamea", DeviceId: "watch", Latitude: 10, Longitude: 10, Distance: 2},
+		{UserName: "usernameb", Latitude: 10, Longitude: 10, Distance: 1},
+		{UserName: "usernameb", Latitude: 10, Longitude: 10, Distance: 2},
+		{UserName: "usernamec", Latitude: 50, Longitude: 10, Distance: 1},
+	}
+
+	for _, lh := range records {
+		if err = locationHistoryRepository.Create(ctx, lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	type test struct {
+		name      string
+		request   dto.GetLeaderboardRequest
+		userNames string // usernames of the page
+		ranks     string // ranks of the page
+		own       uint64 // own rank, zero for none
+		total     uint64
+	}
+
+	area := &dto.BoundingBox{MinLatitude: 0, MinLongitude: 0, MaxLatitude: 20, MaxLongitude: 20}
+	tests := []test{
+		{"every username", dto.GetLeaderboardRequest{UserName: "usernamec", Page: 1, ItemsLimit: 10}, "[usernamea usernameb usernamec]", "[1 1 3]", 3, 3},
+		{"second page", dto.GetLeaderboardRequest{Page: 2, ItemsLimit: 2}, "[usernamec]", "[3]", 0, 3},
+		{"usernames", dto.GetLeaderboardRequest{UserNames: []string{"usernameb", "usernamec"}, Page: 1, ItemsLimit: 10}, "[usernameb usernamec]", "[1 2]", 0, 2},
+		{"area", dto.GetLeaderboardRequest{UserName: "usernamec", Area: area, Page: 1, ItemsLimit: 10}, "[usernamea usernameb]", "[1 1]", 0, 2},
+	}
+
+	for _, v := range tests {
+		v.request.InitialDate = time.Now().Add(-time.Hour)
+		v.request.FinalDate = time.Now().Add(time.Hour)
+
+		resp, err := locationHistoryRepository.GetLeaderboard(ctx, v.request)
+		if err != nil {
+			t.Errorf("%s %s: %v", nameTest, v.name, err)
+			return
+		}
+
+		var userNames []string
+		var ranks []uint64
+		for _, e := range resp.Entries {
+			userNames = append(userNames, e.Username)
+			ranks = append(ranks, e.Rank)
+		}
+
+		if fmt.Sprint(userNames) != v.userNames || fmt.Sprint(ranks) != v.ranks || resp.TotalItems != v.total {
+			t.Errorf("%s %s: Expected %v %v %v but got %v %v %v", nameTest, v.name, v.userNames, v.ranks, v.total, userNames, ranks, resp.TotalItems)
+			return
+		}
+
+		var own uint64
+		if resp.Own != nil {
+			own = resp.Own.Rank
+		}
+
+		if own != v.own {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.own, own)
+			return
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGetLastInAreaByDateRange(t *testing.T) {
+	nameTest := "TestGetLastInAreaByDateRange"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	ctx := context.Background()
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// usernamea left the area, the outlier of usernamec is skipped
+	records := []dto.CreateLocationHistoryRequest{
+		{UserName: "usernamea", Latitude: 10, Longitude: 10},
+		{UserName: "usernamea", Latitude: 50, Longitude: 50},
+		{UserName: "usernameb", Latitude: 10, Longitude: 10},
+		{UserName: "usernamec", Latitude: 10, Longitude: 10},
+		{UserName: "usernamec", Latitude: 50, Longitude: 50, ExcludedReason: histEnums.ExcludedOutlier},
+	}
+
+	for _, lh := range records {
+		if err = locationHistoryRepository.Create(ctx, lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	area := dto.BoundingBox{MinLatitude: 9, MinLongitude: 9, MaxLatitude: 11, MaxLongitude: 11}
+
+	type test struct {
+		name        string
+		initialDate time.Time
+		finalDate   time.Time
+		answer      string
+	}
+
+	tests := []test{
+		{"now", time.Now().Add(-time.Hour), time.Now().Add(time.Hour), "[usernameb usernamec]"},
+		{"before the records", time.Now().Add(-2 * time.Hour), time.Now().Add(-time.Hour), "[]"},
+	}
+
+	for _, v := range tests {
+		last, err := locationHistoryRepository.GetLastInAreaByDateRange(ctx, area, v.initialDate, v.finalDate)
+		if err != nil {
+			t.Errorf("%s %s: %v", nameTest, v.name, err)
+			return
+		}
+
+		userNames := []string{}
+		for _, l := range last {
+			userNames = append(userNames, l.UserName)
+		}
+
+		if fmt.Sprint(userNames) != v.answer {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, userNames)
+			return
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
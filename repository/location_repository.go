@@ -9,48 +9,69 @@ import (
 	"fmt"
 	"github.com/go-pg/pg/v10"
 	respKit "github.com/oboadagd/kit-go/middleware/responses"
-	"github.com/oboadagd/location-common/dto"
+	commonDto "github.com/oboadagd/location-common/dto"
 	"github.com/oboadagd/location-common/enums"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	histEnums "github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/sirupsen/logrus"
 	"time"
 )
 
 // LocationRepositoryInterface is the interface of Location repository layer.
 // Contains definition of methods to manage the database representation of
-// Location entity.
+// Location entity. Queries run under the caller's context bounded by the statement
+// timeout configured for each operation.
 type LocationRepositoryInterface interface {
 	Create(ctx context.Context, request dto.SaveLocationRequest) error
 	UpdateByUserName(ctx context.Context, request dto.SaveLocationRequest, userName string) error
 	ExistsByUserName(ctx context.Context, userName string) bool
-	GetByLatitudeLongitudeRange(ctx context.Context, request dto.GetByLatitudeLongitudeRangeRequest) (*dto.GetUsersByLocationAndRadiusResponse, error)
+	GetByUserName(ctx context.Context, userName string) (*dto.Location, error)
+	SetPrimaryDevice(ctx context.Context, userName string, deviceId string) error
+	GetByLatitudeLongitudeRange(ctx context.Context, request commonDto.GetByLatitudeLongitudeRangeRequest, updatedSince time.Time) (*dto.GetUsersByLocationAndRadiusResponse, error)
+	GetDivergences(ctx context.Context, userName string) ([]dto.LocationDivergence, error)
+	RebuildFromHistory(ctx context.Context, userName string) (int, error)
+	GetTenants(ctx context.Context) ([]string, error)
+	GetUserNames(ctx context.Context) ([]string, error)
 }
 
 // LocationRepository  represents the relational database repository layer of
 // Location entity. It's the registry of Location entity records. Exists a
-// unique record for each username. Username's Location registry is updated
-// each time geographic coordinates change.
+// unique record for each username of a tenant. Username's Location registry is updated
+// each time geographic coordinates change. Every query is scoped by the tenant
+// carried by the context.
 type LocationRepository struct {
-	Db *pg.DB // available database
+	Db  *pg.DB         // available database
+	log *logrus.Logger // structured logger
 }
 
 // NewLocationRepository initializes repository of Location entity.
-func NewLocationRepository(db *pg.DB) LocationRepositoryInterface {
+func NewLocationRepository(db *pg.DB, log *logrus.Logger) LocationRepositoryInterface {
 	return &LocationRepository{
 		db,
+		log,
 	}
 }
 
-// Create implements insert action of Location entity.
-func (r *LocationRepository) Create(_ context.Context, request dto.SaveLocationRequest) error {
+// Create implements insert action of Location entity. The reporting device becomes the
+// username's primary device.
+func (r *LocationRepository) Create(ctx context.Context, request dto.SaveLocationRequest) error {
+	ctx, cancel := queryContext(ctx, OpCreateLocation)
+	defer cancel()
 
 	l := dto.Location{
-		UserName:  request.UserName,
-		Latitude:  request.Latitude,
-		Longitude: request.Latitude,
-		UpdatedAt: time.Now(),
+		TenantId:           tenant.FromContext(ctx),
+		UserName:           request.UserName,
+		DeviceId:           request.DeviceId,
+		PrimaryDeviceId:    request.DeviceId,
+		Latitude:           request.Latitude,
+		Longitude:          request.Longitude,
+		HorizontalAccuracy: request.HorizontalAccuracy,
+		UpdatedAt:          time.Now(),
 	}
-	_, errIns := r.Db.Model(&l).Insert()
+	_, errIns := r.Db.ModelContext(ctx, &l).Insert()
 	if errIns != nil {
-		return respKit.GenericBadRequestError(enums.ErrorInsertLocationCode, errIns.Error())
+		return queryError(ctx, r.log, OpCreateLocation, enums.ErrorInsertLocationCode, errIns)
 	}
 
 	return nil
@@ -58,20 +79,35 @@ func (r *LocationRepository) Create(_ context.Context, request dto.SaveLocationR
 
 // UpdateByUserName implements update action of Location entity by username.
 // Returns username data not found if username doesn't exist.
-func (r *LocationRepository) UpdateByUserName(_ context.Context, request dto.SaveLocationRequest, userName string) error {
+func (r *LocationRepository) UpdateByUserName(ctx context.Context, request dto.SaveLocationRequest, userName string) error {
+	ctx, cancel := queryContext(ctx, OpUpdateLocationByUserName)
+	defer cancel()
+
 	var resp []dto.Location
-	err := r.Db.Model(&dto.Location{}).Where("userName = ?", userName).Select(&resp)
+	err := r.Db.ModelContext(ctx, &dto.Location{}).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("userName = ?", userName).
+		Select(&resp)
+
+	if err != nil && err != pg.ErrNoRows {
+		return queryError(ctx, r.log, OpUpdateLocationByUserName, enums.ErrorUpdateLocationCode, err)
+	}
 
 	if resp == nil || (err != nil && err == pg.ErrNoRows) {
 		return respKit.GenericNotFoundError(enums.ErrorUserNameNotFoundCode, fmt.Sprintf(enums.ErrorUserNameNotFoundMsg, userName))
 	}
 
+	resp[0].DeviceId = request.DeviceId
 	resp[0].Latitude = request.Latitude
 	resp[0].Longitude = request.Longitude
+	resp[0].HorizontalAccuracy = request.HorizontalAccuracy
 	resp[0].UpdatedAt = time.Now()
 
-	if _, err := r.Db.Model(&resp[0]).Where("userName = ?", userName).Update(); err != nil {
-		return respKit.GenericBadRequestError(enums.ErrorUpdateLocationCode, err.Error())
+	if _, err := r.Db.ModelContext(ctx, &resp[0]).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("userName = ?", userName).
+		Update(); err != nil {
+		return queryError(ctx, r.log, OpUpdateLocationByUserName, enums.ErrorUpdateLocationCode, err)
 	}
 
 	return nil
@@ -79,9 +115,15 @@ func (r *LocationRepository) UpdateByUserName(_ context.Context, request dto.Sav
 
 // ExistsByUserName implements exist action of Location entity by username.
 // Returns true if successful and false otherwise.
-func (r *LocationRepository) ExistsByUserName(_ context.Context, userName string) bool {
+func (r *LocationRepository) ExistsByUserName(ctx context.Context, userName string) bool {
+	ctx, cancel := queryContext(ctx, OpExistsByUserName)
+	defer cancel()
+
 	var resp []dto.Location
-	err := r.Db.Model(&dto.Location{}).Where("userName = ?", userName).Select(&resp)
+	err := r.Db.ModelContext(ctx, &dto.Location{}).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("userName = ?", userName).
+		Select(&resp)
 	if resp == nil || (err != nil && err == pg.ErrNoRows) {
 		return false
 	}
@@ -89,25 +131,190 @@ func (r *LocationRepository) ExistsByUserName(_ context.Context, userName string
 	return true
 }
 
+// GetByUserName implements query select action of Location entity by username.
+// Returns username data not found if username doesn't exist.
+func (r *LocationRepository) GetByUserName(ctx context.Context, userName string) (*dto.Location, error) {
+	ctx, cancel := queryContext(ctx, OpGetLocationByUserName)
+	defer cancel()
+
+	l := dto.Location{}
+	err := r.Db.ModelContext(ctx, &l).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("userName = ?", userName).
+		Select()
+
+	if err == pg.ErrNoRows {
+		return &dto.Location{}, respKit.GenericNotFoundError(enums.ErrorUserNameNotFoundCode, fmt.Sprintf(enums.ErrorUserNameNotFoundMsg, userName))
+	}
+
+	if err != nil {
+		return &dto.Location{}, queryError(ctx, r.log, OpGetLocationByUserName, enums.ErrorUpdateLocationCode, err)
+	}
+
+	return &l, nil
+}
+
+// SetPrimaryDevice implements update action of the primary device of Location entity
+// by username. Returns username data not found if username doesn't exist.
+func (r *LocationRepository) SetPrimaryDevice(ctx context.Context, userName string, deviceId string) error {
+	ctx, cancel := queryContext(ctx, OpSetPrimaryDevice)
+	defer cancel()
+
+	res, err := r.Db.ModelContext(ctx, &dto.Location{}).
+		Set("primary_device_id = ?", deviceId).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("userName = ?", userName).
+		Update()
+
+	if err != nil {
+		return queryError(ctx, r.log, OpSetPrimaryDevice, enums.ErrorUpdateLocationCode, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return respKit.GenericNotFoundError(enums.ErrorUserNameNotFoundCode, fmt.Sprintf(enums.ErrorUserNameNotFoundMsg, userName))
+	}
+
+	return nil
+}
+
 // GetByLatitudeLongitudeRange implements query select action of Location entity on
 // a square area. The square area is defined by the maximum and minimum latitude and
-// also by the maximum and minimum longitude.
-func (r *LocationRepository) GetByLatitudeLongitudeRange(_ context.Context, request dto.GetByLatitudeLongitudeRangeRequest) (*dto.GetUsersByLocationAndRadiusResponse, error) {
+// also by the maximum and minimum longitude. Locations updated before updatedSince are
+// skipped, unless it has empty value.
+func (r *LocationRepository) GetByLatitudeLongitudeRange(ctx context.Context, request commonDto.GetByLatitudeLongitudeRangeRequest, updatedSince time.Time) (*dto.GetUsersByLocationAndRadiusResponse, error) {
+	ctx, cancel := queryContext(ctx, OpGetByLatitudeLongitudeRange)
+	defer cancel()
+
 	var u []dto.Location
 	lr := dto.GetUsersByLocationAndRadiusResponse{}
 	l := dto.Location{}
-	err := r.Db.Model(&l).
+	q := r.Db.ModelContext(ctx, &l).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
 		Where("latitude >= ?", request.LatitudeMin).
 		Where("latitude <= ?", request.LatitudeMax).
 		Where("longitude >= ?", request.LongitudeMin).
-		Where("longitude <= ?", request.LongitudeMax).
-		Select(&u)
+		Where("longitude <= ?", request.LongitudeMax)
+
+	if !updatedSince.IsZero() {
+		q = q.Where("updated_at >= ?", updatedSince)
+	}
+
+	err := q.Select(&u)
 
 	lr.Users = u
 
 	if err != nil {
-		return &lr, respKit.GenericBadRequestError(enums.ErrorGetByLatitudeLongitudeRangeMsg, err.Error())
+		return &lr, queryError(ctx, r.log, OpGetByLatitudeLongitudeRange, enums.ErrorGetByLatitudeLongitudeRangeMsg, err)
 	}
 
 	return &lr, nil
 }
+
+// GetDivergences implements query select action of the Location entities of a username,
+// or of every username of the tenant if userName is empty, that diverge from their
+// LocationHistory records, ordered by username. A Location record diverges when its
+// coordinates differ from the latest LocationHistory record of its device that isn't an
+// outlier, or when its device has no such record. Usernames with such records and no
+// Location record diverge too.
+func (r *LocationRepository) GetDivergences(ctx context.Context, userName string) ([]dto.LocationDivergence, error) {
+	ctx, cancel := queryContext(ctx, OpGetLocationDivergences)
+	defer cancel()
+
+	var divergences []dto.LocationDivergence
+	_, err := r.Db.QueryContext(ctx, &divergences, `
+		WITH latest AS (
+			SELECT DISTINCT ON (username, device_id) username, device_id, latitude, longitude, updated_at
+			FROM location_history
+			WHERE tenant_id = ?0 AND (?2 = '' OR username = ?2) AND (excluded_reason IS NULL OR excluded_reason <> ?1)
+			ORDER BY username, device_id, updated_at DESC, id DESC
+		)
+		SELECT * FROM (
+			SELECT l.username, l.device_id, l.latitude, l.longitude,
+				h.latitude AS history_latitude, h.longitude AS history_longitude
+			FROM location AS l
+			LEFT JOIN latest AS h ON h.username = l.username AND h.device_id = l.device_id
+			WHERE l.tenant_id = ?0 AND (?2 = '' OR l.username = ?2)
+				AND (h.username IS NULL OR h.latitude <> l.latitude OR h.longitude <> l.longitude)
+			UNION ALL
+			(SELECT DISTINCT ON (h.username) h.username, h.device_id, NULL, NULL, h.latitude, h.longitude
+			FROM latest AS h
+			WHERE NOT EXISTS (SELECT 1 FROM location AS l WHERE l.tenant_id = ?0 AND l.username = h.username)
+			ORDER BY h.username, h.updated_at DESC)
+		) AS divergence
+		ORDER BY username`,
+		tenant.FromContext(ctx), histEnums.ExcludedOutlier, userName)
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetLocationDivergences, histEnums.ErrorCheckLocationsCode, err)
+	}
+
+	return divergences, nil
+}
+
+// RebuildFromHistory implements upsert action of the Location entity of a username, or of
+// every username of the tenant if userName is empty, from its latest LocationHistory
+// record that isn't an outlier. The device of that record reports the coordinates, and
+// becomes the primary device of created records. Returns the number of stored records.
+func (r *LocationRepository) RebuildFromHistory(ctx context.Context, userName string) (int, error) {
+	ctx, cancel := queryContext(ctx, OpRebuildLocations)
+	defer cancel()
+
+	res, err := r.Db.ExecContext(ctx, `
+		INSERT INTO location (tenant_id, username, device_id, primary_device_id, latitude, longitude, horizontal_accuracy, updated_at)
+		SELECT DISTINCT ON (username) tenant_id, username, device_id, device_id, latitude, longitude, horizontal_accuracy, updated_at
+		FROM location_history
+		WHERE tenant_id = ?0 AND (?1 = '' OR username = ?1) AND (excluded_reason IS NULL OR excluded_reason <> ?2)
+		ORDER BY username, updated_at DESC, id DESC
+		ON CONFLICT (tenant_id, username) DO UPDATE SET
+			device_id = EXCLUDED.device_id,
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			horizontal_accuracy = EXCLUDED.horizontal_accuracy,
+			updated_at = EXCLUDED.updated_at`,
+		tenant.FromContext(ctx), userName, histEnums.ExcludedOutlier)
+
+	if err != nil {
+		return 0, queryError(ctx, r.log, OpRebuildLocations, histEnums.ErrorRebuildLocationsCode, err)
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// GetTenants implements query select action of the tenants with Location entities,
+// ordered by tenant. It's the only query that isn't scoped by the tenant of the context.
+func (r *LocationRepository) GetTenants(ctx context.Context) ([]string, error) {
+	ctx, cancel := queryContext(ctx, OpGetLocationTenants)
+	defer cancel()
+
+	var tenants []string
+	err := r.Db.ModelContext(ctx, (*dto.Location)(nil)).
+		ColumnExpr("DISTINCT tenant_id").
+		Order("tenant_id").
+		Select(&tenants)
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetLocationTenants, histEnums.ErrorGetUserNamesCode, err)
+	}
+
+	return tenants, nil
+}
+
+// GetUserNames implements query select action of the usernames of the tenant with a
+// Location entity, ordered by username.
+func (r *LocationRepository) GetUserNames(ctx context.Context) ([]string, error) {
+	ctx, cancel := queryContext(ctx, OpGetLocationUserNames)
+	defer cancel()
+
+	var userNames []string
+	err := r.Db.ModelContext(ctx, (*dto.Location)(nil)).
+		Column("username").
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Order("username").
+		Select(&userNames)
+
+	if err != nil {
+		return nil, queryError(ctx, r.log, OpGetLocationUserNames, histEnums.ErrorGetUserNamesCode, err)
+	}
+
+	return userNames, nil
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"github.com/oboadagd/location-common/dto"
 	"github.com/oboadagd/location-common/enums"
+	histDto "github.com/oboadagd/location-history-mgmt/dto"
+	histEnums "github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
 	"testing"
+	"time"
 )
 import "github.com/oboadagd/location-history-mgmt/testutils"
 import "github.com/go-pg/pg/v10"
@@ -17,7 +21,7 @@ func TestCreateLocation(t *testing.T) {
 	db = testutils.GetTestDB()
 	defer db.Close()
 
-	locationRepository := NewLocationRepository(db)
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
 	ctx := context.Background()
 
 	err := testutils.CreateSchema(db)
@@ -49,7 +53,7 @@ func TestCreateLocation_ErrorInsertLocationCode(t *testing.T) {
 	db = testutils.GetTestDB()
 	defer db.Close()
 
-	locationRepository := NewLocationRepository(db)
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
 	ctx := context.Background()
 
 	err := testutils.CreateSchema(db)
@@ -89,7 +93,7 @@ func TestExistsByUserName_True(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.Background()
-	locationRepository := NewLocationRepository(db)
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
 
 	err := testutils.CreateSchema(db)
 	if err != nil {
@@ -130,7 +134,7 @@ func TestExistsByUserName_False(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.Background()
-	locationRepository := NewLocationRepository(db)
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
 
 	err := testutils.CreateSchema(db)
 	if err != nil {
@@ -170,7 +174,7 @@ func TestUpdateByUserName_ErrorUserNameNotFoundCode(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.Background()
-	locationRepository := NewLocationRepository(db)
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
 
 	err := testutils.CreateSchema(db)
 	if err != nil {
@@ -208,7 +212,7 @@ func TestUpdateByUserName_ErrorUpdateLocationCode(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.Background()
-	locationRepository := NewLocationRepository(db)
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
 
 	err := testutils.CreateSchema(db)
 	if err != nil {
@@ -226,7 +230,7 @@ func TestUpdateByUserName_ErrorUpdateLocationCode(t *testing.T) {
 	}
 
 	userName := l.UserName
-	l = &dto.SaveLocationRequest{}
+	l = &histDto.SaveLocationRequest{}
 	err = locationRepository.UpdateByUserName(ctx, *l, userName)
 	if err != nil && err.Error() == fmt.Sprintf(enums.ErrorUserNameNotFoundMsg, userName) {
 		t.Errorf("%s: Expected %v but got %v", nameTest, enums.ErrorUpdateLocationCode, err.Error())
@@ -248,7 +252,7 @@ func TestGetByLatitudeLongitudeRange(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.Background()
-	locationRepository := NewLocationRepository(db)
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
 
 	err := testutils.CreateSchema(db)
 	if err != nil {
@@ -272,9 +276,9 @@ func TestGetByLatitudeLongitudeRange(t *testing.T) {
 		LatitudeMin:  1,
 	}
 
-	var resp *dto.GetUsersByLocationAndRadiusResponse
+	var resp *histDto.GetUsersByLocationAndRadiusResponse
 
-	resp, err = locationRepository.GetByLatitudeLongitudeRange(ctx, llr)
+	resp, err = locationRepository.GetByLatitudeLongitudeRange(ctx, llr, time.Time{})
 
 	if err != nil {
 		t.Errorf("%s: %v", nameTest, err)
@@ -286,6 +290,407 @@ func TestGetByLatitudeLongitudeRange(t *testing.T) {
 		return
 	}
 
+	type test struct {
+		updatedSince time.Time
+		answer       int
+	}
+
+	tests := []test{
+		{time.Now().Add(-time.Hour), 1},
+		{time.Now().Add(time.Hour), 0},
+	}
+
+	for _, v := range tests {
+		resp, err = locationRepository.GetByLatitudeLongitudeRange(ctx, llr, v.updatedSince)
+		if err != nil || len(resp.Users) != v.answer {
+			t.Errorf("%s: Expected %v but got %v %v", nameTest, v.answer, len(resp.Users), err)
+			return
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestLocationTenantIsolation(t *testing.T) {
+	nameTest := "TestLocationTenantIsolation"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
+	ctxA := tenant.WithTenant(context.Background(), "tenanta")
+	ctxB := tenant.WithTenant(context.Background(), "tenantb")
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	l := testutils.GetLocation()
+
+	if err = locationRepository.Create(ctxA, *l); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if locationRepository.ExistsByUserName(ctxB, l.UserName) {
+		t.Errorf("%s: Expected %v but got %v", nameTest, false, true)
+		return
+	}
+
+	// the same username may exist in another tenant
+	if err = locationRepository.Create(ctxB, *l); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	moved := *l
+	moved.Latitude = 50
+	moved.Longitude = 50
+	if err = locationRepository.UpdateByUserName(ctxB, moved, l.UserName); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	llr := dto.GetByLatitudeLongitudeRangeRequest{
+		LatitudeMin:  1,
+		LatitudeMax:  20,
+		LongitudeMin: 1,
+		LongitudeMax: 20,
+	}
+
+	type test struct {
+		ctx    context.Context
+		answer int
+	}
+
+	tests := []test{
+		{ctxA, 1},
+		{ctxB, 0},
+	}
+
+	for _, v := range tests {
+		resp, err := locationRepository.GetByLatitudeLongitudeRange(v.ctx, llr, time.Time{})
+		if err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+
+		if len(resp.Users) != v.answer {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, len(resp.Users))
+			return
+		}
+
+		for _, u := range resp.Users {
+			if u.TenantId != tenant.FromContext(v.ctx) {
+				t.Errorf("%s: Expected %v but got %v", nameTest, tenant.FromContext(v.ctx), u.TenantId)
+				return
+			}
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestCreateLocation_StoredValues(t *testing.T) {
+	nameTest := "TestCreateLocation_StoredValues"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// distinct coordinates so a swapped column can't go unnoticed
+	l := testutils.GetLocation()
+	l.DeviceId = "devicesample"
+	l.Latitude = 40.4168
+	l.Longitude = -3.7038
+
+	if err = locationRepository.Create(ctx, *l); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	stored, err := locationRepository.GetByUserName(ctx, l.UserName)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	expected := histDto.Location{
+		TenantId:        tenant.FromContext(ctx),
+		UserName:        l.UserName,
+		DeviceId:        l.DeviceId,
+		PrimaryDeviceId: l.DeviceId,
+		Latitude:        l.Latitude,
+		Longitude:       l.Longitude,
+	}
+	if stored.TenantId != expected.TenantId || stored.UserName != expected.UserName || stored.DeviceId != expected.DeviceId ||
+		stored.PrimaryDeviceId != expected.PrimaryDeviceId || stored.Latitude != expected.Latitude ||
+		stored.Longitude != expected.Longitude || stored.UpdatedAt.IsZero() {
+		t.Errorf("%s: Expected %v but got %v", nameTest, expected, *stored)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestUpdateByUserName_StoredValues(t *testing.T) {
+	nameTest := "TestUpdateByUserName_StoredValues"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	l := testutils.GetLocation()
+	l.DeviceId = "devicesample"
+
+	if err = locationRepository.Create(ctx, *l); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	moved := *l
+	moved.DeviceId = "otherdevice"
+	moved.Latitude = -33.8688
+	moved.Longitude = 151.2093
+	if err = locationRepository.UpdateByUserName(ctx, moved, l.UserName); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	stored, err := locationRepository.GetByUserName(ctx, l.UserName)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// the primary device is kept by updates
+	if stored.DeviceId != moved.DeviceId || stored.PrimaryDeviceId != l.DeviceId ||
+		stored.Latitude != moved.Latitude || stored.Longitude != moved.Longitude {
+		t.Errorf("%s: Expected %v but got %v", nameTest, moved, *stored)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGetDivergencesAndRebuildFromHistory(t *testing.T) {
+	nameTest := "TestGetDivergencesAndRebuildFromHistory"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// consistent matches its history, wrongsample has a swapped longitude, nolocation
+	// has no Location record and nohistory has no LocationHistory record
+	history := []histDto.CreateLocationHistoryRequest{
+		{UserName: "consistent", Latitude: 10, Longitude: 20},
+		{UserName: "wrongsample", Latitude: 30, Longitude: 40},
+		{UserName: "nolocation", Latitude: 50, Longitude: 60},
+		{UserName: "nolocation", Latitude: 51, Longitude: 61},
+		{UserName: "nolocation", Latitude: 89, Longitude: 179, ExcludedReason: histEnums.ExcludedOutlier},
+	}
+	for _, lh := range history {
+		if err = locationHistoryRepository.Create(ctx, lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	locations := []histDto.SaveLocationRequest{
+		{UserName: "consistent", Latitude: 10, Longitude: 20},
+		{UserName: "wrongsample", Latitude: 30, Longitude: 30},
+		{UserName: "nohistory", Latitude: 70, Longitude: 80},
+	}
+	for _, l := range locations {
+		if err = locationRepository.Create(ctx, l); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	divergences, err := locationRepository.GetDivergences(ctx, "")
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	type test struct {
+		userName         string
+		location         bool
+		historyLatitude  float64
+		historyLongitude float64
+		history          bool
+	}
+
+	tests := []test{
+		{"nohistory", true, 0, 0, false},
+		{"nolocation", false, 51, 61, true},
+		{"wrongsample", true, 30, 40, true},
+	}
+
+	if len(divergences) != len(tests) {
+		t.Errorf("%s: Expected %v but got %v", nameTest, len(tests), len(divergences))
+		return
+	}
+
+	for i, v := range tests {
+		d := divergences[i]
+		if d.UserName != v.userName || (d.Latitude != nil) != v.location || (d.HistoryLatitude != nil) != v.history {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.userName, v, d)
+			return
+		}
+
+		if v.history && (*d.HistoryLatitude != v.historyLatitude || *d.HistoryLongitude != v.historyLongitude) {
+			t.Errorf("%s %s: Expected %v %v but got %v %v", nameTest, v.userName, v.historyLatitude, v.historyLongitude,
+				*d.HistoryLatitude, *d.HistoryLongitude)
+			return
+		}
+	}
+
+	n, err := locationRepository.RebuildFromHistory(ctx, "")
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if n != 3 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 3, n)
+		return
+	}
+
+	for _, v := range []histDto.SaveLocationRequest{
+		{UserName: "consistent", Latitude: 10, Longitude: 20},
+		{UserName: "wrongsample", Latitude: 30, Longitude: 40},
+		{UserName: "nolocation", Latitude: 51, Longitude: 61},
+		{UserName: "nohistory", Latitude: 70, Longitude: 80},
+	} {
+		stored, err := locationRepository.GetByUserName(ctx, v.UserName)
+		if err != nil {
+			t.Errorf("%s %s: %v", nameTest, v.UserName, err)
+			return
+		}
+
+		if stored.Latitude != v.Latitude || stored.Longitude != v.Longitude {
+			t.Errorf("%s %s: Expected %v %v but got %v %v", nameTest, v.UserName, v.Latitude, v.Longitude,
+				stored.Latitude, stored.Longitude)
+			return
+		}
+	}
+
+	// only locations without history diverge after the rebuild
+	divergences, err = locationRepository.GetDivergences(ctx, "")
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if len(divergences) != 1 || divergences[0].UserName != "nohistory" {
+		t.Errorf("%s: Expected %v but got %v", nameTest, "nohistory", divergences)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGetDivergencesOfUserNameWithoutLocation(t *testing.T) {
+	nameTest := "TestGetDivergencesOfUserNameWithoutLocation"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	locationRepository := NewLocationRepository(db, testutils.GetLogger())
+	locationHistoryRepository := NewLocationHistoryRepository(db, testutils.GetLogger())
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// nolocation reports from two devices, the latest record is reported by tablet
+	history := []histDto.CreateLocationHistoryRequest{
+		{UserName: "nolocation", DeviceId: "phone", Latitude: 50, Longitude: 60},
+		{UserName: "nolocation", DeviceId: "tablet", Latitude: 51, Longitude: 61},
+		{UserName: "otherusername", DeviceId: "phone", Latitude: 10, Longitude: 20},
+	}
+	for _, lh := range history {
+		if err = locationHistoryRepository.Create(ctx, lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	divergences, err := locationRepository.GetDivergences(ctx, "nolocation")
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if len(divergences) != 1 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 1, len(divergences))
+		return
+	}
+
+	d := divergences[0]
+	if d.UserName != "nolocation" || d.DeviceId != "tablet" || d.Latitude != nil || d.HistoryLatitude == nil ||
+		*d.HistoryLatitude != 51 || *d.HistoryLongitude != 61 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, history[1], d)
+		return
+	}
+
 	err = testutils.DropSchema(db)
 	if err != nil {
 		t.Errorf("%s: %v", nameTest, err)
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-pg/pg/v10"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/logger"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// Repository operation names. They are the keys of config.Cfg.DBOperationTimeouts.
+const (
+	OpCreateLocation                    = "CreateLocation"
+	OpUpdateLocationByUserName          = "UpdateByUserName"
+	OpExistsByUserName                  = "ExistsByUserName"
+	OpGetLocationByUserName             = "GetLocationByUserName"
+	OpSetPrimaryDevice                  = "SetPrimaryDevice"
+	OpGetByLatitudeLongitudeRange       = "GetByLatitudeLongitudeRange"
+	OpGetLocationDivergences            = "GetLocationDivergences"
+	OpRebuildLocations                  = "RebuildLocations"
+	OpGetLocationTenants                = "GetLocationTenants"
+	OpGetLocationUserNames              = "GetLocationUserNames"
+	OpCreateLocationHistory             = "CreateLocationHistory"
+	OpGetDistanceByUserNameAndDateRange = "GetDistanceByUserNameAndDateRange"
+	OpGetDistanceByBuckets              = "GetDistanceByBuckets"
+	OpGetLastByUserName                 = "GetLastByUserName"
+	OpGetHistoryByUserNameAndDateRange  = "GetHistoryByUserNameAndDateRange"
+	OpCountOutliersByUserName           = "CountOutliersByUserName"
+	OpGetLocationFilterState            = "GetLocationFilterState"
+	OpSaveLocationFilterState           = "SaveLocationFilterState"
+	OpGetUserDevices                    = "GetUserDevices"
+	OpGetHistoryPageByUserNameAndDevice = "GetHistoryPageByUserNameAndDevice"
+	OpRebuildDistanceRollups            = "RebuildDistanceRollups"
+	OpGetLeaderboard                    = "GetLeaderboard"
+	OpGetLastInAreaByDateRange          = "GetLastInAreaByDateRange"
+	OpGetTrackByUserNameAndDateRange    = "GetTrackByUserNameAndDateRange"
+	OpGetTracksInAreaByDateRange        = "GetTracksInAreaByDateRange"
+	OpGetOpenTrip                       = "GetOpenTrip"
+	OpGetTrip                           = "GetTrip"
+	OpListTrips                         = "ListTrips"
+	OpSaveTrip                          = "SaveTrip"
+	OpDeleteTrips                       = "DeleteTrips"
+	OpGetCurrentStay                    = "GetCurrentStay"
+	OpListStays                         = "ListStays"
+	OpGetStaysByUserNameAndDateRange    = "GetStaysByUserNameAndDateRange"
+	OpSaveStay                          = "SaveStay"
+	OpDeleteStays                       = "DeleteStays"
+	OpGetCurrentEncounters              = "GetCurrentEncounters"
+	OpListEncounters                    = "ListEncounters"
+	OpSaveEncounter                     = "SaveEncounter"
+	OpCreateApiKey                      = "CreateApiKey"
+	OpGetApiKeyByHash                   = "GetApiKeyByHash"
+	OpUpdateHistoryDistances            = "UpdateHistoryDistances"
+	OpGetJobCheckpoint                  = "GetJobCheckpoint"
+	OpSaveJobCheckpoint                 = "SaveJobCheckpoint"
+	OpDeleteJobCheckpoint               = "DeleteJobCheckpoint"
+	OpGetPrunableDays                   = "GetPrunableDays"
+	OpPruneHistoryDay                   = "PruneHistoryDay"
+	OpGetHistoryHorizon                 = "GetHistoryHorizon"
+	OpCreateHistoryPartitions           = "CreateHistoryPartitions"
+	OpGetHistoryPartitions              = "GetHistoryPartitions"
+	OpDropHistoryPartition              = "DropHistoryPartition"
+)
+
+// StatusClientClosedRequest is the non-standard http status returned when the caller
+// cancelled the request before the query finished.
+const StatusClientClosedRequest = 499
+
+// pgQueryCanceledCode is the postgres SQLSTATE raised when a statement is cancelled,
+// either by a cancel request or by statement_timeout.
+const pgQueryCanceledCode = "57014"
+
+// queryContext returns a copy of ctx bounded by the statement timeout configured for
+// the given operation. The caller must invoke the returned cancel function once the
+// query finishes.
+func queryContext(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	timeout, ok := config.Cfg.DBOperationTimeouts[operation]
+	if !ok {
+		timeout = config.Cfg.DBStatementTimeout
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// queryError builds the error returned by a failed query. Queries aborted because the
+// caller cancelled the context or because the deadline expired are reported with
+// ErrorQueryCanceledCode and ErrorQueryTimeoutCode respectively, any other failure is
+// reported as a bad request with the given code. The failure is logged with l.
+func queryError(ctx context.Context, l *logrus.Logger, operation string, code string, err error) error {
+	logger.FromContext(ctx, l).WithError(err).WithField("operation", operation).Warn("query failed")
+
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		return respKit.NewGenericHttpError(StatusClientClosedRequest, enums.ErrorQueryCanceledCode,
+			fmt.Errorf(enums.ErrorQueryCanceledMsg, operation, err))
+	case errors.Is(ctx.Err(), context.DeadlineExceeded) || isPgQueryCanceled(err):
+		return respKit.NewGenericHttpError(http.StatusGatewayTimeout, enums.ErrorQueryTimeoutCode,
+			fmt.Errorf(enums.ErrorQueryTimeoutMsg, operation, err))
+	}
+
+	return respKit.GenericBadRequestError(code, err.Error())
+}
+
+// isPgQueryCanceled returns true if err is a postgres query_canceled error.
+func isPgQueryCanceled(err error) bool {
+	var pgErr pg.Error
+	if errors.As(err, &pgErr) {
+		return pgErr.Field('C') == pgQueryCanceledCode
+	}
+
+	return false
+}
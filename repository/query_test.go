@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-common/enums"
+	"github.com/oboadagd/location-history-mgmt/config"
+	histEnums "github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/testutils"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestQueryContext(t *testing.T) {
+	nameTest := "TestQueryContext"
+	defer func(d time.Duration, o map[string]time.Duration) {
+		config.Cfg.DBStatementTimeout, config.Cfg.DBOperationTimeouts = d, o
+	}(config.Cfg.DBStatementTimeout, config.Cfg.DBOperationTimeouts)
+
+	config.Cfg.DBStatementTimeout = time.Second
+	config.Cfg.DBOperationTimeouts = map[string]time.Duration{OpGetLastByUserName: time.Minute}
+
+	type test struct {
+		operation string
+		answer    time.Duration
+	}
+
+	tests := []test{
+		{OpCreateLocation, time.Second},
+		{OpGetLastByUserName, time.Minute},
+	}
+
+	for _, v := range tests {
+		ctx, cancel := queryContext(context.Background(), v.operation)
+		deadline, ok := ctx.Deadline()
+		cancel()
+
+		if !ok || time.Until(deadline) > v.answer || time.Until(deadline) < v.answer-time.Second {
+			t.Errorf("%s: Expected deadline in %v but got %v", nameTest, v.answer, time.Until(deadline))
+			return
+		}
+	}
+
+	config.Cfg.DBStatementTimeout = 0
+	ctx, cancel := queryContext(context.Background(), OpCreateLocation)
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Errorf("%s: Expected no deadline", nameTest)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestQueryError(t *testing.T) {
+	nameTest := "TestQueryError"
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancelExpired := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelExpired()
+
+	type test struct {
+		ctx    context.Context
+		status int
+		answer string
+	}
+
+	tests := []test{
+		{canceled, StatusClientClosedRequest, histEnums.ErrorQueryCanceledCode},
+		{expired, http.StatusGatewayTimeout, histEnums.ErrorQueryTimeoutCode},
+		{context.Background(), http.StatusBadRequest, enums.ErrorInsertLocationCode},
+	}
+
+	for _, v := range tests {
+		err := queryError(v.ctx, testutils.GetLogger(), OpCreateLocation, enums.ErrorInsertLocationCode, errors.New("query failed"))
+
+		var httpErr *respKit.GenericHttpError
+		if !errors.As(err, &httpErr) || httpErr.ErrorCode != v.answer || httpErr.Status != v.status {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, err)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
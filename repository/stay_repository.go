@@ -0,0 +1,181 @@
+// Package repository implements facade to relational database.
+// Through implementation of the StayRepositoryInterface methods,
+// it is possible to define the necessary updates and fetches
+// to manage Stay entity model.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// StayRepositoryInterface is the interface of Stay repository layer. Contains definition
+// of methods to manage the database representation of Stay entity.
+type StayRepositoryInterface interface {
+	GetCurrentByUserNameAndDevice(ctx context.Context, userName string, deviceId string) (*dto.Stay, error)
+	ListByUserNameAndDateRange(ctx context.Context, request dto.ListStaysRequest) (*dto.ListStaysResponse, error)
+	GetByUserNameAndDateRange(ctx context.Context, userName string, deviceId string, initialDate time.Time, finalDate time.Time) ([]dto.Stay, error)
+	Save(ctx context.Context, stay *dto.Stay) error
+	DeleteByUserNameAndDevice(ctx context.Context, userName string, deviceId string) error
+}
+
+// StayRepository represents the relational database repository layer of Stay entity.
+// Exists at most one candidate or open record for each device of a username of a
+// tenant. Every query is scoped by the tenant carried by the context.
+type StayRepository struct {
+	db  *pg.DB         // available database
+	log *logrus.Logger // structured logger
+}
+
+// NewStayRepository initializes repository of Stay entity.
+func NewStayRepository(db *pg.DB, log *logrus.Logger) StayRepositoryInterface {
+	return &StayRepository{
+		db,
+		log,
+	}
+}
+
+// GetCurrentByUserNameAndDevice implements query select action of the candidate or open
+// Stay entity of a device of a username. Returns stay not found if the device has none.
+func (r *StayRepository) GetCurrentByUserNameAndDevice(ctx context.Context, userName string, deviceId string) (*dto.Stay, error) {
+	ctx, cancel := queryContext(ctx, OpGetCurrentStay)
+	defer cancel()
+
+	var s dto.Stay
+	err := r.db.ModelContext(ctx, &s).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", userName).
+		Where("device_id = ?", deviceId).
+		Where("status <> ?", enums.StayClosed).
+		Select()
+
+	if err == pg.ErrNoRows {
+		return &dto.Stay{}, respKit.GenericNotFoundError(enums.ErrorStayNotFoundCode, fmt.Sprintf(enums.ErrorStayNotFoundMsg, userName, deviceId))
+	}
+
+	if err != nil {
+		return &dto.Stay{}, queryError(ctx, r.log, OpGetCurrentStay, enums.ErrorGetStayCode, err)
+	}
+
+	return &s, nil
+}
+
+// ListByUserNameAndDateRange implements query select action of Stay entity by username,
+// optionally by device, and date range. Returns the requested page of open and closed
+// stays that overlap the range ordered by arrival date. Returns an empty page in case
+// username has no stays in the range.
+func (r *StayRepository) ListByUserNameAndDateRange(ctx context.Context, request dto.ListStaysRequest) (*dto.ListStaysResponse, error) {
+	ctx, cancel := queryContext(ctx, OpListStays)
+	defer cancel()
+
+	var stays []dto.Stay
+	q := r.db.ModelContext(ctx, &stays)
+	q = staysInRange(q, tenant.FromContext(ctx), request.UserName, request.DeviceId, request.InitialDate, request.FinalDate)
+
+	count, err := q.Order("arrived_at", "id").
+		Offset(int((request.Page - 1) * request.ItemsLimit)).
+		Limit(int(request.ItemsLimit)).
+		SelectAndCount()
+
+	if err != nil {
+		return &dto.ListStaysResponse{}, queryError(ctx, r.log, OpListStays, enums.ErrorListStaysCode, err)
+	}
+
+	totalItems := uint64(count)
+	totalPages := totalItems / request.ItemsLimit
+	if totalItems%request.ItemsLimit != 0 {
+		totalPages++
+	}
+
+	return &dto.ListStaysResponse{
+		Stays:      stays,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// GetByUserNameAndDateRange implements query select action of every open and closed Stay
+// entity of a username, optionally of a device, that overlaps a date range ordered by
+// arrival date.
+func (r *StayRepository) GetByUserNameAndDateRange(ctx context.Context, userName string, deviceId string, initialDate time.Time, finalDate time.Time) ([]dto.Stay, error) {
+	ctx, cancel := queryContext(ctx, OpGetStaysByUserNameAndDateRange)
+	defer cancel()
+
+	var stays []dto.Stay
+	q := r.db.ModelContext(ctx, &stays)
+	q = staysInRange(q, tenant.FromContext(ctx), userName, deviceId, initialDate, finalDate)
+
+	if err := q.Order("arrived_at", "id").Select(); err != nil {
+		return nil, queryError(ctx, r.log, OpGetStaysByUserNameAndDateRange, enums.ErrorListStaysCode, err)
+	}
+
+	return stays, nil
+}
+
+// Save implements insert action of Stay entity when stay has no identifier, and update
+// action by identifier otherwise. The identifier of inserted stays is set in stay.
+func (r *StayRepository) Save(ctx context.Context, stay *dto.Stay) error {
+	ctx, cancel := queryContext(ctx, OpSaveStay)
+	defer cancel()
+
+	stay.TenantId = tenant.FromContext(ctx)
+
+	var err error
+	if stay.Id == 0 {
+		_, err = r.db.ModelContext(ctx, stay).Insert()
+	} else {
+		_, err = r.db.ModelContext(ctx, stay).
+			WherePK().
+			Where("tenant_id = ?", stay.TenantId).
+			Update()
+	}
+
+	if err != nil {
+		return queryError(ctx, r.log, OpSaveStay, enums.ErrorSaveStayCode, err)
+	}
+
+	return nil
+}
+
+// DeleteByUserNameAndDevice implements delete action of every Stay entity of a device of
+// a username.
+func (r *StayRepository) DeleteByUserNameAndDevice(ctx context.Context, userName string, deviceId string) error {
+	ctx, cancel := queryContext(ctx, OpDeleteStays)
+	defer cancel()
+
+	_, err := r.db.ModelContext(ctx, (*dto.Stay)(nil)).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", userName).
+		Where("device_id = ?", deviceId).
+		Delete()
+
+	if err != nil {
+		return queryError(ctx, r.log, OpDeleteStays, enums.ErrorDeleteStaysCode, err)
+	}
+
+	return nil
+}
+
+// staysInRange filters q by the open and closed stays of a username of tenantId,
+// optionally of a device, that overlap a date range.
+func staysInRange(q *orm.Query, tenantId string, userName string, deviceId string, initialDate time.Time, finalDate time.Time) *orm.Query {
+	q = q.Where("tenant_id = ?", tenantId).
+		Where("username = ?", userName).
+		Where("status <> ?", enums.StayCandidate).
+		Where("arrived_at <= ?", finalDate).
+		Where("departed_at >= ?", initialDate)
+
+	if deviceId != "" {
+		q = q.Where("device_id = ?", deviceId)
+	}
+
+	return q
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/testutils"
+	"testing"
+	"time"
+)
+
+func TestStayRepository(t *testing.T) {
+	nameTest := "TestStayRepository"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	stayRepository := NewStayRepository(db, testutils.GetLogger())
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	start := time.Now().Add(-3 * time.Hour)
+	stays := []*dto.Stay{
+		{UserName: "usernamesample", DeviceId: "phone", Status: enums.StayClosed, ArrivedAt: start, DepartedAt: start.Add(time.Hour)},
+		{UserName: "usernamesample", DeviceId: "phone", Status: enums.StayCandidate, ArrivedAt: start.Add(2 * time.Hour), DepartedAt: start.Add(2 * time.Hour)},
+		{UserName: "usernamesample", DeviceId: "watch", Status: enums.StayOpen, ArrivedAt: start.Add(time.Hour), DepartedAt: start.Add(2 * time.Hour)},
+	}
+
+	for _, st := range stays {
+		if err = stayRepository.Save(ctx, st); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	current, err := stayRepository.GetCurrentByUserNameAndDevice(ctx, "usernamesample", "phone")
+	if err != nil || current.Id != stays[1].Id {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, stays[1].Id, current.Id, err)
+		return
+	}
+
+	// the candidate becomes an open stay in place
+	current.Status = enums.StayOpen
+	current.PointCount = 2
+	if err = stayRepository.Save(ctx, current); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	type test struct {
+		deviceId    string
+		initialDate time.Time
+		answer      int
+	}
+
+	tests := []test{
+		{"", start.Add(-time.Hour), 3},
+		{"phone", start.Add(-time.Hour), 2},
+		{"", start.Add(90 * time.Minute), 2},
+	}
+
+	for _, v := range tests {
+		resp, err := stayRepository.GetByUserNameAndDateRange(ctx, "usernamesample", v.deviceId, v.initialDate, time.Now())
+		if err != nil || len(resp) != v.answer {
+			t.Errorf("%s: Expected %v but got %v %v", nameTest, v.answer, len(resp), err)
+			return
+		}
+
+		req := dto.ListStaysRequest{
+			UserName:    "usernamesample",
+			DeviceId:    v.deviceId,
+			InitialDate: v.initialDate,
+			FinalDate:   time.Now(),
+			Page:        1,
+			ItemsLimit:  1,
+		}
+
+		page, err := stayRepository.ListByUserNameAndDateRange(ctx, req)
+		if err != nil || page.TotalItems != uint64(v.answer) || len(page.Stays) != 1 {
+			t.Errorf("%s: Expected %v but got %v %v", nameTest, v.answer, page.TotalItems, err)
+			return
+		}
+	}
+
+	if err = stayRepository.DeleteByUserNameAndDevice(ctx, "usernamesample", "phone"); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if _, err = stayRepository.GetCurrentByUserNameAndDevice(ctx, "usernamesample", "phone"); err == nil {
+		t.Errorf("%s: Expected %v but got %v", nameTest, enums.ErrorStayNotFoundCode, err)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,176 @@
+// Package repository implements facade to relational database.
+// Through implementation of the TripRepositoryInterface methods,
+// it is possible to define the necessary updates and fetches
+// to manage Trip entity model.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-pg/pg/v10"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/sirupsen/logrus"
+)
+
+// TripRepositoryInterface is the interface of Trip repository layer. Contains definition
+// of methods to manage the database representation of Trip entity.
+type TripRepositoryInterface interface {
+	GetOpenByUserNameAndDevice(ctx context.Context, userName string, deviceId string) (*dto.Trip, error)
+	GetByUserNameAndId(ctx context.Context, userName string, id int64) (*dto.Trip, error)
+	ListByUserNameAndDateRange(ctx context.Context, request dto.ListTripsRequest) (*dto.ListTripsResponse, error)
+	Save(ctx context.Context, trip *dto.Trip) error
+	DeleteByUserNameAndDevice(ctx context.Context, userName string, deviceId string) error
+}
+
+// TripRepository represents the relational database repository layer of Trip entity.
+// Exists at most one open record for each device of a username of a tenant. Every query
+// is scoped by the tenant carried by the context.
+type TripRepository struct {
+	db  *pg.DB         // available database
+	log *logrus.Logger // structured logger
+}
+
+// NewTripRepository initializes repository of Trip entity.
+func NewTripRepository(db *pg.DB, log *logrus.Logger) TripRepositoryInterface {
+	return &TripRepository{
+		db,
+		log,
+	}
+}
+
+// GetOpenByUserNameAndDevice implements query select action of the open Trip entity of
+// a device of a username. Returns trip not found if the device has no open trip.
+func (r *TripRepository) GetOpenByUserNameAndDevice(ctx context.Context, userName string, deviceId string) (*dto.Trip, error) {
+	ctx, cancel := queryContext(ctx, OpGetOpenTrip)
+	defer cancel()
+
+	var t dto.Trip
+	err := r.db.ModelContext(ctx, &t).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", userName).
+		Where("device_id = ?", deviceId).
+		Where("status = ?", enums.TripOpen).
+		Select()
+
+	if err == pg.ErrNoRows {
+		return &dto.Trip{}, respKit.GenericNotFoundError(enums.ErrorTripNotFoundCode, fmt.Sprintf(enums.ErrorTripNotFoundMsg, userName, 0))
+	}
+
+	if err != nil {
+		return &dto.Trip{}, queryError(ctx, r.log, OpGetOpenTrip, enums.ErrorGetTripCode, err)
+	}
+
+	return &t, nil
+}
+
+// GetByUserNameAndId implements query select action of Trip entity by username and
+// identifier. Returns trip not found if the username has no such trip.
+func (r *TripRepository) GetByUserNameAndId(ctx context.Context, userName string, id int64) (*dto.Trip, error) {
+	ctx, cancel := queryContext(ctx, OpGetTrip)
+	defer cancel()
+
+	var t dto.Trip
+	err := r.db.ModelContext(ctx, &t).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", userName).
+		Where("id = ?", id).
+		Select()
+
+	if err == pg.ErrNoRows {
+		return &dto.Trip{}, respKit.GenericNotFoundError(enums.ErrorTripNotFoundCode, fmt.Sprintf(enums.ErrorTripNotFoundMsg, userName, id))
+	}
+
+	if err != nil {
+		return &dto.Trip{}, queryError(ctx, r.log, OpGetTrip, enums.ErrorGetTripCode, err)
+	}
+
+	return &t, nil
+}
+
+// ListByUserNameAndDateRange implements query select action of Trip entity by username,
+// optionally by device, and date range. Returns the requested page of trips that overlap
+// the range ordered by start date. Returns an empty page in case username has no trips
+// in the range.
+func (r *TripRepository) ListByUserNameAndDateRange(ctx context.Context, request dto.ListTripsRequest) (*dto.ListTripsResponse, error) {
+	ctx, cancel := queryContext(ctx, OpListTrips)
+	defer cancel()
+
+	var trips []dto.Trip
+	q := r.db.ModelContext(ctx, &trips).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", request.UserName).
+		Where("started_at <= ?", request.FinalDate).
+		Where("ended_at >= ?", request.InitialDate)
+
+	if request.DeviceId != "" {
+		q = q.Where("device_id = ?", request.DeviceId)
+	}
+
+	count, err := q.Order("started_at", "id").
+		Offset(int((request.Page - 1) * request.ItemsLimit)).
+		Limit(int(request.ItemsLimit)).
+		SelectAndCount()
+
+	if err != nil {
+		return &dto.ListTripsResponse{}, queryError(ctx, r.log, OpListTrips, enums.ErrorListTripsCode, err)
+	}
+
+	totalItems := uint64(count)
+	totalPages := totalItems / request.ItemsLimit
+	if totalItems%request.ItemsLimit != 0 {
+		totalPages++
+	}
+
+	return &dto.ListTripsResponse{
+		Trips:      trips,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Save implements insert action of Trip entity when trip has no identifier, and update
+// action by identifier otherwise. The identifier of inserted trips is set in trip.
+func (r *TripRepository) Save(ctx context.Context, trip *dto.Trip) error {
+	ctx, cancel := queryContext(ctx, OpSaveTrip)
+	defer cancel()
+
+	trip.TenantId = tenant.FromContext(ctx)
+
+	var err error
+	if trip.Id == 0 {
+		_, err = r.db.ModelContext(ctx, trip).Insert()
+	} else {
+		_, err = r.db.ModelContext(ctx, trip).
+			WherePK().
+			Where("tenant_id = ?", trip.TenantId).
+			Update()
+	}
+
+	if err != nil {
+		return queryError(ctx, r.log, OpSaveTrip, enums.ErrorSaveTripCode, err)
+	}
+
+	return nil
+}
+
+// DeleteByUserNameAndDevice implements delete action of every Trip entity of a device of
+// a username.
+func (r *TripRepository) DeleteByUserNameAndDevice(ctx context.Context, userName string, deviceId string) error {
+	ctx, cancel := queryContext(ctx, OpDeleteTrips)
+	defer cancel()
+
+	_, err := r.db.ModelContext(ctx, (*dto.Trip)(nil)).
+		Where("tenant_id = ?", tenant.FromContext(ctx)).
+		Where("username = ?", userName).
+		Where("device_id = ?", deviceId).
+		Delete()
+
+	if err != nil {
+		return queryError(ctx, r.log, OpDeleteTrips, enums.ErrorDeleteTripsCode, err)
+	}
+
+	return nil
+}
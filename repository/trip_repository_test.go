@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/testutils"
+	"testing"
+	"time"
+)
+
+func TestTripRepository(t *testing.T) {
+	nameTest := "TestTripRepository"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	tripRepository := NewTripRepository(db, testutils.GetLogger())
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	start := time.Now().Add(-2 * time.Hour)
+	trips := []*dto.Trip{
+		{UserName: "usernamesample", DeviceId: "phone", Status: enums.TripClosed, StartedAt: start, EndedAt: start.Add(20 * time.Minute), LastPointAt: start.Add(20 * time.Minute)},
+		{UserName: "usernamesample", DeviceId: "phone", Status: enums.TripOpen, StartedAt: start.Add(time.Hour), EndedAt: start.Add(time.Hour), LastPointAt: start.Add(time.Hour)},
+		{UserName: "usernamesample", DeviceId: "watch", Status: enums.TripClosed, StartedAt: start, EndedAt: start.Add(10 * time.Minute), LastPointAt: start.Add(10 * time.Minute)},
+	}
+
+	for _, trip := range trips {
+		if err = tripRepository.Save(ctx, trip); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	open, err := tripRepository.GetOpenByUserNameAndDevice(ctx, "usernamesample", "phone")
+	if err != nil || open.Id != trips[1].Id {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, trips[1].Id, open.Id, err)
+		return
+	}
+
+	// extending the open trip updates it in place
+	open.Distance = 3
+	open.EndedAt = start.Add(90 * time.Minute)
+	if err = tripRepository.Save(ctx, open); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	got, err := tripRepository.GetByUserNameAndId(ctx, "usernamesample", open.Id)
+	if err != nil || got.Distance != 3 {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, 3, got.Distance, err)
+		return
+	}
+
+	if _, err = tripRepository.GetOpenByUserNameAndDevice(ctx, "usernamesample", "watch"); err == nil {
+		t.Errorf("%s: Expected %v but got %v", nameTest, enums.ErrorTripNotFoundCode, err)
+		return
+	}
+
+	type test struct {
+		deviceId    string
+		initialDate time.Time
+		answer      uint64
+	}
+
+	tests := []test{
+		{"", start.Add(-time.Hour), 3},
+		{"phone", start.Add(-time.Hour), 2},
+		{"", start.Add(30 * time.Minute), 1},
+	}
+
+	for _, v := range tests {
+		req := dto.ListTripsRequest{
+			UserName:    "usernamesample",
+			DeviceId:    v.deviceId,
+			InitialDate: v.initialDate,
+			FinalDate:   time.Now(),
+			Page:        1,
+			ItemsLimit:  10,
+		}
+
+		resp, err := tripRepository.ListByUserNameAndDateRange(ctx, req)
+		if err != nil || resp.TotalItems != v.answer {
+			t.Errorf("%s: Expected %v but got %v %v", nameTest, v.answer, resp.TotalItems, err)
+			return
+		}
+	}
+
+	if err = tripRepository.DeleteByUserNameAndDevice(ctx, "usernamesample", "phone"); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if _, err = tripRepository.GetByUserNameAndId(ctx, "usernamesample", trips[0].Id); err == nil {
+		t.Errorf("%s: Expected %v but got %v", nameTest, enums.ErrorTripNotFoundCode, err)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -12,6 +12,7 @@ type Router struct {
 	server             *echo.Echo                                // *echo.Echo that has embedded a http server
 	locationController controller.LocationControllerInterface    // controller layer
 	errorMiddleware    middleKit.ErrorHandlerMiddlewareInterface // error handle middleware
+	middlewares        []echo.MiddlewareFunc                     // authentication and rate limiting middlewares, applied in order after the error handler
 }
 
 // NewRouter initializes router layer
@@ -19,11 +20,13 @@ func NewRouter(
 	server *echo.Echo,
 	locationController controller.LocationControllerInterface,
 	errorMiddleware middleKit.ErrorHandlerMiddlewareInterface,
+	middlewares ...echo.MiddlewareFunc,
 ) *Router {
 	return &Router{
 		server,
 		locationController,
 		errorMiddleware,
+		middlewares,
 	}
 }
 
@@ -32,9 +35,36 @@ func (r *Router) Init() {
 
 	basePath := r.server.Group("/location-history-mgmt")
 
-	locations := basePath.Group("/locations", r.errorMiddleware.HandlerError)
+	middlewares := append([]echo.MiddlewareFunc{r.errorMiddleware.HandlerError}, r.middlewares...)
+
+	locations := basePath.Group("/locations", middlewares...)
 	{
 		locations.GET("/distance/:userName/:initialDate/:finalDate", r.locationController.GetDistanceTraveled)
 		locations.GET("/distance/:userName", r.locationController.GetDistanceTraveled)
+		locations.GET("/distance-breakdown/:userName/:initialDate/:finalDate", r.locationController.GetDistanceBreakdown)
+		locations.GET("/distance-breakdown/:userName", r.locationController.GetDistanceBreakdown)
+		locations.GET("/history/:userName/:initialDate/:finalDate", r.locationController.GetLocationHistory)
+		locations.GET("/history/:userName", r.locationController.GetLocationHistory)
+		locations.GET("/route/:userName/:initialDate/:finalDate", r.locationController.GetRoute)
+		locations.GET("/route/:userName", r.locationController.GetRoute)
+		locations.GET("/outliers/:userName/:initialDate/:finalDate", r.locationController.GetOutlierCount)
+		locations.GET("/outliers/:userName", r.locationController.GetOutlierCount)
+		locations.PUT("/primary-device/:userName/:deviceId", r.locationController.SetPrimaryDevice)
+		locations.GET("/trips/:userName/:initialDate/:finalDate", r.locationController.ListTrips)
+		locations.GET("/trips/:userName", r.locationController.ListTrips)
+		locations.GET("/trip/:userName/:tripId", r.locationController.GetTrip)
+		locations.GET("/stays/:userName/:initialDate/:finalDate", r.locationController.ListStays)
+		locations.GET("/stays/:userName", r.locationController.ListStays)
+		locations.GET("/places/:userName/:initialDate/:finalDate", r.locationController.GetFrequentPlaces)
+		locations.GET("/places/:userName", r.locationController.GetFrequentPlaces)
+		locations.GET("/leaderboard/:initialDate/:finalDate", r.locationController.GetLeaderboard)
+		locations.GET("/leaderboard", r.locationController.GetLeaderboard)
+		locations.GET("/snapshot", r.locationController.GetUsersByLocationAtTime)
+		locations.POST("/polygon", r.locationController.GetUsersInPolygon)
+		locations.GET("/bounding-box", r.locationController.GetUsersInBoundingBox)
+		locations.GET("/encounters/:userName/:initialDate/:finalDate", r.locationController.ListEncounters)
+		locations.GET("/encounters/:userName", r.locationController.ListEncounters)
+		locations.GET("/encounters-scan/:userName/:initialDate/:finalDate", r.locationController.FindEncounters)
+		locations.GET("/encounters-scan/:userName", r.locationController.FindEncounters)
 	}
 }
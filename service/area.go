@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	commonDto "github.com/oboadagd/location-common/dto"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"math"
+	"sort"
+	"time"
+)
+
+// maxPolygonPositions is the maximum number of positions of the polygons of a search.
+const maxPolygonPositions = 10000
+
+// polygonArea is a polygon prepared for point-in-polygon tests. The longitudes of a
+// polygon crossing the antimeridian are unwrapped, shifting western longitudes by 360
+// degrees, so its rings are continuous.
+type polygonArea struct {
+	rings   [][][2]float64  // exterior ring followed by the holes, as [longitude, latitude] positions
+	box     dto.BoundingBox // smallest bounding box of the exterior ring
+	shifted bool            // whether western longitudes are shifted by 360 degrees
+}
+
+// newPolygonArea returns the polygonArea of polygon p, or a description of the reason p
+// is invalid. Rings need at least 3 positions within the coordinate ranges.
+func newPolygonArea(p dto.Polygon) (*polygonArea, string) {
+	if len(p) == 0 {
+		return nil, "missing exterior ring"
+	}
+
+	a := polygonArea{}
+	for i, ring := range p {
+		if len(ring) < 3 || len(ring) == 3 && ring[0] == ring[2] {
+			return nil, fmt.Sprintf("ring %d has less than 3 positions", i)
+		}
+
+		for _, pos := range ring {
+			if pos[0] < -180 || pos[0] > 180 || pos[1] < -90 || pos[1] > 90 {
+				return nil, fmt.Sprintf("ring %d has position %v out of range", i, pos)
+			}
+		}
+	}
+
+	exterior := p[0]
+	for i := range exterior {
+		if math.Abs(exterior[i][0]-exterior[(i+1)%len(exterior)][0]) > 180 {
+			a.shifted = true
+			break
+		}
+	}
+
+	for _, ring := range p {
+		r := make([][2]float64, len(ring))
+		for i, pos := range ring {
+			r[i] = [2]float64{a.longitude(pos[0]), pos[1]}
+		}
+		a.rings = append(a.rings, r)
+	}
+
+	a.box = dto.BoundingBox{MinLatitude: 90, MinLongitude: 360, MaxLatitude: -90, MaxLongitude: -180}
+	for _, pos := range a.rings[0] {
+		a.box.MinLongitude = math.Min(a.box.MinLongitude, pos[0])
+		a.box.MaxLongitude = math.Max(a.box.MaxLongitude, pos[0])
+		a.box.MinLatitude = math.Min(a.box.MinLatitude, pos[1])
+		a.box.MaxLatitude = math.Max(a.box.MaxLatitude, pos[1])
+	}
+	if a.box.MaxLongitude > 180 {
+		a.box.MaxLongitude -= 360
+	}
+
+	return &a, ""
+}
+
+// longitude returns longitude lng unwrapped as the rings of a.
+func (a *polygonArea) longitude(lng float64) float64 {
+	if a.shifted && lng < 0 {
+		return lng + 360
+	}
+	return lng
+}
+
+// contains returns true if the position at latitude lat and longitude lng is within the
+// exterior ring of a and out of its holes. Edges are straight lines in the plane of
+// longitudes and latitudes, as in GeoJSON.
+func (a *polygonArea) contains(lat float64, lng float64) bool {
+	lng = a.longitude(lng)
+	if !inRing(a.rings[0], lat, lng) {
+		return false
+	}
+
+	for _, hole := range a.rings[1:] {
+		if inRing(hole, lat, lng) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inRing returns true if the position at latitude lat and longitude lng is within ring,
+// casting a ray along the latitude and counting the edges it crosses.
+func inRing(ring [][2]float64, lat float64, lng float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > lat) != (yj > lat) && lng < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+// locationsInBoundingBox returns the current locations within area updated since date
+// since, or at any date if since has empty value. Areas crossing the antimeridian are
+// fetched as their eastern and western halves.
+func (s *LocationService) locationsInBoundingBox(ctx context.Context, area dto.BoundingBox, since time.Time) ([]dto.Location, error) {
+	ranges := []commonDto.GetByLatitudeLongitudeRangeRequest{{
+		LatitudeMin:  area.MinLatitude,
+		LatitudeMax:  area.MaxLatitude,
+		LongitudeMin: area.MinLongitude,
+		LongitudeMax: area.MaxLongitude,
+	}}
+
+	if area.MinLongitude > area.MaxLongitude {
+		ranges[0].LongitudeMax = 180
+		ranges = append(ranges, commonDto.GetByLatitudeLongitudeRangeRequest{
+			LatitudeMin:  area.MinLatitude,
+			LatitudeMax:  area.MaxLatitude,
+			LongitudeMin: -180,
+			LongitudeMax: area.MaxLongitude,
+		})
+	}
+
+	var locations []dto.Location
+	for _, r := range ranges {
+		ulr, err := s.locationRepository.GetByLatitudeLongitudeRange(ctx, r, since)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, ulr.Users...)
+	}
+
+	return locations, nil
+}
+
+// usersInArea returns the requested page of the locations of users ordered by username.
+func usersInArea(users []dto.Location, page uint64, itemsLimit uint64) (*dto.GetUsersInAreaResponse, error) {
+	sort.Slice(users, func(i, j int) bool { return users[i].UserName < users[j].UserName })
+
+	totalItems := uint64(len(users))
+	start, end, totalPages, err := pageBounds(page, itemsLimit, totalItems)
+	if err != nil {
+		return &dto.GetUsersInAreaResponse{}, err
+	}
+
+	return &dto.GetUsersInAreaResponse{
+		Users:      users[start:end],
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// GetUsersInPolygon implements business logic of getting the usernames whose current
+// location is within any of the requested polygons, by requested page. Locations older
+// than the requested maximum age, or the configured one, are skipped. Returns
+// ErrorPolygonInvalidCode for polygons with invalid rings or more than
+// maxPolygonPositions positions in total.
+func (s *LocationService) GetUsersInPolygon(ctx context.Context, request dto.GetUsersInPolygonRequest) (*dto.GetUsersInAreaResponse, error) {
+
+	positions := 0
+	areas := make([]*polygonArea, len(request.Polygons))
+	for i, p := range request.Polygons {
+		a, reason := newPolygonArea(p)
+		if a == nil {
+			return &dto.GetUsersInAreaResponse{}, respKit.GenericBadRequestError(enums.ErrorPolygonInvalidCode,
+				fmt.Sprintf(enums.ErrorPolygonInvalidMsg, i, reason))
+		}
+
+		for _, ring := range p {
+			positions += len(ring)
+		}
+		if positions > maxPolygonPositions {
+			return &dto.GetUsersInAreaResponse{}, respKit.GenericBadRequestError(enums.ErrorPolygonInvalidCode,
+				fmt.Sprintf(enums.ErrorPolygonInvalidMsg, i, fmt.Sprintf("polygons exceed %d positions", maxPolygonPositions)))
+		}
+		areas[i] = a
+	}
+
+	// polygons of a multipolygon may overlap, so each username is listed once
+	since := updatedSince(request.MaxAge)
+	found := map[string]bool{}
+	var users []dto.Location
+	for _, a := range areas {
+		locations, err := s.locationsInBoundingBox(ctx, a.box, since)
+		if err != nil {
+			return &dto.GetUsersInAreaResponse{}, err
+		}
+
+		for _, l := range locations {
+			if !found[l.UserName] && a.contains(l.Latitude, l.Longitude) {
+				found[l.UserName] = true
+				users = append(users, l)
+			}
+		}
+	}
+
+	return usersInArea(users, request.Page, request.ItemsLimit)
+}
+
+// GetUsersInBoundingBox implements business logic of getting the usernames whose current
+// location is within a bounding box, by requested page. Locations older than the requested
+// maximum age, or the configured one, are skipped. A minimum longitude greater than the
+// maximum longitude selects a box crossing the antimeridian.
+func (s *LocationService) GetUsersInBoundingBox(ctx context.Context, request dto.GetUsersInBoundingBoxRequest) (*dto.GetUsersInAreaResponse, error) {
+
+	users, err := s.locationsInBoundingBox(ctx, request.Area, updatedSince(request.MaxAge))
+	if err != nil {
+		return &dto.GetUsersInAreaResponse{}, err
+	}
+
+	return usersInArea(users, request.Page, request.ItemsLimit)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"testing"
+)
+
+func TestPolygonAreaContains(t *testing.T) {
+	nameTest := "TestPolygonAreaContains"
+
+	// a square around Madrid with a square hole in its center
+	madrid := dto.Polygon{
+		{{-4, 40}, {-3, 40}, {-3, 41}, {-4, 41}, {-4, 40}},
+		{{-3.6, 40.4}, {-3.4, 40.4}, {-3.4, 40.6}, {-3.6, 40.6}},
+	}
+
+	// a triangle crossing the antimeridian around Fiji
+	fiji := dto.Polygon{
+		{{178, -16}, {-178, -16}, {-178, -20}},
+	}
+
+	type test struct {
+		name      string
+		polygon   dto.Polygon
+		latitude  float64
+		longitude float64
+		answer    bool
+	}
+
+	tests := []test{
+		{"inside", madrid, 40.2, -3.8, true},
+		{"in the hole", madrid, 40.5, -3.5, false},
+		{"outside", madrid, 42, -3.5, false},
+		{"east of the antimeridian", fiji, -16.5, 179.5, true},
+		{"west of the antimeridian", fiji, -16.5, -179, true},
+		{"out of the triangle", fiji, -19.5, 178.5, false},
+		{"other side of the world", fiji, -16.5, 0, false},
+	}
+
+	for _, v := range tests {
+		a, reason := newPolygonArea(v.polygon)
+		if a == nil {
+			t.Errorf("%s %s: %v", nameTest, v.name, reason)
+			return
+		}
+
+		if got := a.contains(v.latitude, v.longitude); got != v.answer {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestNewPolygonArea_BoundingBox(t *testing.T) {
+	nameTest := "TestNewPolygonArea_BoundingBox"
+
+	type test struct {
+		name    string
+		polygon dto.Polygon
+		answer  *dto.BoundingBox // nil for invalid polygons
+	}
+
+	tests := []test{
+		{"plain", dto.Polygon{{{-4, 40}, {-3, 40}, {-3, 41}}}, &dto.BoundingBox{MinLatitude: 40, MinLongitude: -4, MaxLatitude: 41, MaxLongitude: -3}},
+		{"antimeridian", dto.Polygon{{{178, -16}, {-178, -16}, {-178, -20}}}, &dto.BoundingBox{MinLatitude: -20, MinLongitude: 178, MaxLatitude: -16, MaxLongitude: -178}},
+		{"no rings", dto.Polygon{}, nil},
+		{"closed triangle without area", dto.Polygon{{{-4, 40}, {-3, 40}, {-4, 40}}}, nil},
+		{"out of range", dto.Polygon{{{-4, 40}, {-3, 91}, {-3, 41}}}, nil},
+	}
+
+	for _, v := range tests {
+		a, reason := newPolygonArea(v.polygon)
+		if v.answer == nil {
+			if a != nil || reason == "" {
+				t.Errorf("%s %s: Expected invalid polygon but got %v", nameTest, v.name, a)
+				return
+			}
+			continue
+		}
+
+		if a == nil || a.box != *v.answer {
+			t.Errorf("%s %s: Expected %v but got %v %v", nameTest, v.name, *v.answer, a, reason)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/logger"
+)
+
+// backfillPageSize is the number of LocationHistory records read at once while
+// backfilling derived data.
+const backfillPageSize = 1000
+
+// backfill rebuilds with backfillDevice the kind of data derived from the
+// LocationHistory records of every device of a username, or of every username of the
+// tenant if userName is empty. Returns the number of stored records.
+func (s *LocationService) backfill(ctx context.Context, userName string, kind string,
+	backfillDevice func(ctx context.Context, d dto.UserDevice) (int, error)) (int, error) {
+	devices, err := s.locationHistoryRepository.GetUserDevices(ctx, userName)
+	if err != nil {
+		return 0, err
+	}
+
+	stored := 0
+	for _, d := range devices {
+		n, err := backfillDevice(ctx, d)
+		stored += n
+		if err != nil {
+			return stored, err
+		}
+
+		logger.FromContext(ctx, s.log).
+			WithField(logger.FieldUserName, d.UserName).
+			WithField(logger.FieldDeviceID, d.DeviceId).
+			WithField(kind, n).
+			Info(kind + " backfilled")
+	}
+
+	return stored, nil
+}
+
+// walkHistory calls fn with every LocationHistory record of device d in date order,
+// stopping at the first error.
+func (s *LocationService) walkHistory(ctx context.Context, d dto.UserDevice, fn func(lh dto.LocationHistory) error) error {
+	var after dto.LocationHistory
+	for {
+		page, err := s.locationHistoryRepository.GetPageByUserNameAndDevice(ctx, d.UserName, d.DeviceId, after, backfillPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, lh := range page {
+			if err := fn(lh); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < backfillPageSize {
+			return nil
+		}
+		after = page[len(page)-1]
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"time"
+)
+
+// maxDistanceBuckets is the maximum number of buckets of a distance breakdown.
+const maxDistanceBuckets = 1000
+
+// bucketStart returns the start of the bucket of t in the time zone of t. Hours are
+// truncated on the absolute time line, so the repeated hour of a DST change makes two
+// buckets, while days, weeks and months start at local midnight.
+func bucketStart(t time.Time, bucket string) time.Time {
+	y, m, d := t.Date()
+	switch bucket {
+	case enums.BucketHour:
+		return t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	case enums.BucketWeek:
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case enums.BucketMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// nextBucket returns the start of the bucket following the bucket that starts at start.
+func nextBucket(start time.Time, bucket string) time.Time {
+	y, m, d := start.Date()
+	switch bucket {
+	case enums.BucketHour:
+		return start.Add(time.Hour)
+	case enums.BucketWeek:
+		return time.Date(y, m, d+7, 0, 0, 0, 0, start.Location())
+	case enums.BucketMonth:
+		return time.Date(y, m+1, 1, 0, 0, 0, 0, start.Location())
+	default:
+		return time.Date(y, m, d+1, 0, 0, 0, 0, start.Location())
+	}
+}
+
+// bucketBoundaries returns the boundaries of the consecutive buckets in time zone loc
+// that cover the range of initial and final dates, from the start of the bucket of the
+// initial date to the end of the bucket of the final date. Returns ErrorTooManyBucketsCode
+// if the range spans more than maxDistanceBuckets buckets.
+func bucketBoundaries(initialDate time.Time, finalDate time.Time, bucket string, loc *time.Location) ([]time.Time, error) {
+	start := bucketStart(initialDate.In(loc), bucket)
+	boundaries := []time.Time{start}
+	for !start.After(finalDate) {
+		if len(boundaries) > maxDistanceBuckets {
+			return nil, respKit.GenericBadRequestError(enums.ErrorTooManyBucketsCode,
+				fmt.Sprintf(enums.ErrorTooManyBucketsMsg, maxDistanceBuckets, bucket))
+		}
+
+		start = nextBucket(start, bucket)
+		boundaries = append(boundaries, start)
+	}
+
+	return boundaries, nil
+}
+
+// GetDistanceBreakdown implements business logic of getting the distance traveled by a
+// username in each hour, day, week or month of a time range, in the requested IANA time
+// zone. Buckets without locations have zero distance. Returns username data not found if
+// username has no locations in the range, ErrorTimeZoneInvalidCode for unknown time zones
+// and ErrorTooManyBucketsCode for ranges of more than maxDistanceBuckets buckets. If
+// initial or final date has empty value then time range defaults to 1 day.
+func (s *LocationService) GetDistanceBreakdown(ctx context.Context, request dto.GetDistanceBreakdownRequest) (*dto.GetDistanceBreakdownResponse, error) {
+
+	loc, err := time.LoadLocation(request.TimeZone)
+	if err != nil {
+		return &dto.GetDistanceBreakdownResponse{}, respKit.GenericBadRequestError(enums.ErrorTimeZoneInvalidCode,
+			fmt.Sprintf(enums.ErrorTimeZoneInvalidMsg, request.TimeZone))
+	}
+
+	request.InitialDate, request.FinalDate = dateRange(request.InitialDate, request.FinalDate)
+
+	boundaries, err := bucketBoundaries(request.InitialDate, request.FinalDate, request.Bucket, loc)
+	if err != nil {
+		return &dto.GetDistanceBreakdownResponse{}, err
+	}
+
+	// the total selects the device when none is requested, as GetDistanceTraveled does
+	dt, err := s.locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctx, dto.GetDistanceTraveledRequest{
+		UserName:    request.UserName,
+		DeviceId:    request.DeviceId,
+		InitialDate: request.InitialDate,
+		FinalDate:   request.FinalDate,
+	})
+	if err != nil {
+		return &dto.GetDistanceBreakdownResponse{}, err
+	}
+
+	request.DeviceId = dt.DeviceId
+	distances, err := s.locationHistoryRepository.GetDistanceByBuckets(ctx, request, boundaries)
+	if err != nil {
+		return &dto.GetDistanceBreakdownResponse{}, err
+	}
+
+	buckets := make([]dto.DistanceBucket, len(distances))
+	for i, d := range distances {
+		buckets[i] = dto.DistanceBucket{
+			Start:    boundaries[i],
+			End:      boundaries[i+1],
+			Distance: d,
+		}
+	}
+
+	return &dto.GetDistanceBreakdownResponse{
+		Username:      request.UserName,
+		DeviceId:      dt.DeviceId,
+		Bucket:        request.Bucket,
+		TimeZone:      loc.String(),
+		TotalDistance: dt.TotalDistance,
+		Buckets:       buckets,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"testing"
+	"time"
+)
+
+func TestBucketBoundaries(t *testing.T) {
+	nameTest := "TestBucketBoundaries"
+
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// DST starts on 2024-03-31 and ends on 2024-10-27 in Europe/Madrid
+	type test struct {
+		name     string
+		initial  time.Time
+		final    time.Time
+		bucket   string
+		lengths  []time.Duration // lengths of the buckets
+		firstDay int             // local day of the month of the first boundary
+	}
+
+	tests := []test{
+		{"spring forward day", time.Date(2024, 3, 30, 12, 0, 0, 0, madrid), time.Date(2024, 4, 1, 1, 0, 0, 0, madrid),
+			enums.BucketDay, []time.Duration{24 * time.Hour, 23 * time.Hour, 24 * time.Hour}, 30},
+		{"fall back day", time.Date(2024, 10, 27, 0, 0, 0, 0, madrid), time.Date(2024, 10, 27, 23, 0, 0, 0, madrid),
+			enums.BucketDay, []time.Duration{25 * time.Hour}, 27},
+		{"fall back hours", time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC).Add(-2 * time.Hour), time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC),
+			enums.BucketHour, []time.Duration{time.Hour, time.Hour, time.Hour}, 27},
+		{"week from monday", time.Date(2024, 3, 27, 10, 0, 0, 0, madrid), time.Date(2024, 4, 2, 10, 0, 0, 0, madrid),
+			enums.BucketWeek, []time.Duration{7*24*time.Hour - time.Hour, 7 * 24 * time.Hour}, 25},
+		{"months", time.Date(2024, 2, 10, 0, 0, 0, 0, madrid), time.Date(2024, 3, 10, 0, 0, 0, 0, madrid),
+			enums.BucketMonth, []time.Duration{29 * 24 * time.Hour, 31*24*time.Hour - time.Hour}, 1},
+	}
+
+	for _, v := range tests {
+		boundaries, err := bucketBoundaries(v.initial, v.final, v.bucket, madrid)
+		if err != nil {
+			t.Errorf("%s %s: %v", nameTest, v.name, err)
+			return
+		}
+
+		if len(boundaries) != len(v.lengths)+1 {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, len(v.lengths)+1, boundaries)
+			return
+		}
+
+		if boundaries[0].Day() != v.firstDay || boundaries[0].After(v.initial) || !boundaries[len(boundaries)-1].After(v.final) {
+			t.Errorf("%s %s: Expected boundaries covering %v to %v but got %v", nameTest, v.name, v.initial, v.final, boundaries)
+			return
+		}
+
+		for i, l := range v.lengths {
+			if got := boundaries[i+1].Sub(boundaries[i]); got != l {
+				t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, l, got)
+				return
+			}
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestBucketBoundaries_ErrorTooManyBucketsCode(t *testing.T) {
+	nameTest := "TestBucketBoundaries_ErrorTooManyBucketsCode"
+
+	final := time.Now()
+
+	type test struct {
+		initial time.Time
+		fails   bool
+	}
+
+	tests := []test{
+		{final.Add(-999 * time.Hour), false},
+		{final.Add(-1000 * time.Hour), true},
+	}
+
+	for _, v := range tests {
+		_, err := bucketBoundaries(v.initial, final, enums.BucketHour, time.UTC)
+		if (err != nil) != v.fails {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.fails, err)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/enums"
+)
+
+// radiusCursor is the position of a username in the results of a radius search, which
+// are ordered by distance and then by username. Clients get it as an opaque string.
+type radiusCursor struct {
+	Distance float64 `json:"d"` // distance in kilometers from the center to the username
+	UserName string  `json:"u"` // username
+}
+
+// after returns true if the result of username userName at distance kilometers from the
+// center follows cursor c.
+func (c radiusCursor) after(distance float64, userName string) bool {
+	return distance > c.Distance || distance == c.Distance && userName > c.UserName
+}
+
+// encode returns c as an opaque string.
+func (c radiusCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeRadiusCursor returns the radiusCursor encoded as s. Returns ErrorCursorInvalidCode
+// if s isn't an encoded cursor.
+func decodeRadiusCursor(s string) (radiusCursor, error) {
+	var c radiusCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(b, &c)
+	}
+
+	if err != nil || c.UserName == "" {
+		return radiusCursor{}, respKit.GenericBadRequestError(enums.ErrorCursorInvalidCode,
+			fmt.Sprintf(enums.ErrorCursorInvalidMsg, s))
+	}
+
+	return c, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestDecodeRadiusCursor(t *testing.T) {
+	nameTest := "TestDecodeRadiusCursor"
+
+	c := radiusCursor{Distance: 1.5, UserName: "usernamesample"}
+
+	type test struct {
+		cursor string
+		fails  bool
+	}
+
+	tests := []test{
+		{c.encode(), false},
+		{"", true},
+		{"notacursor", true},
+		{radiusCursor{Distance: 1.5}.encode(), true},
+	}
+
+	for _, v := range tests {
+		got, err := decodeRadiusCursor(v.cursor)
+		if (err != nil) != v.fails || !v.fails && got != c {
+			t.Errorf("%s: Expected %v %v but got %v %v", nameTest, c, v.fails, got, err)
+			return
+		}
+	}
+
+	type testAfter struct {
+		distance float64
+		userName string
+		answer   bool
+	}
+
+	testsAfter := []testAfter{
+		{1.5, "usernamesample", false},
+		{1.5, "zetasample", true},
+		{1.5, "othersample", false},
+		{1.4, "zetasample", false},
+		{1.6, "othersample", true},
+	}
+
+	for _, v := range testsAfter {
+		if got := c.after(v.distance, v.userName); got != v.answer {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"math"
+)
+
+// Distance algorithms. They decide how the traveled distance between two locations is
+// measured and are recorded on every LocationHistory record.
+const (
+	DistanceHaversine = "haversine" // great circle on a sphere of radius 6371 kilometers. Off by up to about 0.5% versus WGS-84
+	DistanceVincenty  = "vincenty"  // geodesic on the WGS-84 ellipsoid by Vincenty's inverse formula
+)
+
+// WGS-84 ellipsoid parameters.
+const (
+	wgs84SemiMajorAxis = 6378137.0                                  // meters
+	wgs84Flattening    = 1 / 298.257223563                          // flattening
+	wgs84SemiMinorAxis = wgs84SemiMajorAxis * (1 - wgs84Flattening) // meters
+)
+
+// vincentyMaxIterations is the number of iterations after which Vincenty's formula is
+// considered not to converge, which only happens for nearly antipodal points.
+const vincentyMaxIterations = 200
+
+// DistanceCalculator measures the distance between geographic points.
+type DistanceCalculator interface {
+	// Name returns the distance algorithm of the calculator.
+	Name() string
+	// Distance returns the distance in kilometers from a to b.
+	Distance(a *geo.Point, b *geo.Point) float64
+}
+
+// haversineCalculator measures great circle distances on a sphere.
+type haversineCalculator struct{}
+
+// Name implements DistanceCalculator.
+func (haversineCalculator) Name() string {
+	return DistanceHaversine
+}
+
+// Distance implements DistanceCalculator.
+func (haversineCalculator) Distance(a *geo.Point, b *geo.Point) float64 {
+	return a.GreatCircleDistance(b)
+}
+
+// vincentyCalculator measures geodesic distances on the WGS-84 ellipsoid.
+type vincentyCalculator struct{}
+
+// Name implements DistanceCalculator.
+func (vincentyCalculator) Name() string {
+	return DistanceVincenty
+}
+
+// Distance implements DistanceCalculator with Vincenty's inverse formula, which is
+// accurate to within a millimeter. Nearly antipodal points, where the formula doesn't
+// converge, are measured as great circle distances instead.
+func (vincentyCalculator) Distance(a *geo.Point, b *geo.Point) float64 {
+	const rad = math.Pi / 180
+	f := wgs84Flattening
+
+	L := (b.Lng() - a.Lng()) * rad
+	U1 := math.Atan((1 - f) * math.Tan(a.Lat()*rad))
+	U2 := math.Atan((1 - f) * math.Tan(b.Lat()*rad))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM float64
+	converged := false
+	for i := 0; i < vincentyMaxIterations; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0 // coincident points
+		}
+
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+
+		// points on the equator have no meridional component
+		cos2SigmaM = 0
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+
+		C := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-previous) < 1e-12 {
+			converged = true
+			break
+		}
+	}
+
+	if !converged {
+		return a.GreatCircleDistance(b)
+	}
+
+	aa, bb := wgs84SemiMajorAxis, wgs84SemiMinorAxis
+	u2 := cos2Alpha * (aa*aa - bb*bb) / (bb * bb)
+	A := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	B := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return bb * A * (sigma - deltaSigma) / 1000
+}
+
+// distanceCalculators are the calculators by distance algorithm.
+var distanceCalculators = map[string]DistanceCalculator{
+	DistanceHaversine: haversineCalculator{},
+	DistanceVincenty:  vincentyCalculator{},
+}
+
+// IsDistanceAlgorithm returns true if algorithm is a known distance algorithm.
+func IsDistanceAlgorithm(algorithm string) bool {
+	_, ok := distanceCalculators[algorithm]
+	return ok
+}
+
+// calculatorNamed returns the calculator of algorithm. Records stored before the
+// algorithm was recorded have an empty one and were measured with haversine.
+func calculatorNamed(algorithm string) DistanceCalculator {
+	if c, ok := distanceCalculators[algorithm]; ok {
+		return c
+	}
+
+	return haversineCalculator{}
+}
+
+// distanceCalculator returns the calculator configured for the tenant carried by ctx,
+// falling back to the default algorithm of every tenant.
+func distanceCalculator(ctx context.Context) DistanceCalculator {
+	algorithm := config.Cfg.DistanceAlgorithm
+	if a, ok := config.Cfg.DistanceAlgorithms[tenant.FromContext(ctx)]; ok {
+		algorithm = a
+	}
+
+	return calculatorNamed(algorithm)
+}
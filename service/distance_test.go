@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"math"
+	"testing"
+)
+
+func TestDistanceCalculators(t *testing.T) {
+	nameTest := "TestDistanceCalculators"
+
+	dms := func(d float64, m float64, s float64) float64 { return d + m/60 + s/3600 }
+	flindersPeak := geo.NewPoint(-dms(37, 57, 3.72030), dms(144, 25, 29.52440))
+	buninyong := geo.NewPoint(-dms(37, 39, 10.15610), dms(143, 55, 35.38390))
+
+	type test struct {
+		name       string
+		calculator DistanceCalculator
+		a          *geo.Point
+		b          *geo.Point
+		answer     float64 // kilometers
+	}
+
+	// ellipsoidal references from Vincenty (1975) and Geoscience Australia
+	tests := []test{
+		{"vincenty flinders peak to buninyong", vincentyCalculator{}, flindersPeak, buninyong, 54.972271},
+		{"vincenty degree of equator", vincentyCalculator{}, geo.NewPoint(0, 0), geo.NewPoint(0, 1), 111.319491},
+		{"vincenty degree of meridian", vincentyCalculator{}, geo.NewPoint(0, 0), geo.NewPoint(1, 0), 110.574389},
+		{"vincenty coincident", vincentyCalculator{}, flindersPeak, flindersPeak, 0},
+		{"vincenty nearly antipodal", vincentyCalculator{}, geo.NewPoint(0, 0), geo.NewPoint(0.5, 179.7), 19950.249787},
+		{"haversine flinders peak to buninyong", haversineCalculator{}, flindersPeak, buninyong, 54.925432},
+		{"haversine degree of equator", haversineCalculator{}, geo.NewPoint(0, 0), geo.NewPoint(0, 1), 111.194927},
+	}
+
+	for _, v := range tests {
+		if d := v.calculator.Distance(v.a, v.b); math.Abs(d-v.answer) > 1e-6 {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, d)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestDistanceCalculator(t *testing.T) {
+	nameTest := "TestDistanceCalculator"
+	defer func(algorithm string, algorithms map[string]string) {
+		config.Cfg.DistanceAlgorithm = algorithm
+		config.Cfg.DistanceAlgorithms = algorithms
+	}(config.Cfg.DistanceAlgorithm, config.Cfg.DistanceAlgorithms)
+	config.Cfg.DistanceAlgorithm = DistanceHaversine
+	config.Cfg.DistanceAlgorithms = map[string]string{"tenanta": DistanceVincenty}
+
+	type test struct {
+		name   string
+		ctx    context.Context
+		answer string
+	}
+
+	tests := []test{
+		{"default", context.Background(), DistanceHaversine},
+		{"configured tenant", tenant.WithTenant(context.Background(), "tenanta"), DistanceVincenty},
+		{"other tenant", tenant.WithTenant(context.Background(), "tenantb"), DistanceHaversine},
+	}
+
+	for _, v := range tests {
+		if name := distanceCalculator(v.ctx).Name(); name != v.answer {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, name)
+			return
+		}
+	}
+
+	// records stored before the algorithm was recorded were measured with haversine
+	if name := calculatorNamed("").Name(); name != DistanceHaversine {
+		t.Errorf("%s: Expected %v but got %v", nameTest, DistanceHaversine, name)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	geo "github.com/kellydunn/golang-geo"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/logger"
+	"math"
+	"sort"
+	"time"
+)
+
+// maxEncounterRange is the longest range of an encounter scan.
+const maxEncounterRange = 7 * 24 * time.Hour
+
+// positionAt returns the position of a username at date at from its track ordered by
+// date, interpolating linearly between the locations around at. Returns false if at is
+// out of the track or the locations around it are more than maxGap apart.
+func positionAt(track []dto.LocationHistory, at time.Time, maxGap time.Duration) (*geo.Point, bool) {
+	i := sort.Search(len(track), func(i int) bool { return !track[i].UpdatedAt.Before(at) })
+	if i < len(track) && track[i].UpdatedAt.Equal(at) {
+		return geo.NewPoint(track[i].Latitude, track[i].Longitude), true
+	}
+
+	if i == 0 || i == len(track) {
+		return nil, false
+	}
+
+	a, b := track[i-1], track[i]
+	span := b.UpdatedAt.Sub(a.UpdatedAt)
+	if span > maxGap {
+		return nil, false
+	}
+
+	f := float64(at.Sub(a.UpdatedAt)) / float64(span)
+	return geo.NewPoint(a.Latitude+(b.Latitude-a.Latitude)*f, a.Longitude+(b.Longitude-a.Longitude)*f), true
+}
+
+// findEncounters returns the encounters of the usernames of track and other, both
+// ordered by date, closed and in date order. The usernames are compared at the dates of
+// the locations of either of them, and encounter while they are within distance meters
+// for at least minDuration.
+func findEncounters(track []dto.LocationHistory, other []dto.LocationHistory, distance float64, minDuration time.Duration, maxGap time.Duration) []dto.Encounter {
+	if len(track) == 0 || len(other) == 0 {
+		return nil
+	}
+
+	dates := make([]time.Time, 0, len(track)+len(other))
+	for _, lh := range track {
+		dates = append(dates, lh.UpdatedAt)
+	}
+	for _, lh := range other {
+		dates = append(dates, lh.UpdatedAt)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var encounters []dto.Encounter
+	var current *dto.Encounter
+	end := func() {
+		if current != nil && current.EndedAt.Sub(current.StartedAt) >= minDuration {
+			current.Duration = current.EndedAt.Sub(current.StartedAt).Seconds()
+			encounters = append(encounters, *current)
+		}
+		current = nil
+	}
+
+	for i, at := range dates {
+		if i > 0 && at.Equal(dates[i-1]) {
+			continue
+		}
+
+		a, okA := positionAt(track, at, maxGap)
+		b, okB := positionAt(other, at, maxGap)
+		if !okA || !okB {
+			end()
+			continue
+		}
+
+		d := a.GreatCircleDistance(b) * 1000
+		if d > distance {
+			end()
+			continue
+		}
+
+		if current == nil {
+			current = &dto.Encounter{
+				UserName:      track[0].UserName,
+				OtherUserName: other[0].UserName,
+				Status:        enums.EncounterClosed,
+				StartedAt:     at,
+				MinDistance:   d,
+			}
+		}
+		current.EndedAt = at
+		if d < current.MinDistance {
+			current.MinDistance = d
+		}
+	}
+	end()
+
+	return encounters
+}
+
+// advanceEncounter extends the current encounter of a pair of usernames, or nil if the
+// pair has none, with their distance in meters at date at. Pairs within distance extend
+// the encounter, which opens once it lasts minDuration. Pairs apart, or an encounter
+// whose last date is more than maxGap before at, close an open encounter and discard a
+// candidate, keeping its record with zero dates for reuse. Returns the encounter closed,
+// if any, and the current encounter of the pair.
+func advanceEncounter(current *dto.Encounter, userName string, otherUserName string, d float64, at time.Time,
+	distance float64, minDuration time.Duration, maxGap time.Duration) (*dto.Encounter, *dto.Encounter) {
+	near := d <= distance
+
+	var closed *dto.Encounter
+	if current != nil && current.Status == enums.EncounterOpen && (!near || at.Sub(current.EndedAt) > maxGap) {
+		current.Status = enums.EncounterClosed
+		closed, current = current, nil
+	}
+
+	if !near {
+		if current != nil {
+			current.StartedAt, current.EndedAt, current.Duration = time.Time{}, time.Time{}, 0
+		}
+		return closed, current
+	}
+
+	if current == nil {
+		if otherUserName < userName {
+			userName, otherUserName = otherUserName, userName
+		}
+		current = &dto.Encounter{
+			UserName:      userName,
+			OtherUserName: otherUserName,
+		}
+	}
+
+	if current.Status != enums.EncounterOpen && at.Sub(current.EndedAt) > maxGap {
+		current.Status = enums.EncounterCandidate
+		current.StartedAt = at
+		current.MinDistance = d
+	}
+
+	current.EndedAt = at
+	current.Duration = current.EndedAt.Sub(current.StartedAt).Seconds()
+	if d < current.MinDistance {
+		current.MinDistance = d
+	}
+	if current.Status == enums.EncounterCandidate && current.EndedAt.Sub(current.StartedAt) >= minDuration {
+		current.Status = enums.EncounterOpen
+	}
+
+	return closed, current
+}
+
+// trackBoundingBox returns the area within distance meters of the locations of track.
+func trackBoundingBox(track []dto.LocationHistory, distance float64) dto.BoundingBox {
+	var area dto.BoundingBox
+	for i, lh := range track {
+		box := radiusBoundingBox(geo.NewPoint(lh.Latitude, lh.Longitude), distance/1000)
+		if i == 0 {
+			area = box
+			continue
+		}
+
+		area.MinLatitude = math.Min(area.MinLatitude, box.MinLatitude)
+		area.MaxLatitude = math.Max(area.MaxLatitude, box.MaxLatitude)
+		if area.MinLongitude > area.MaxLongitude || box.MinLongitude > box.MaxLongitude {
+			// an area crossing the antimeridian spans every longitude
+			area.MinLongitude, area.MaxLongitude = -180, 180
+			continue
+		}
+		area.MinLongitude = math.Min(area.MinLongitude, box.MinLongitude)
+		area.MaxLongitude = math.Max(area.MaxLongitude, box.MaxLongitude)
+	}
+
+	return area
+}
+
+// FindEncounters implements business logic of scanning the location history of a username
+// against the histories of every other username of the tenant over a time range, by
+// requested page. Positions are interpolated between the locations of each username at
+// most the configured maximum gap apart. Returns ErrorEncounterRangeCode for ranges
+// longer than maxEncounterRange. If initial or final date has empty value then time range
+// defaults to 1 day.
+func (s *LocationService) FindEncounters(ctx context.Context, request dto.ListEncountersRequest) (*dto.ListEncountersResponse, error) {
+
+	request.InitialDate, request.FinalDate = dateRange(request.InitialDate, request.FinalDate)
+	if request.FinalDate.Sub(request.InitialDate) > maxEncounterRange {
+		return &dto.ListEncountersResponse{}, respKit.GenericBadRequestError(enums.ErrorEncounterRangeCode,
+			fmt.Sprintf(enums.ErrorEncounterRangeMsg, maxEncounterRange))
+	}
+
+	track, err := s.locationHistoryRepository.GetTrackByUserNameAndDateRange(ctx, request.UserName, "", request.InitialDate, request.FinalDate)
+	if err != nil || len(track) == 0 {
+		return &dto.ListEncountersResponse{}, err
+	}
+
+	distance, minDuration, maxGap := config.Cfg.EncounterDistance, config.Cfg.EncounterMinDuration, config.Cfg.EncounterMaxGap
+	others, err := s.locationHistoryRepository.GetTracksInAreaByDateRange(ctx, trackBoundingBox(track, distance),
+		request.InitialDate, request.FinalDate)
+	if err != nil {
+		return &dto.ListEncountersResponse{}, err
+	}
+
+	var encounters []dto.Encounter
+	for start := 0; start < len(others); {
+		end := start
+		for end < len(others) && others[end].UserName == others[start].UserName {
+			end++
+		}
+
+		if others[start].UserName != request.UserName {
+			encounters = append(encounters, findEncounters(track, others[start:end], distance, minDuration, maxGap)...)
+		}
+		start = end
+	}
+
+	sort.SliceStable(encounters, func(i, j int) bool { return encounters[i].StartedAt.Before(encounters[j].StartedAt) })
+
+	totalItems := uint64(len(encounters))
+	start, end, totalPages, err := pageBounds(request.Page, request.ItemsLimit, totalItems)
+	if err != nil {
+		return &dto.ListEncountersResponse{}, err
+	}
+
+	return &dto.ListEncountersResponse{
+		Encounters: encounters[start:end],
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// trackEncounters advances the current encounters of the username of p with the last
+// locations of the other usernames near p and stores the changed encounters. Encounters
+// are derived data that FindEncounters can scan again, so failures are logged instead of
+// failing the location.
+func (s *LocationService) trackEncounters(ctx context.Context, p trackPoint) {
+	if !config.Cfg.EncounterTracking || !segmentable(p.reason) {
+		return
+	}
+
+	entry := logger.FromContext(ctx, s.log).
+		WithField(logger.FieldUserName, p.userName).
+		WithField(logger.FieldDeviceID, p.deviceId)
+
+	distance, minDuration, maxGap := config.Cfg.EncounterDistance, config.Cfg.EncounterMinDuration, config.Cfg.EncounterMaxGap
+	point := geo.NewPoint(p.latitude, p.longitude)
+	last, err := s.locationHistoryRepository.GetLastInAreaByDateRange(ctx, radiusBoundingBox(point, distance/1000), p.at.Add(-maxGap), p.at)
+	if err != nil {
+		entry.WithError(err).Warn("encounter detection failed")
+		return
+	}
+
+	current, err := s.encounterRepository.GetCurrentByUserName(ctx, p.userName)
+	if err != nil {
+		entry.WithError(err).Warn("encounter detection failed")
+		return
+	}
+
+	// pairs without a near location move apart
+	near := map[string]float64{}
+	for _, l := range last {
+		if d := point.GreatCircleDistance(geo.NewPoint(l.Latitude, l.Longitude)) * 1000; l.UserName != p.userName && d <= distance {
+			near[l.UserName] = d
+		}
+	}
+
+	var changed []*dto.Encounter
+	for i := range current {
+		other := current[i].OtherUserName
+		if other == p.userName {
+			other = current[i].UserName
+		}
+
+		d, ok := near[other]
+		if !ok {
+			d = distance + 1
+		}
+		delete(near, other)
+
+		closed, next := advanceEncounter(&current[i], p.userName, other, d, p.at, distance, minDuration, maxGap)
+		changed = append(changed, closed, next)
+	}
+
+	for other, d := range near {
+		closed, next := advanceEncounter(nil, p.userName, other, d, p.at, distance, minDuration, maxGap)
+		changed = append(changed, closed, next)
+	}
+
+	for _, e := range changed {
+		if e == nil {
+			continue
+		}
+
+		if err := s.encounterRepository.Save(ctx, e); err != nil {
+			entry.WithError(err).Warn("encounter detection failed")
+			return
+		}
+	}
+}
+
+// ListEncounters implements business logic of listing the stored encounters of a
+// username with any other username that overlap a time range by requested page. Listed
+// encounters name the requested username as UserName. If initial or final date has empty
+// value then time range defaults to 1 day.
+func (s *LocationService) ListEncounters(ctx context.Context, request dto.ListEncountersRequest) (*dto.ListEncountersResponse, error) {
+
+	request.InitialDate, request.FinalDate = dateRange(request.InitialDate, request.FinalDate)
+
+	le, err := s.encounterRepository.ListByUserNameAndDateRange(ctx, request)
+	if err != nil {
+		return &dto.ListEncountersResponse{}, err
+	}
+
+	for i, e := range le.Encounters {
+		if e.UserName != request.UserName {
+			le.Encounters[i].UserName, le.Encounters[i].OtherUserName = e.OtherUserName, e.UserName
+		}
+	}
+
+	return le, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPositionAt(t *testing.T) {
+	nameTest := "TestPositionAt"
+
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	track := []dto.LocationHistory{
+		{Latitude: 40, Longitude: -3, UpdatedAt: start},
+		{Latitude: 40.002, Longitude: -3.002, UpdatedAt: start.Add(2 * time.Minute)},
+		{Latitude: 41, Longitude: -4, UpdatedAt: start.Add(time.Hour)},
+	}
+
+	type test struct {
+		name      string
+		at        time.Time
+		ok        bool
+		latitude  float64
+		longitude float64
+	}
+
+	tests := []test{
+		{"exact", start, true, 40, -3},
+		{"interpolated", start.Add(time.Minute), true, 40.001, -3.001},
+		{"before track", start.Add(-time.Minute), false, 0, 0},
+		{"gap", start.Add(30 * time.Minute), false, 0, 0},
+		{"after track", start.Add(2 * time.Hour), false, 0, 0},
+	}
+
+	for _, v := range tests {
+		p, ok := positionAt(track, v.at, 5*time.Minute)
+		if ok != v.ok {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.ok, ok)
+			return
+		}
+
+		if ok && (math.Abs(p.Lat()-v.latitude) > 1e-9 || math.Abs(p.Lng()-v.longitude) > 1e-9) {
+			t.Errorf("%s %s: Expected %v %v but got %v %v", nameTest, v.name, v.latitude, v.longitude, p.Lat(), p.Lng())
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestFindEncounters(t *testing.T) {
+	nameTest := "TestFindEncounters"
+
+	// usernamesample walks north 0.0001 degrees, about 11 meters, each minute while
+	// othersample stands still at the start and reports every 3 minutes, offset by 1
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	var track, other []dto.LocationHistory
+	for i := 0; i <= 20; i++ {
+		track = append(track, dto.LocationHistory{UserName: "usernamesample", Latitude: 40 + float64(i)*0.0001,
+			Longitude: -3, UpdatedAt: start.Add(time.Duration(i) * time.Minute)})
+		if i%3 == 1 {
+			other = append(other, dto.LocationHistory{UserName: "othersample", Latitude: 40, Longitude: -3,
+				UpdatedAt: start.Add(time.Duration(i) * time.Minute)})
+		}
+	}
+
+	type test struct {
+		name        string
+		distance    float64
+		minDuration time.Duration
+		answer      int
+		duration    time.Duration
+	}
+
+	tests := []test{
+		{"near from the second location of othersample", 50, 3 * time.Minute, 1, 3 * time.Minute},
+		{"too short", 50, 10 * time.Minute, 0, 0},
+		{"far", 5, time.Minute, 0, 0},
+		{"whole overlap", 1000, 5 * time.Minute, 1, 18 * time.Minute},
+	}
+
+	for _, v := range tests {
+		encounters := findEncounters(track, other, v.distance, v.minDuration, 5*time.Minute)
+		if len(encounters) != v.answer {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, encounters)
+			return
+		}
+
+		if v.answer == 0 {
+			continue
+		}
+
+		e := encounters[0]
+		if e.OtherUserName != "othersample" || e.EndedAt.Sub(e.StartedAt) != v.duration || e.Duration != v.duration.Seconds() {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.duration, e)
+			return
+		}
+
+		// the closest approach is the first location of usernamesample, about 11 meters away
+		if !e.StartedAt.Equal(start.Add(time.Minute)) || e.MinDistance < 10 || e.MinDistance > 12 {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, start.Add(time.Minute), e)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestAdvanceEncounter(t *testing.T) {
+	nameTest := "TestAdvanceEncounter"
+
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	minDuration, maxGap := 5*time.Minute, 5*time.Minute
+
+	type step struct {
+		distance float64
+		at       time.Duration // time since start
+		closed   bool          // whether the step closes an encounter
+		status   string        // status of the current encounter after the step, empty if none
+	}
+
+	steps := []step{
+		{30, 0, false, enums.EncounterCandidate},
+		{20, 2 * time.Minute, false, enums.EncounterCandidate},
+		{60, 3 * time.Minute, false, enums.EncounterCandidate},
+		{10, 4 * time.Minute, false, enums.EncounterCandidate},
+		{10, 9 * time.Minute, false, enums.EncounterOpen},
+		{40, 12 * time.Minute, false, enums.EncounterOpen},
+		{10, 30 * time.Minute, true, enums.EncounterCandidate},
+		{70, 31 * time.Minute, false, enums.EncounterCandidate},
+	}
+
+	var current *dto.Encounter
+	for i, v := range steps {
+		var closed *dto.Encounter
+		closed, current = advanceEncounter(current, "usernamesample", "othersample", v.distance, start.Add(v.at), 50, minDuration, maxGap)
+		if (closed != nil) != v.closed || current == nil || current.Status != v.status {
+			t.Errorf("%s step %d: Expected %v %v but got %v %v", nameTest, i, v.closed, v.status, closed, current)
+			return
+		}
+
+		// the pair is stored in lexical order
+		if current.UserName != "othersample" {
+			t.Errorf("%s step %d: Expected %v but got %v", nameTest, i, "othersample", current.UserName)
+			return
+		}
+
+		if closed != nil && (closed.Status != enums.EncounterClosed || closed.Duration != (8*time.Minute).Seconds() || closed.MinDistance != 10) {
+			t.Errorf("%s step %d: Expected %v but got %v", nameTest, i, 8*time.Minute, closed)
+			return
+		}
+	}
+
+	// the discarded candidate restarts on the next near location
+	if !current.StartedAt.IsZero() {
+		t.Errorf("%s: Expected %v but got %v", nameTest, time.Time{}, current.StartedAt)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"time"
+)
+
+// defaultLeaderboardRange is the range of leaderboards whose request doesn't set one.
+const defaultLeaderboardRange = 7 * 24 * time.Hour
+
+// GetLeaderboard implements business logic of ranking the usernames of the tenant, or the
+// requested ones, by the distance they traveled in a time range, optionally within an
+// area, by requested page. The rank of the requesting username is returned along the
+// page. If initial or final date has empty value then time range defaults to 7 days.
+func (s *LocationService) GetLeaderboard(ctx context.Context, request dto.GetLeaderboardRequest) (*dto.GetLeaderboardResponse, error) {
+
+	request.InitialDate, request.FinalDate = dateRangeOr(request.InitialDate, request.FinalDate, defaultLeaderboardRange)
+
+	lb, err := s.locationHistoryRepository.GetLeaderboard(ctx, request)
+	if err != nil {
+		return &dto.GetLeaderboardResponse{}, err
+	}
+
+	return lb, nil
+}
@@ -6,9 +6,17 @@ package service
 
 import (
 	"context"
+	"fmt"
 	geo "github.com/kellydunn/golang-geo"
-	"github.com/oboadagd/location-common/dto"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/logger"
 	"github.com/oboadagd/location-history-mgmt/repository"
+	"github.com/sirupsen/logrus"
+	"math"
+	"sort"
 	"time"
 )
 
@@ -16,113 +24,230 @@ import (
 // methods to manage the business logic of Location and LocationHistory models.
 type LocationServiceInterface interface {
 	Save(ctx context.Context, request dto.SaveLocationRequest) error
+	SetPrimaryDevice(ctx context.Context, userName string, deviceId string) error
 	GetUsersByLocationAndRadius(ctx context.Context, request dto.GetUsersByLocationAndRadiusRequest) (*dto.GetUsersByLocationAndRadiusResponse, error)
 	GetDistanceTraveled(ctx context.Context, request dto.GetDistanceTraveledRequest) (*dto.GetDistanceTraveledResponse, error)
+	GetDistanceBreakdown(ctx context.Context, request dto.GetDistanceBreakdownRequest) (*dto.GetDistanceBreakdownResponse, error)
+	RebuildDistanceRollups(ctx context.Context, userName string) (int, error)
+	RepairLocations(ctx context.Context, userName string) (int, error)
+	CheckLocations(ctx context.Context) ([]dto.LocationDivergence, error)
+	RecomputeDistances(ctx context.Context, request dto.RecomputeDistancesRequest) (*dto.RecomputeDistancesResponse, error)
+	PruneHistory(ctx context.Context, request dto.PruneHistoryRequest) (*dto.PruneHistoryResponse, error)
+	PruneTenants(ctx context.Context) (int, error)
+	PrunePartitions(ctx context.Context, dryRun bool) ([]dto.HistoryPartition, error)
+	MaintainPartitions(ctx context.Context) ([]string, error)
+	GetLeaderboard(ctx context.Context, request dto.GetLeaderboardRequest) (*dto.GetLeaderboardResponse, error)
+	GetUsersByLocationAtTime(ctx context.Context, request dto.GetUsersByLocationAtTimeRequest) (*dto.GetUsersByLocationAtTimeResponse, error)
+	GetUsersInPolygon(ctx context.Context, request dto.GetUsersInPolygonRequest) (*dto.GetUsersInAreaResponse, error)
+	GetUsersInBoundingBox(ctx context.Context, request dto.GetUsersInBoundingBoxRequest) (*dto.GetUsersInAreaResponse, error)
+	GetLocationHistory(ctx context.Context, request dto.GetLocationHistoryRequest) (*dto.GetLocationHistoryResponse, error)
+	GetRoute(ctx context.Context, request dto.GetRouteRequest) (*dto.GetRouteResponse, error)
+	GetOutlierCount(ctx context.Context, request dto.GetOutlierCountRequest) (*dto.GetOutlierCountResponse, error)
+	ListTrips(ctx context.Context, request dto.ListTripsRequest) (*dto.ListTripsResponse, error)
+	GetTrip(ctx context.Context, request dto.GetTripRequest) (*dto.Trip, error)
+	BackfillTrips(ctx context.Context, userName string) (int, error)
+	ListStays(ctx context.Context, request dto.ListStaysRequest) (*dto.ListStaysResponse, error)
+	GetFrequentPlaces(ctx context.Context, request dto.GetFrequentPlacesRequest) (*dto.GetFrequentPlacesResponse, error)
+	BackfillStays(ctx context.Context, userName string) (int, error)
+	ListEncounters(ctx context.Context, request dto.ListEncountersRequest) (*dto.ListEncountersResponse, error)
+	FindEncounters(ctx context.Context, request dto.ListEncountersRequest) (*dto.ListEncountersResponse, error)
 }
 
 // LocationService represents the Location service layer.
 type LocationService struct {
-	locationRepository        repository.LocationRepositoryInterface        // Location repository interface
-	locationHistoryRepository repository.LocationHistoryRepositoryInterface // LocationHistory repository interface
+	locationRepository            repository.LocationRepositoryInterface            // Location repository interface
+	locationHistoryRepository     repository.LocationHistoryRepositoryInterface     // LocationHistory repository interface
+	locationFilterStateRepository repository.LocationFilterStateRepositoryInterface // LocationFilterState repository interface
+	tripRepository                repository.TripRepositoryInterface                // Trip repository interface
+	stayRepository                repository.StayRepositoryInterface                // Stay repository interface
+	encounterRepository           repository.EncounterRepositoryInterface           // Encounter repository interface
+	jobCheckpointRepository       repository.JobCheckpointRepositoryInterface       // JobCheckpoint repository interface
+	log                           *logrus.Logger                                    // structured logger
 }
 
 // NewLocationService initializes Location service layer.
-func NewLocationService(locationRepository repository.LocationRepositoryInterface, locationHistoryRepository repository.LocationHistoryRepositoryInterface) LocationServiceInterface {
+func NewLocationService(locationRepository repository.LocationRepositoryInterface, locationHistoryRepository repository.LocationHistoryRepositoryInterface, locationFilterStateRepository repository.LocationFilterStateRepositoryInterface, tripRepository repository.TripRepositoryInterface, stayRepository repository.StayRepositoryInterface, encounterRepository repository.EncounterRepositoryInterface, jobCheckpointRepository repository.JobCheckpointRepositoryInterface, log *logrus.Logger) LocationServiceInterface {
 	return &LocationService{
 		locationRepository,
 		locationHistoryRepository,
+		locationFilterStateRepository,
+		tripRepository,
+		stayRepository,
+		encounterRepository,
+		jobCheckpointRepository,
+		log,
 	}
 }
 
 // Save implements business logic of create and update actions of Location model.
 // It creates records in Location and LocationHistory models when username doesn't
-// already exist in Location model. Updates Location model and Create LocationHistory
-// model record if username already exists in Location model. Sets traveled
-// LocationHistory.distance from last to current location, if username already
-// exists in Location model or zero otherwise.
+// already exist in Location model. Otherwise it creates the LocationHistory record and
+// updates Location model if the reporting device drives the current location under the
+// configured current location policy. Sets traveled LocationHistory.distance from the
+// last accumulated to the current location of the same device, or zero if it's the
+// first location of the device. Locations less accurate than the configured maximum,
+// within the jitter threshold or flagged as outliers are stored with zero distance and an
+// exclusion reason, and are skipped by later distances. Outliers don't update Location
+// model. Returns ErrorImplausibleSpeedCode, without storing the location, for outliers
+// rejected by the outlier policy. The Kalman filter state of the device is persisted once
+// the LocationHistory record is stored. The location then extends or closes the trips and
+// stays of the device, and the encounters of the username when encounter tracking is
+// enabled.
 func (s *LocationService) Save(ctx context.Context, request dto.SaveLocationRequest) error {
 
-	var distance float64 = 0
-	if s.locationRepository.ExistsByUserName(ctx, request.UserName) {
-		if err := s.locationRepository.UpdateByUserName(ctx, request, request.UserName); err != nil {
-			return err
-		}
-
-		llh := dto.GetLastByUserNameRequest{
-			UserName: request.UserName,
-		}
+	now := time.Now()
+	m, err := s.measureMovement(ctx, request, now)
+	if err != nil {
+		return err
+	}
 
-		resp, err := s.locationHistoryRepository.GetLastByUserName(ctx, llh)
-		if err != nil {
+	l, err := s.locationRepository.GetByUserName(ctx, request.UserName)
+	switch {
+	case isNotFound(err):
+		if err := s.locationRepository.Create(ctx, request); err != nil {
 			return err
 		}
-		ps := geo.NewPoint(resp.Latitude, resp.Longitude)
-		pf := geo.NewPoint(request.Latitude, request.Longitude)
-		distance = ps.GreatCircleDistance(pf)
-	} else if err := s.locationRepository.Create(ctx, request); err != nil {
+	case err != nil:
 		return err
+	case m.reason != enums.ExcludedOutlier && drivesCurrentLocation(config.Cfg.CurrentLocationPolicy, l, request, now, config.Cfg.MostAccurateWindow):
+		if err := s.locationRepository.UpdateByUserName(ctx, request, request.UserName); err != nil {
+			return err
+		}
 	}
 
 	lh := dto.CreateLocationHistoryRequest{
-		UserName:  request.UserName,
-		Latitude:  request.Latitude,
-		Longitude: request.Longitude,
-		Distance:  distance,
+		UserName:          request.UserName,
+		DeviceId:          request.DeviceId,
+		TransportMode:     request.TransportMode,
+		Latitude:          request.Latitude,
+		Longitude:         request.Longitude,
+		Distance:          m.distance,
+		ExcludedReason:    m.reason,
+		DistanceAlgorithm: m.algorithm,
+		Fix:               request.Fix,
+	}
+
+	if m.filtered != nil {
+		lat, lng := m.filtered.Lat(), m.filtered.Lng()
+		lh.FilteredLatitude = &lat
+		lh.FilteredLongitude = &lng
 	}
 
 	if err := s.locationHistoryRepository.Create(ctx, lh); err != nil {
 		return err
 	}
 
+	if m.state != nil {
+		if err := s.locationFilterStateRepository.Save(ctx, *m.state); err != nil {
+			return err
+		}
+	}
+
+	p := newTrackPoint(request.UserName, request.DeviceId, request.Latitude, request.Longitude, now,
+		m.distance, m.reason, request.Speed, m.last)
+	s.trackTrip(ctx, p)
+	s.trackStay(ctx, p)
+	s.trackEncounters(ctx, p)
+
+	logger.FromContext(ctx, s.log).
+		WithField(logger.FieldUserName, request.UserName).
+		WithField(logger.FieldDeviceID, request.DeviceId).
+		WithFields(logger.Coordinates(s.log, request.Latitude, request.Longitude)).
+		WithField("distance", m.distance).
+		WithField("excluded_reason", m.reason).
+		Info("location saved")
+
 	return nil
 }
 
+// SetPrimaryDevice implements business logic of choosing the device that drives the
+// current location of a username under the primary current location policy. Returns
+// username data not found if username doesn't exist in Location model.
+func (s *LocationService) SetPrimaryDevice(ctx context.Context, userName string, deviceId string) error {
+	return s.locationRepository.SetPrimaryDevice(ctx, userName, deviceId)
+}
+
+// maxRadiusItemsLimit is the maximum page size of radius searches.
+const maxRadiusItemsLimit = 1000
+
 // GetUsersByLocationAndRadius implements business logic of getting a list of username's Location models
-// that belongs to a given radius by requested page.
+// that belongs to a given radius by requested page, ordered by distance to the center and
+// then by username. Locations older than the requested maximum age, or the configured
+// one, are skipped. A request with a cursor gets the page that follows it instead of the
+// requested page, and every page returns the cursor of its last item while more items
+// follow. Returns ErrorPageInvalidCode for empty pages or page sizes out of range and
+// ErrorCursorInvalidCode for cursors that weren't returned by a search.
 func (s *LocationService) GetUsersByLocationAndRadius(ctx context.Context, request dto.GetUsersByLocationAndRadiusRequest) (*dto.GetUsersByLocationAndRadiusResponse, error) {
 
-	center := geo.NewPoint(request.Latitude, request.Longitude)
-	pe := center.PointAtDistanceAndBearing(request.Radius, 90)
-	pw := center.PointAtDistanceAndBearing(request.Radius, 270)
-	pn := center.PointAtDistanceAndBearing(request.Radius, 0)
-	ps := center.PointAtDistanceAndBearing(request.Radius, 180)
+	if request.Page == 0 && request.Cursor == "" || request.ItemsLimit == 0 || request.ItemsLimit > maxRadiusItemsLimit {
+		return &dto.GetUsersByLocationAndRadiusResponse{}, respKit.GenericBadRequestError(enums.ErrorPageInvalidCode,
+			fmt.Sprintf(enums.ErrorPageInvalidMsg, maxRadiusItemsLimit))
+	}
 
-	llr := dto.GetByLatitudeLongitudeRangeRequest{
-		LatitudeMin:  ps.Lat(),
-		LatitudeMax:  pn.Lat(),
-		LongitudeMin: pw.Lng(),
-		LongitudeMax: pe.Lng(),
+	var cursor *radiusCursor
+	if request.Cursor != "" {
+		c, err := decodeRadiusCursor(request.Cursor)
+		if err != nil {
+			return &dto.GetUsersByLocationAndRadiusResponse{}, err
+		}
+		cursor = &c
 	}
 
-	ulr, err := s.locationRepository.GetByLatitudeLongitudeRange(ctx, llr)
+	center := geo.NewPoint(request.Latitude, request.Longitude)
+	locations, err := s.locationsInBoundingBox(ctx, radiusBoundingBox(center, request.Radius), updatedSince(request.MaxAge))
 	if err != nil {
 		return &dto.GetUsersByLocationAndRadiusResponse{}, err
 	}
 
-	var previewUsers []dto.Location
-	for _, l := range ulr.Users {
-		pf := geo.NewPoint(l.Latitude, l.Longitude)
-		if center.GreatCircleDistance(pf) <= request.Radius {
-			previewUsers = append(previewUsers, l)
+	var users []dto.Location
+	var distances []float64
+	for _, l := range locations {
+		if d := center.GreatCircleDistance(geo.NewPoint(l.Latitude, l.Longitude)); d <= request.Radius {
+			users = append(users, l)
+			distances = append(distances, d)
 		}
 	}
 
-	var resp dto.GetUsersByLocationAndRadiusResponse
-	var minLimit = (request.Page - 1) * request.ItemsLimit
-	var maxLimit = minLimit + request.ItemsLimit - 1
-	var totalItems = uint64(len(ulr.Users))
-	var totalPages = totalItems / request.ItemsLimit
-	if totalItems%request.ItemsLimit != 0 {
-		totalPages++
+	order := make([]int, len(users))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if distances[a] != distances[b] {
+			return distances[a] < distances[b]
+		}
+		return users[a].UserName < users[b].UserName
+	})
+
+	totalItems := uint64(len(users))
+	start, end, totalPages, err := pageBounds(request.Page, request.ItemsLimit, totalItems)
+	if err != nil {
+		return &dto.GetUsersByLocationAndRadiusResponse{}, err
 	}
-	for i, l := range ulr.Users {
-		if uint64(i) >= minLimit && uint64(i) <= maxLimit {
-			resp.Users = append(resp.Users, l)
+	if cursor != nil {
+		start = uint64(sort.Search(len(order), func(i int) bool {
+			return cursor.after(distances[order[i]], users[order[i]].UserName)
+		}))
+		end = start + request.ItemsLimit
+		if end > totalItems {
+			end = totalItems
 		}
 	}
 
-	resp.TotalItems = totalItems
-	resp.TotalPages = totalPages
+	resp := dto.GetUsersByLocationAndRadiusResponse{
+		Users:      make([]dto.Location, 0, end-start),
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}
+	for _, i := range order[start:end] {
+		resp.Users = append(resp.Users, users[i])
+	}
+
+	if end < totalItems {
+		last := order[end-1]
+		resp.NextCursor = radiusCursor{Distance: distances[last], UserName: users[last].UserName}.encode()
+	}
 
 	return &resp, nil
 }
@@ -132,16 +257,7 @@ func (s *LocationService) GetUsersByLocationAndRadius(ctx context.Context, reque
 // If initial or final date has empty value then time range defaults to 1 day.
 func (s *LocationService) GetDistanceTraveled(ctx context.Context, request dto.GetDistanceTraveledRequest) (*dto.GetDistanceTraveledResponse, error) {
 
-	if request.FinalDate.IsZero() || request.InitialDate.IsZero() {
-		end := time.Now()
-		start := end.Add(-24 * time.Hour)
-		request.InitialDate = start
-		request.FinalDate = end
-	}
-
-	if request.FinalDate.Before(request.InitialDate) {
-		request.InitialDate, request.FinalDate = request.FinalDate, request.InitialDate
-	}
+	request.InitialDate, request.FinalDate = dateRange(request.InitialDate, request.FinalDate)
 
 	dt, err := s.locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctx, request)
 	if err != nil {
@@ -150,3 +266,148 @@ func (s *LocationService) GetDistanceTraveled(ctx context.Context, request dto.G
 
 	return dt, nil
 }
+
+// RebuildDistanceRollups implements business logic of rebuilding the hourly distance
+// rollups of a username, or of every username of the tenant if userName is empty, from
+// their LocationHistory records. Returns the number of stored rollups.
+func (s *LocationService) RebuildDistanceRollups(ctx context.Context, userName string) (int, error) {
+	return s.locationHistoryRepository.RebuildDistanceRollups(ctx, userName)
+}
+
+// RepairLocations implements business logic of rebuilding the current location of a
+// username, or of every username of the tenant if userName is empty, from their latest
+// LocationHistory record. Returns the number of stored locations.
+func (s *LocationService) RepairLocations(ctx context.Context, userName string) (int, error) {
+	return s.locationRepository.RebuildFromHistory(ctx, userName)
+}
+
+// CheckLocations implements business logic of listing the usernames of the tenant whose
+// current location diverges from their latest LocationHistory record.
+func (s *LocationService) CheckLocations(ctx context.Context) ([]dto.LocationDivergence, error) {
+	return s.locationRepository.GetDivergences(ctx, "")
+}
+
+// GetLocationHistory implements business logic of listing the locations of a username,
+// optionally of one of its devices, in a time range by requested page. The locations of
+// the page are simplified by the requested tolerance and maximum number of points as
+// GetRoute does, and encoded as a polyline if requested. Returns username data not found
+// if username has no locations in the range and ErrorHistoryDeviceRequiredCode for
+// simplified or encoded pages with locations of several devices. If initial or final
+// date has empty value then time range defaults to 1 day.
+func (s *LocationService) GetLocationHistory(ctx context.Context, request dto.GetLocationHistoryRequest) (*dto.GetLocationHistoryResponse, error) {
+
+	request.InitialDate, request.FinalDate = dateRange(request.InitialDate, request.FinalDate)
+
+	lh, err := s.locationHistoryRepository.GetHistoryByUserNameAndDateRange(ctx, request)
+	if err != nil {
+		return &dto.GetLocationHistoryResponse{}, err
+	}
+
+	if request.Tolerance == 0 && request.MaxPoints == 0 && !request.Encoded {
+		return lh, nil
+	}
+
+	// a route is drawn for one device
+	route := make([]dto.RoutePoint, len(lh.Locations))
+	for i, l := range lh.Locations {
+		if l.DeviceId != lh.Locations[0].DeviceId {
+			return &dto.GetLocationHistoryResponse{}, respKit.GenericBadRequestError(enums.ErrorHistoryDeviceRequiredCode,
+				enums.ErrorHistoryDeviceRequiredMsg)
+		}
+		route[i] = dto.RoutePoint{Latitude: l.Latitude, Longitude: l.Longitude, UpdatedAt: l.UpdatedAt}
+	}
+
+	kept := simplifiedPoints(route, request.Tolerance, int(request.MaxPoints))
+	locations := make([]dto.LocationHistory, len(kept))
+	points := make([]dto.RoutePoint, len(kept))
+	for i, k := range kept {
+		locations[i] = lh.Locations[k]
+		points[i] = route[k]
+	}
+
+	lh.Locations = locations
+	if request.Encoded {
+		lh.Locations = nil
+		lh.Polyline = encodePolyline(points)
+	}
+
+	return lh, nil
+}
+
+// GetOutlierCount implements business logic of counting the locations of a username,
+// optionally of one of its devices, flagged as outliers in a time range. If initial or
+// final date has empty value then time range defaults to 1 day.
+func (s *LocationService) GetOutlierCount(ctx context.Context, request dto.GetOutlierCountRequest) (*dto.GetOutlierCountResponse, error) {
+
+	request.InitialDate, request.FinalDate = dateRange(request.InitialDate, request.FinalDate)
+
+	oc, err := s.locationHistoryRepository.CountOutliersByUserNameAndDateRange(ctx, request)
+	if err != nil {
+		return &dto.GetOutlierCountResponse{}, err
+	}
+
+	return oc, nil
+}
+
+// dateRange returns the ordered range of initial and final dates. If any of them has
+// empty value then the range defaults to the last day.
+func dateRange(initialDate time.Time, finalDate time.Time) (time.Time, time.Time) {
+	return dateRangeOr(initialDate, finalDate, 24*time.Hour)
+}
+
+// dateRangeOr returns the ordered range of initial and final dates. If any of them has
+// empty value then the range defaults to the last span.
+func dateRangeOr(initialDate time.Time, finalDate time.Time, span time.Duration) (time.Time, time.Time) {
+	if finalDate.IsZero() || initialDate.IsZero() {
+		end := time.Now()
+		start := end.Add(-span)
+		return start, end
+	}
+
+	if finalDate.Before(initialDate) {
+		return finalDate, initialDate
+	}
+
+	return initialDate, finalDate
+}
+
+// updatedSince returns the earliest update date of the locations found by area searches
+// with maximum age maxAge, or the configured maximum age if maxAge is zero. Returns an
+// empty date if both are zero.
+func updatedSince(maxAge time.Duration) time.Time {
+	if maxAge == 0 {
+		maxAge = config.Cfg.LocationMaxAge
+	}
+
+	if maxAge == 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(-maxAge)
+}
+
+// pageBounds returns the bounds of the items of a page of itemsLimit items out of
+// totalItems items, and the number of pages. Pages past the last one are empty. Returns
+// ErrorPageInvalidCode for pages whose first item overflows the offset.
+func pageBounds(page uint64, itemsLimit uint64, totalItems uint64) (uint64, uint64, uint64, error) {
+	if page-1 > math.MaxUint64/itemsLimit {
+		return 0, 0, 0, respKit.GenericBadRequestError(enums.ErrorPageInvalidCode,
+			fmt.Sprintf(enums.ErrorPageOutOfRangeMsg, page, itemsLimit))
+	}
+
+	totalPages := totalItems / itemsLimit
+	if totalItems%itemsLimit != 0 {
+		totalPages++
+	}
+
+	start := (page - 1) * itemsLimit
+	if start > totalItems {
+		start = totalItems
+	}
+	end := start + itemsLimit
+	if end > totalItems {
+		end = totalItems
+	}
+
+	return start, end, totalPages, nil
+}
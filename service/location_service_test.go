@@ -3,23 +3,35 @@ package service
 import (
 	"context"
 	"github.com/go-pg/pg/v10"
-	"github.com/oboadagd/location-common/dto"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/config"
+	histDto "github.com/oboadagd/location-history-mgmt/dto"
+	histEnums "github.com/oboadagd/location-history-mgmt/enums"
 	"github.com/oboadagd/location-history-mgmt/repository"
 	"github.com/oboadagd/location-history-mgmt/testutils"
+	"math"
+	"net/http"
 	"testing"
 	"time"
 )
 
 var db *pg.DB
 
+// newTestLocationService returns a LocationService whose repositories query db.
+func newTestLocationService(db *pg.DB) *LocationService {
+	log := testutils.GetLogger()
+	return NewLocationService(repository.NewLocationRepository(db, log), repository.NewLocationHistoryRepository(db, log),
+		repository.NewLocationFilterStateRepository(db, log), repository.NewTripRepository(db, log),
+		repository.NewStayRepository(db, log), repository.NewEncounterRepository(db, log),
+		repository.NewJobCheckpointRepository(db, log), log).(*LocationService)
+}
+
 func TestSave_Create(t *testing.T) {
 	nameTest := "TestSave_Create"
 	db = testutils.GetTestDB()
 	defer db.Close()
 
-	locationRepository := repository.NewLocationRepository(db)
-	locationHistoryRepository := repository.NewLocationHistoryRepository(db)
-	locationService := NewLocationService(locationRepository, locationHistoryRepository)
+	locationService := newTestLocationService(db)
 
 	ctx := context.Background()
 
@@ -52,9 +64,7 @@ func TestSave_Update(t *testing.T) {
 	db = testutils.GetTestDB()
 	defer db.Close()
 
-	locationRepository := repository.NewLocationRepository(db)
-	locationHistoryRepository := repository.NewLocationHistoryRepository(db)
-	locationService := NewLocationService(locationRepository, locationHistoryRepository)
+	locationService := newTestLocationService(db)
 
 	ctx := context.Background()
 
@@ -81,11 +91,11 @@ func TestSave_Update(t *testing.T) {
 		return
 	}
 
-	llh := dto.GetLastByUserNameRequest{
+	llh := histDto.GetLastByUserNameRequest{
 		UserName: lh.UserName,
 	}
 
-	resp, err := locationHistoryRepository.GetLastByUserName(ctx, llh)
+	resp, err := locationService.locationHistoryRepository.GetLastByUserName(ctx, llh)
 	if err != nil {
 		t.Errorf("%s: %v", nameTest, err)
 		return
@@ -110,9 +120,7 @@ func TestGetUsersByLocationAndRadius(t *testing.T) {
 	db = testutils.GetTestDB()
 	defer db.Close()
 
-	locationRepository := repository.NewLocationRepository(db)
-	locationHistoryRepository := repository.NewLocationHistoryRepository(db)
-	locationService := NewLocationService(locationRepository, locationHistoryRepository)
+	locationService := newTestLocationService(db)
 
 	ctx := context.Background()
 
@@ -131,7 +139,7 @@ func TestGetUsersByLocationAndRadius(t *testing.T) {
 		return
 	}
 
-	ulr := dto.GetUsersByLocationAndRadiusRequest{
+	ulr := histDto.GetUsersByLocationAndRadiusRequest{
 		Latitude:   lh.Latitude,
 		Longitude:  lh.Longitude,
 		Radius:     10,
@@ -160,14 +168,123 @@ func TestGetUsersByLocationAndRadius(t *testing.T) {
 	t.Logf("%s Success", nameTest)
 }
 
+func TestGetUsersByLocationAndRadius_Pagination(t *testing.T) {
+	nameTest := "TestGetUsersByLocationAndRadius_Pagination"
+	db = testutils.GetTestDB()
+	defer db.Close()
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// cornersample is within the bounding box of the circle but out of the circle
+	locations := []histDto.SaveLocationRequest{
+		{UserName: "usernamesample", Latitude: 10, Longitude: 10},
+		{UserName: "nearsample", Latitude: 10.05, Longitude: 10},
+		{UserName: "cornersample", Latitude: 10.085, Longitude: 10.085},
+		{UserName: "samesample", Latitude: 10, Longitude: 10},
+	}
+
+	for _, l := range locations {
+		if err = locationService.Save(ctx, l); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	ulr := histDto.GetUsersByLocationAndRadiusRequest{
+		Latitude:   10,
+		Longitude:  10,
+		Radius:     10,
+		Page:       1,
+		ItemsLimit: 2,
+	}
+
+	type test struct {
+		name   string
+		cursor bool     // whether the request follows the cursor of the previous response
+		answer []string // usernames in order
+		next   bool     // whether the response has a cursor
+	}
+
+	tests := []test{
+		{"first page", false, []string{"samesample", "usernamesample"}, true},
+		{"cursor", true, []string{"nearsample"}, false},
+	}
+
+	var next string
+	for _, v := range tests {
+		req := ulr
+		if v.cursor {
+			req.Page, req.Cursor = 0, next
+		}
+
+		resp, err := locationService.GetUsersByLocationAndRadius(ctx, req)
+		if err != nil {
+			t.Errorf("%s %s: %v", nameTest, v.name, err)
+			return
+		}
+
+		if resp.TotalItems != 3 || resp.TotalPages != 2 || (resp.NextCursor != "") != v.next || len(resp.Users) != len(v.answer) {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, resp)
+			return
+		}
+
+		for i, u := range resp.Users {
+			if u.UserName != v.answer[i] {
+				t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer[i], u.UserName)
+				return
+			}
+		}
+		next = resp.NextCursor
+	}
+
+	type testErr struct {
+		page       uint64
+		itemsLimit uint64
+		cursor     string
+		code       string
+	}
+
+	testsErr := []testErr{
+		{0, 10, "", histEnums.ErrorPageInvalidCode},
+		{1, 0, "", histEnums.ErrorPageInvalidCode},
+		{1, 1001, "", histEnums.ErrorPageInvalidCode},
+		{0, 10, "notacursor", histEnums.ErrorCursorInvalidCode},
+	}
+
+	for _, v := range testsErr {
+		req := ulr
+		req.Page, req.ItemsLimit, req.Cursor = v.page, v.itemsLimit, v.cursor
+
+		_, err := locationService.GetUsersByLocationAndRadius(ctx, req)
+		if e, ok := err.(*respKit.GenericHttpError); !ok || e.ErrorCode != v.code {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.code, err)
+			return
+		}
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
 func TestGetDistanceTraveled(t *testing.T) {
 	nameTest := "GetDistanceTraveled"
 	db = testutils.GetTestDB()
 	defer db.Close()
 
-	locationRepository := repository.NewLocationRepository(db)
-	locationHistoryRepository := repository.NewLocationHistoryRepository(db)
-	locationService := NewLocationService(locationRepository, locationHistoryRepository)
+	locationService := newTestLocationService(db)
 
 	ctx := context.Background()
 
@@ -188,7 +305,7 @@ func TestGetDistanceTraveled(t *testing.T) {
 
 	end := time.Now()
 	start := end.Add(-24 * time.Hour)
-	dtr := dto.GetDistanceTraveledRequest{
+	dtr := histDto.GetDistanceTraveledRequest{
 		UserName:    lh.UserName,
 		InitialDate: start,
 		FinalDate:   end,
@@ -214,3 +331,957 @@ func TestGetDistanceTraveled(t *testing.T) {
 
 	t.Logf("%s Success", nameTest)
 }
+
+func TestSave_Devices(t *testing.T) {
+	nameTest := "TestSave_Devices"
+	db = testutils.GetTestDB()
+	defer db.Close()
+	defer func(policy string) { config.Cfg.CurrentLocationPolicy = policy }(config.Cfg.CurrentLocationPolicy)
+	config.Cfg.CurrentLocationPolicy = PolicyPrimary
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// phone and watch report interleaved fixes, the watch lagging 1 degree behind
+	type test struct {
+		deviceId string
+		latitude float64
+	}
+
+	tests := []test{
+		{"phone", 10},
+		{"watch", 11},
+		{"phone", 12},
+		{"watch", 13},
+	}
+
+	for _, v := range tests {
+		lh := testutils.GetLocation()
+		lh.DeviceId = v.deviceId
+		lh.Latitude = v.latitude
+		if err := locationService.Save(ctx, *lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	// each device traveled 2 degrees of latitude, about 222 km
+	for _, deviceId := range []string{"phone", "watch"} {
+		dtr := histDto.GetDistanceTraveledRequest{
+			UserName: testutils.GetLocation().UserName,
+			DeviceId: deviceId,
+		}
+
+		resp, err := locationService.GetDistanceTraveled(ctx, dtr)
+		if err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+
+		if resp.TotalDistance < 220 || resp.TotalDistance > 225 {
+			t.Errorf("%s: %s Expected %v but got %v", nameTest, deviceId, 222, resp.TotalDistance)
+			return
+		}
+	}
+
+	// the phone registered first, so it's the primary device that drives the current location
+	l, err := locationService.locationRepository.GetByUserName(ctx, testutils.GetLocation().UserName)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if l.DeviceId != "phone" || l.Latitude != 12 {
+		t.Errorf("%s: Expected %v %v but got %v %v", nameTest, "phone", 12, l.DeviceId, l.Latitude)
+		return
+	}
+
+	hr := histDto.GetLocationHistoryRequest{
+		UserName:   testutils.GetLocation().UserName,
+		DeviceId:   "watch",
+		Page:       1,
+		ItemsLimit: 1,
+	}
+
+	hist, err := locationService.GetLocationHistory(ctx, hr)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if hist.TotalItems != 2 || hist.TotalPages != 2 || len(hist.Locations) != 1 || hist.Locations[0].Latitude != 11 {
+		t.Errorf("%s: Expected %v items but got %v", nameTest, 2, hist.TotalItems)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestSave_LowAccuracy(t *testing.T) {
+	nameTest := "TestSave_LowAccuracy"
+	db = testutils.GetTestDB()
+	defer db.Close()
+	defer func(max float64) { config.Cfg.MaxHorizontalAccuracy = max }(config.Cfg.MaxHorizontalAccuracy)
+	config.Cfg.MaxHorizontalAccuracy = 100
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// a 2 km cell tower fix far away between two accurate gps fixes
+	type test struct {
+		latitude float64
+		accuracy float64
+		source   string
+	}
+
+	tests := []test{
+		{10, 5, "gps"},
+		{15, 2000, "cell"},
+		{11, 5, "gps"},
+	}
+
+	for _, v := range tests {
+		accuracy := v.accuracy
+		lh := testutils.GetLocation()
+		lh.Latitude = v.latitude
+		lh.HorizontalAccuracy = &accuracy
+		lh.Source = v.source
+		if err := locationService.Save(ctx, *lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	hr := histDto.GetLocationHistoryRequest{
+		UserName:   testutils.GetLocation().UserName,
+		Page:       1,
+		ItemsLimit: 10,
+	}
+
+	hist, err := locationService.GetLocationHistory(ctx, hr)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if len(hist.Locations) != 3 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 3, len(hist.Locations))
+		return
+	}
+
+	excluded := hist.Locations[1]
+	if excluded.ExcludedReason != histEnums.ExcludedLowAccuracy || excluded.Distance != 0 ||
+		excluded.HorizontalAccuracy == nil || *excluded.HorizontalAccuracy != 2000 || excluded.Source != "cell" {
+		t.Errorf("%s: Expected %v but got %+v", nameTest, histEnums.ExcludedLowAccuracy, excluded)
+		return
+	}
+
+	// the last fix is chained to the first one, 1 degree of latitude away
+	if d := hist.Locations[2].Distance; d < 110 || d > 112 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 111, d)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestSave_Jitter(t *testing.T) {
+	nameTest := "TestSave_Jitter"
+	db = testutils.GetTestDB()
+	defer db.Close()
+	defer func(min float64, kalman bool) {
+		config.Cfg.JitterMinDisplacement = min
+		config.Cfg.KalmanEnabled = kalman
+	}(config.Cfg.JitterMinDisplacement, config.Cfg.KalmanEnabled)
+	config.Cfg.JitterMinDisplacement = 10
+	config.Cfg.KalmanEnabled = true
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// a phone on a desk wobbling a few meters around the same point
+	for _, offset := range []float64{0, 0.00003, -0.00002, 0.00004, -0.00003} {
+		accuracy := 5.0
+		lh := testutils.GetLocation()
+		lh.Latitude += offset
+		lh.HorizontalAccuracy = &accuracy
+		if err := locationService.Save(ctx, *lh); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	dtr := histDto.GetDistanceTraveledRequest{
+		UserName: testutils.GetLocation().UserName,
+	}
+
+	resp, err := locationService.GetDistanceTraveled(ctx, dtr)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if resp.TotalDistance != 0 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 0, resp.TotalDistance)
+		return
+	}
+
+	hr := histDto.GetLocationHistoryRequest{
+		UserName:   testutils.GetLocation().UserName,
+		Page:       1,
+		ItemsLimit: 10,
+	}
+
+	hist, err := locationService.GetLocationHistory(ctx, hr)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	for i, l := range hist.Locations[1:] {
+		if l.ExcludedReason != histEnums.ExcludedJitter || l.FilteredLatitude == nil {
+			t.Errorf("%s: %d Expected %v but got %+v", nameTest, i+1, histEnums.ExcludedJitter, l)
+			return
+		}
+	}
+
+	state, err := locationService.locationFilterStateRepository.GetByUserNameAndDevice(ctx, testutils.GetLocation().UserName, "")
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if state.Variance >= 25 {
+		t.Errorf("%s: Expected variance below %v but got %v", nameTest, 25, state.Variance)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestSave_Outlier(t *testing.T) {
+	nameTest := "TestSave_Outlier"
+	db = testutils.GetTestDB()
+	defer db.Close()
+	defer func(policy string, policies map[string]string) {
+		config.Cfg.OutlierPolicy = policy
+		config.Cfg.OutlierPolicies = policies
+	}(config.Cfg.OutlierPolicy, config.Cfg.OutlierPolicies)
+	config.Cfg.OutlierPolicy = OutlierFlag
+	config.Cfg.OutlierPolicies = map[string]string{"car": OutlierReject}
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// jumps of 20 degrees within a second are implausible, flagged when walking and rejected when driving
+	type test struct {
+		latitude      float64
+		transportMode string
+		status        int
+	}
+
+	tests := []test{
+		{10, "walk", 0},
+		{30, "walk", 0},
+		{30, "car", http.StatusUnprocessableEntity},
+	}
+
+	for _, v := range tests {
+		lh := testutils.GetLocation()
+		lh.Latitude = v.latitude
+		lh.Longitude = v.latitude
+		lh.TransportMode = v.transportMode
+
+		err := locationService.Save(ctx, *lh)
+
+		status := 0
+		if httpErr, ok := err.(*respKit.GenericHttpError); ok {
+			status = httpErr.Status
+		} else if err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+
+		if status != v.status {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.status, err)
+			return
+		}
+	}
+
+	oc, err := locationService.GetOutlierCount(ctx, histDto.GetOutlierCountRequest{UserName: testutils.GetLocation().UserName})
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if oc.Outliers != 1 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 1, oc.Outliers)
+		return
+	}
+
+	// outliers don't move the current location
+	l, err := locationService.locationRepository.GetByUserName(ctx, testutils.GetLocation().UserName)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if l.Latitude != 10 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 10, l.Latitude)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestSave_Trips(t *testing.T) {
+	nameTest := "TestSave_Trips"
+	db = testutils.GetTestDB()
+	defer db.Close()
+	defer func(policy string, policies map[string]string, dwell time.Duration, gap time.Duration) {
+		config.Cfg.OutlierPolicy = policy
+		config.Cfg.OutlierPolicies = policies
+		config.Cfg.TripDwellTime = dwell
+		config.Cfg.TripGapTime = gap
+	}(config.Cfg.OutlierPolicy, config.Cfg.OutlierPolicies, config.Cfg.TripDwellTime, config.Cfg.TripGapTime)
+
+	// fixes are saved milliseconds apart, so speeds are not checked
+	config.Cfg.OutlierPolicy = OutlierAccept
+	config.Cfg.OutlierPolicies = nil
+	config.Cfg.TripDwellTime = time.Hour
+	config.Cfg.TripGapTime = time.Hour
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// the device stands still, then moves 0.02 degrees of latitude, about 2.2 km
+	for _, latitude := range []float64{10, 10, 10.01, 10.02} {
+		l := testutils.GetLocation()
+		l.Latitude = latitude
+		if err := locationService.Save(ctx, *l); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	ltr := histDto.ListTripsRequest{
+		UserName:   testutils.GetLocation().UserName,
+		Page:       1,
+		ItemsLimit: 10,
+	}
+
+	lt, err := locationService.ListTrips(ctx, ltr)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if lt.TotalItems != 1 || len(lt.Trips) != 1 {
+		t.Errorf("%s: Expected %v trips but got %v", nameTest, 1, lt.TotalItems)
+		return
+	}
+
+	trip := lt.Trips[0]
+	if trip.Status != histEnums.TripOpen || trip.StartLatitude != 10 || trip.EndLatitude != 10.02 || trip.Distance < 2.2 || trip.Distance > 2.25 {
+		t.Errorf("%s: Expected open trip from %v to %v but got %+v", nameTest, 10, 10.02, trip)
+		return
+	}
+
+	gt, err := locationService.GetTrip(ctx, histDto.GetTripRequest{UserName: ltr.UserName, TripId: trip.Id})
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if gt.Id != trip.Id || gt.PointCount != 3 {
+		t.Errorf("%s: Expected %v points but got %v", nameTest, 3, gt.PointCount)
+		return
+	}
+
+	_, err = locationService.GetTrip(ctx, histDto.GetTripRequest{UserName: ltr.UserName, TripId: trip.Id + 1})
+	if errResp, ok := err.(*respKit.GenericHttpError); !ok || errResp.Status != http.StatusNotFound {
+		t.Errorf("%s: Expected %v but got %v", nameTest, http.StatusNotFound, err)
+		return
+	}
+
+	// rebuilding from the location history yields the same trip
+	n, err := locationService.BackfillTrips(ctx, ltr.UserName)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	lt, err = locationService.ListTrips(ctx, ltr)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if n != 1 || len(lt.Trips) != 1 || lt.Trips[0].PointCount != 3 || math.Abs(lt.Trips[0].Distance-trip.Distance) > 1e-9 {
+		t.Errorf("%s: Expected %v trip of %v km but got %v %+v", nameTest, 1, trip.Distance, n, lt.Trips)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestSave_Stays(t *testing.T) {
+	nameTest := "TestSave_Stays"
+	db = testutils.GetTestDB()
+	defer db.Close()
+	defer func(policy string, policies map[string]string, minDuration time.Duration) {
+		config.Cfg.OutlierPolicy = policy
+		config.Cfg.OutlierPolicies = policies
+		config.Cfg.StayMinDuration = minDuration
+	}(config.Cfg.OutlierPolicy, config.Cfg.OutlierPolicies, config.Cfg.StayMinDuration)
+
+	// fixes are saved milliseconds apart, so speeds are not checked and any stay lasts enough
+	config.Cfg.OutlierPolicy = OutlierAccept
+	config.Cfg.OutlierPolicies = nil
+	config.Cfg.StayMinDuration = time.Nanosecond
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// the device stays home, goes to the office, stays there and comes back home
+	for _, latitude := range []float64{10, 10.0001, 10.1, 10.1001, 10, 10.0002} {
+		l := testutils.GetLocation()
+		l.Latitude = latitude
+		if err := locationService.Save(ctx, *l); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	lsr := histDto.ListStaysRequest{
+		UserName:   testutils.GetLocation().UserName,
+		Page:       1,
+		ItemsLimit: 10,
+	}
+
+	ls, err := locationService.ListStays(ctx, lsr)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	statuses := []string{histEnums.StayClosed, histEnums.StayClosed, histEnums.StayOpen}
+	if len(ls.Stays) != len(statuses) {
+		t.Errorf("%s: Expected %v stays but got %v", nameTest, len(statuses), len(ls.Stays))
+		return
+	}
+
+	for i, st := range ls.Stays {
+		if st.Status != statuses[i] || st.PointCount != 2 {
+			t.Errorf("%s: Expected %v stay of %v points but got %+v", nameTest, statuses[i], 2, st)
+			return
+		}
+	}
+
+	fp, err := locationService.GetFrequentPlaces(ctx, histDto.GetFrequentPlacesRequest{UserName: lsr.UserName, Limit: 10})
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if len(fp.Places) != 2 || fp.Places[0].Visits != 2 || math.Abs(fp.Places[0].Latitude-10) > 0.001 {
+		t.Errorf("%s: Expected home visited %v times but got %+v", nameTest, 2, fp.Places)
+		return
+	}
+
+	// rebuilding from the location history yields the same stays
+	n, err := locationService.BackfillStays(ctx, lsr.UserName)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	ls, err = locationService.ListStays(ctx, lsr)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if n != len(statuses) || len(ls.Stays) != len(statuses) {
+		t.Errorf("%s: Expected %v stays but got %v %v", nameTest, len(statuses), n, len(ls.Stays))
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestUpdatedSince(t *testing.T) {
+	nameTest := "TestUpdatedSince"
+
+	defaultMaxAge := config.Cfg.LocationMaxAge
+	defer func() { config.Cfg.LocationMaxAge = defaultMaxAge }()
+
+	type test struct {
+		configured time.Duration
+		maxAge     time.Duration
+		answer     time.Duration // expected age of the date, zero for an empty date
+	}
+
+	tests := []test{
+		{24 * time.Hour, 0, 24 * time.Hour},
+		{24 * time.Hour, time.Hour, time.Hour},
+		{0, time.Hour, time.Hour},
+		{0, 0, 0},
+	}
+
+	for _, v := range tests {
+		config.Cfg.LocationMaxAge = v.configured
+		since := updatedSince(v.maxAge)
+		if v.answer == 0 {
+			if !since.IsZero() {
+				t.Errorf("%s: Expected %v but got %v", nameTest, time.Time{}, since)
+				return
+			}
+			continue
+		}
+
+		if age := time.Since(since); age < v.answer || age > v.answer+time.Minute {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, age)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestPageBounds(t *testing.T) {
+	nameTest := "TestPageBounds"
+
+	type test struct {
+		page       uint64
+		itemsLimit uint64
+		start      uint64
+		end        uint64
+		status     int
+	}
+
+	tests := []test{
+		{1, 10, 0, 10, 0},
+		{3, 10, 20, 25, 0},
+		{4, 10, 25, 25, 0},
+		{math.MaxUint64/10 + 1, 10, 25, 25, 0},
+		{math.MaxUint64/10 + 2, 10, 0, 0, http.StatusBadRequest},
+		{math.MaxUint64, 2, 0, 0, http.StatusBadRequest},
+	}
+
+	for i, v := range tests {
+		start, end, totalPages, err := pageBounds(v.page, v.itemsLimit, 25)
+
+		status := 0
+		if httpErr, ok := err.(*respKit.GenericHttpError); ok {
+			status = httpErr.Status
+		}
+
+		if status != v.status || start != v.start || end != v.end || err == nil && totalPages != 3 {
+			t.Errorf("%s: test %d expected %v %v %v but got %v %v %v", nameTest, i, v.start, v.end, v.status, start, end, err)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestRecomputeDistances(t *testing.T) {
+	nameTest := "TestRecomputeDistances"
+	db = testutils.GetTestDB()
+	defer db.Close()
+	defer func(policy string, policies map[string]string) {
+		config.Cfg.OutlierPolicy = policy
+		config.Cfg.OutlierPolicies = policies
+	}(config.Cfg.OutlierPolicy, config.Cfg.OutlierPolicies)
+
+	// fixes are saved milliseconds apart, so speeds are not checked
+	config.Cfg.OutlierPolicy = OutlierAccept
+	config.Cfg.OutlierPolicies = nil
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// three locations about 1.112 kilometers apart
+	for _, lat := range []float64{10, 10.01, 10.02} {
+		l := testutils.GetLocation()
+		l.Latitude = lat
+		if err := locationService.Save(ctx, *l); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	userName := testutils.GetLocation().UserName
+	hist, err := locationService.GetLocationHistory(ctx, histDto.GetLocationHistoryRequest{UserName: userName, Page: 1, ItemsLimit: 10})
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	for _, lh := range hist.Locations {
+		if lh.DistanceAlgorithm != DistanceHaversine {
+			t.Errorf("%s: Expected %v but got %v", nameTest, DistanceHaversine, lh.DistanceAlgorithm)
+			return
+		}
+	}
+
+	// corrupt the distance of the second location and the current location
+	corrupted := []histDto.DistanceDiscrepancy{{LocationHistoryId: hist.Locations[1].Id, Recomputed: 5}}
+	if err = locationService.locationHistoryRepository.UpdateDistances(ctx, corrupted); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	wrong := histDto.SaveLocationRequest{UserName: userName, Latitude: 10.02, Longitude: 20}
+	if err = locationService.locationRepository.UpdateByUserName(ctx, wrong, userName); err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	type test struct {
+		name          string
+		dryRun        bool
+		discrepancies int
+		divergences   int
+	}
+
+	tests := []test{
+		{"dry run", true, 1, 1},
+		{"dry run changes nothing", true, 1, 1},
+		{"fix", false, 1, 1},
+		{"fixed", true, 0, 0},
+	}
+
+	for _, v := range tests {
+		resp, err := locationService.RecomputeDistances(ctx, histDto.RecomputeDistancesRequest{DryRun: v.dryRun})
+		if err != nil {
+			t.Errorf("%s %s: %v", nameTest, v.name, err)
+			return
+		}
+
+		if resp.UserNames != 1 || resp.Records != 3 || len(resp.Discrepancies) != v.discrepancies || len(resp.Divergences) != v.divergences {
+			t.Errorf("%s %s: Expected %v %v but got %+v", nameTest, v.name, v.discrepancies, v.divergences, resp)
+			return
+		}
+
+		if v.discrepancies > 0 && (resp.Discrepancies[0].Stored != 5 || math.Abs(resp.Discrepancies[0].Recomputed-1.112) > 0.001 ||
+			resp.Discrepancies[0].Algorithm != DistanceHaversine) {
+			t.Errorf("%s %s: Expected %v but got %+v", nameTest, v.name, 1.112, resp.Discrepancies[0])
+			return
+		}
+	}
+
+	// completed runs leave no checkpoint behind
+	c, err := locationService.jobCheckpointRepository.Get(ctx, recomputeJob)
+	if err != nil || c != nil {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, nil, c, err)
+		return
+	}
+
+	l, err := locationService.locationRepository.GetByUserName(ctx, userName)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if l.Latitude != 10.02 || l.Longitude != 10 {
+		t.Errorf("%s: Expected %v %v but got %v %v", nameTest, 10.02, 10, l.Latitude, l.Longitude)
+		return
+	}
+
+	dt, err := locationService.GetDistanceTraveled(ctx, histDto.GetDistanceTraveledRequest{UserName: userName})
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	if math.Abs(dt.TotalDistance-2.224) > 0.002 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 2.224, dt.TotalDistance)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestGetRoute(t *testing.T) {
+	nameTest := "TestGetRoute"
+	db = testutils.GetTestDB()
+	defer db.Close()
+	defer func(policy string, policies map[string]string) {
+		config.Cfg.OutlierPolicy = policy
+		config.Cfg.OutlierPolicies = policies
+	}(config.Cfg.OutlierPolicy, config.Cfg.OutlierPolicies)
+
+	// fixes are saved milliseconds apart, so speeds are not checked
+	config.Cfg.OutlierPolicy = OutlierAccept
+	config.Cfg.OutlierPolicies = nil
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// five locations in a straight line north about 1.112 kilometers apart
+	for _, lat := range []float64{10, 10.01, 10.02, 10.03, 10.04} {
+		l := testutils.GetLocation()
+		l.Latitude = lat
+		if err := locationService.Save(ctx, *l); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	type test struct {
+		name      string
+		tolerance float64
+		encoded   bool
+		answer    uint64
+	}
+
+	tests := []test{
+		{"unsimplified", 0, false, 5},
+		{"simplified", 1, false, 2},
+		{"encoded", 1, true, 2},
+	}
+
+	for _, v := range tests {
+		req := histDto.GetRouteRequest{UserName: testutils.GetLocation().UserName, Tolerance: v.tolerance, Encoded: v.encoded}
+		resp, err := locationService.GetRoute(ctx, req)
+		if err != nil {
+			t.Errorf("%s %s: %v", nameTest, v.name, err)
+			return
+		}
+
+		// the total distance is accumulated from every location
+		if resp.PointCount != v.answer || resp.TotalPoints != 5 || math.Abs(resp.TotalDistance-4.448) > 0.002 {
+			t.Errorf("%s %s: Expected %v but got %+v", nameTest, v.name, v.answer, resp)
+			return
+		}
+
+		if v.encoded && (resp.Polyline == "" || resp.Points != nil) || !v.encoded && uint64(len(resp.Points)) != v.answer {
+			t.Errorf("%s %s: Expected %v but got %+v", nameTest, v.name, v.answer, resp)
+			return
+		}
+	}
+
+	// pages of the history are simplified the same way
+	for _, v := range tests {
+		req := histDto.GetLocationHistoryRequest{UserName: testutils.GetLocation().UserName, Page: 1, ItemsLimit: 10,
+			Tolerance: v.tolerance, Encoded: v.encoded}
+		resp, err := locationService.GetLocationHistory(ctx, req)
+		if err != nil || resp.TotalItems != 5 {
+			t.Errorf("%s history %s: Expected %v but got %+v %v", nameTest, v.name, 5, resp, err)
+			return
+		}
+
+		if v.encoded && (resp.Polyline == "" || resp.Locations != nil) || !v.encoded && uint64(len(resp.Locations)) != v.answer {
+			t.Errorf("%s history %s: Expected %v but got %+v", nameTest, v.name, v.answer, resp)
+			return
+		}
+	}
+
+	_, err = locationService.GetRoute(ctx, histDto.GetRouteRequest{UserName: "unknownsample"})
+	if errResp, ok := err.(*respKit.GenericHttpError); !ok || errResp.Status != http.StatusNotFound {
+		t.Errorf("%s: Expected %v but got %v", nameTest, http.StatusNotFound, err)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestPruneHistory(t *testing.T) {
+	nameTest := "TestPruneHistory"
+	db = testutils.GetTestDB()
+	defer db.Close()
+	defer func(policy string, policies map[string]string, period time.Duration, mode string) {
+		config.Cfg.OutlierPolicy = policy
+		config.Cfg.OutlierPolicies = policies
+		config.Cfg.RetentionPeriod = period
+		config.Cfg.RetentionMode = mode
+	}(config.Cfg.OutlierPolicy, config.Cfg.OutlierPolicies, config.Cfg.RetentionPeriod, config.Cfg.RetentionMode)
+
+	// fixes are saved milliseconds apart, so speeds are not checked
+	config.Cfg.OutlierPolicy = OutlierAccept
+	config.Cfg.OutlierPolicies = nil
+	config.Cfg.RetentionPeriod = 24 * time.Hour
+	config.Cfg.RetentionMode = RetentionDelete
+
+	locationService := newTestLocationService(db)
+
+	ctx := context.Background()
+
+	err := testutils.CreateSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	// three locations about 1.112 kilometers apart
+	for _, lat := range []float64{10, 10.01, 10.02} {
+		l := testutils.GetLocation()
+		l.Latitude = lat
+		if err := locationService.Save(ctx, *l); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	// the history and its rollups age three days
+	for _, q := range []string{
+		"UPDATE location_history SET updated_at = updated_at - interval '3 days'",
+		"UPDATE distance_rollup SET hour = hour - interval '3 days'",
+	} {
+		if _, err := db.Exec(q); err != nil {
+			t.Errorf("%s: %v", nameTest, err)
+			return
+		}
+	}
+
+	userName := testutils.GetLocation().UserName
+	initialDate := time.Now().Add(-4 * 24 * time.Hour).Truncate(time.Hour)
+	finalDate := time.Now().Add(-2 * 24 * time.Hour).Truncate(time.Hour)
+	before, err := locationService.GetDistanceTraveled(ctx, histDto.GetDistanceTraveledRequest{UserName: userName, InitialDate: initialDate, FinalDate: finalDate})
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	type test struct {
+		name   string
+		dryRun bool
+		answer int
+	}
+
+	// the dry run only reports, and pruning twice prunes once
+	tests := []test{
+		{"dry run", true, 3},
+		{"prune", false, 3},
+		{"pruned", false, 0},
+	}
+
+	for _, v := range tests {
+		resp, err := locationService.PruneHistory(ctx, histDto.PruneHistoryRequest{UserName: userName, DryRun: v.dryRun})
+		if err != nil || resp.Records != v.answer || resp.Pruned == v.dryRun {
+			t.Errorf("%s %s: Expected %v but got %+v %v", nameTest, v.name, v.answer, resp, err)
+			return
+		}
+	}
+
+	// the pruned distance is still summed from the rollups
+	after, err := locationService.GetDistanceTraveled(ctx, histDto.GetDistanceTraveledRequest{UserName: userName, InitialDate: initialDate, FinalDate: finalDate})
+	if err != nil || math.Abs(after.TotalDistance-before.TotalDistance) > 1e-9 || before.TotalDistance == 0 {
+		t.Errorf("%s: Expected %v but got %v %v", nameTest, before.TotalDistance, after.TotalDistance, err)
+		return
+	}
+
+	err = testutils.DropSchema(db)
+	if err != nil {
+		t.Errorf("%s: %v", nameTest, err)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	geo "github.com/kellydunn/golang-geo"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"math"
+	"net/http"
+	"time"
+)
+
+// movement is the result of filtering a reported location against the previous
+// location of the same device.
+type movement struct {
+	distance  float64                        // traveled distance in kilometers to accumulate
+	reason    string                         // reason to exclude the location from distance accumulation. Empty for included locations
+	filtered  *geo.Point                     // coordinates smoothed by the Kalman filter. Nil when smoothing is disabled
+	state     *dto.LocationFilterState       // Kalman filter state of the device after the location, persisted once the location is stored. Nil when smoothing is disabled
+	algorithm string                         // distance algorithm configured for the tenant
+	last      *dto.GetLastByUserNameResponse // last accumulated location of the device. Nil for its first location or locations excluded by accuracy
+}
+
+// measureMovement filters the location reported by request at now. Locations less
+// accurate than the configured maximum are excluded. Locations whose implied speed from
+// the last accumulated location of the device exceeds the maximum of the transport mode
+// are rejected with ErrorImplausibleSpeedCode or excluded as outliers according to the
+// outlier policy. Otherwise, when smoothing is enabled the location is smoothed with the
+// Kalman filter state of the device, and the distance from the last accumulated location
+// is computed. Displacements within the jitter threshold are excluded as jitter, so the
+// last accumulated location stays as the start of the next distance and slow real
+// movement is still accumulated.
+func (s *LocationService) measureMovement(ctx context.Context, request dto.SaveLocationRequest, now time.Time) (movement, error) {
+	calculator := distanceCalculator(ctx)
+	m := movement{algorithm: calculator.Name()}
+	if m.reason = exclusionReason(request, config.Cfg.MaxHorizontalAccuracy); m.reason != "" {
+		return m, nil
+	}
+
+	llh := dto.GetLastByUserNameRequest{
+		UserName: request.UserName,
+		DeviceId: request.DeviceId,
+	}
+
+	last, err := s.locationHistoryRepository.GetLastByUserName(ctx, llh)
+	if isNotFound(err) {
+		last = nil
+	} else if err != nil {
+		return m, err
+	}
+
+	m.last = last
+	point := geo.NewPoint(request.Latitude, request.Longitude)
+	if last != nil {
+		speed := impliedSpeed(calculator.Distance(geo.NewPoint(last.Latitude, last.Longitude), point), now.Sub(last.UpdatedAt))
+		if policy, maxSpeed := outlierLimits(request.TransportMode); maxSpeed > 0 && speed > maxSpeed {
+			outliers.WithLabelValues(request.TransportMode, policy).Inc()
+			switch policy {
+			case OutlierReject:
+				return m, respKit.NewGenericHttpError(http.StatusUnprocessableEntity, enums.ErrorImplausibleSpeedCode,
+					fmt.Errorf(enums.ErrorImplausibleSpeedMsg, speed, maxSpeed, request.TransportMode))
+			case OutlierFlag:
+				m.reason = enums.ExcludedOutlier
+				return m, nil
+			}
+		}
+	}
+
+	if config.Cfg.KalmanEnabled {
+		state, err := s.smooth(ctx, request, now)
+		if err != nil {
+			return m, err
+		}
+		point = geo.NewPoint(state.Latitude, state.Longitude)
+		m.filtered = point
+		m.state = &state
+	}
+
+	if last == nil {
+		return m, nil
+	}
+
+	anchor := geo.NewPoint(last.Latitude, last.Longitude)
+	if m.filtered != nil && last.FilteredLatitude != nil && last.FilteredLongitude != nil {
+		anchor = geo.NewPoint(*last.FilteredLatitude, *last.FilteredLongitude)
+	}
+
+	d := calculator.Distance(anchor, point)
+	threshold := jitterThreshold(last.HorizontalAccuracy, request.HorizontalAccuracy,
+		config.Cfg.JitterMinDisplacement, config.Cfg.JitterAccuracyFactor)
+	if d*1000 < threshold {
+		m.reason = enums.ExcludedJitter
+		return m, nil
+	}
+
+	m.distance = d
+	return m, nil
+}
+
+// smooth updates the Kalman filter state of the device of request with the reported
+// location and returns the new state. The state isn't persisted, so a location that
+// fails to be stored doesn't move the filter.
+func (s *LocationService) smooth(ctx context.Context, request dto.SaveLocationRequest, now time.Time) (dto.LocationFilterState, error) {
+	state, err := s.locationFilterStateRepository.GetByUserNameAndDevice(ctx, request.UserName, request.DeviceId)
+	if isNotFound(err) {
+		state = nil
+	} else if err != nil {
+		return dto.LocationFilterState{}, err
+	}
+
+	next := kalmanUpdate(state, request.Latitude, request.Longitude, request.HorizontalAccuracy, now,
+		config.Cfg.KalmanProcessNoise, config.Cfg.KalmanDefaultAccuracy)
+	next.UserName = request.UserName
+	next.DeviceId = request.DeviceId
+
+	return next, nil
+}
+
+// jitterThreshold returns the displacement in meters below which a location is jitter
+// of the previous one. It's the larger of minDisplacement and factor times the combined
+// horizontal accuracy of both locations. Missing accuracies count as zero.
+func jitterThreshold(previous *float64, current *float64, minDisplacement float64, factor float64) float64 {
+	var combined float64
+	for _, a := range []*float64{previous, current} {
+		if a != nil {
+			combined += *a * *a
+		}
+	}
+
+	return math.Max(minDisplacement, factor*math.Sqrt(combined))
+}
+
+// kalmanUpdate returns the state of the Kalman filter after the location at latitude and
+// longitude with horizontal accuracy reported at now. A nil state is initialized with the
+// location. The variance of the position grows with the time elapsed since the previous
+// location at processNoise meters per second, and locations without accuracy are
+// weighted with defaultAccuracy.
+func kalmanUpdate(state *dto.LocationFilterState, latitude float64, longitude float64, accuracy *float64, now time.Time, processNoise float64, defaultAccuracy float64) dto.LocationFilterState {
+	a := defaultAccuracy
+	if accuracy != nil && *accuracy > 0 {
+		a = *accuracy
+	}
+	measurementVariance := a * a
+
+	if state == nil {
+		return dto.LocationFilterState{
+			Latitude:  latitude,
+			Longitude: longitude,
+			Variance:  measurementVariance,
+			UpdatedAt: now,
+		}
+	}
+
+	next := *state
+	if dt := now.Sub(state.UpdatedAt).Seconds(); dt > 0 {
+		next.Variance += dt * processNoise * processNoise
+	}
+
+	gain := next.Variance / (next.Variance + measurementVariance)
+	next.Latitude += gain * (latitude - next.Latitude)
+	next.Longitude = normalizeLongitude(next.Longitude + gain*normalizeLongitude(longitude-next.Longitude))
+	next.Variance = (1 - gain) * next.Variance
+	next.UpdatedAt = now
+
+	return next
+}
+
+// normalizeLongitude returns longitude wrapped to the range [-180 to 180).
+func normalizeLongitude(longitude float64) float64 {
+	return math.Mod(math.Mod(longitude+180, 360)+360, 360) - 180
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestJitterThreshold(t *testing.T) {
+	nameTest := "TestJitterThreshold"
+	accuracy := func(v float64) *float64 { return &v }
+
+	type test struct {
+		previous *float64
+		current  *float64
+		factor   float64
+		answer   float64
+	}
+
+	tests := []test{
+		{nil, nil, 1, 10},
+		{accuracy(3), accuracy(4), 1, 10},
+		{accuracy(30), accuracy(40), 1, 50},
+		{accuracy(30), accuracy(40), 2, 100},
+		{accuracy(30), nil, 1, 30},
+		{accuracy(30), accuracy(40), 0, 10},
+	}
+
+	for _, v := range tests {
+		if got := jitterThreshold(v.previous, v.current, 10, v.factor); math.Abs(got-v.answer) > 1e-9 {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestKalmanUpdate(t *testing.T) {
+	nameTest := "TestKalmanUpdate"
+	accuracy := func(v float64) *float64 { return &v }
+	now := time.Now()
+
+	state := kalmanUpdate(nil, 10, 10, accuracy(10), now, 3, 20)
+	if state.Latitude != 10 || state.Longitude != 10 || state.Variance != 100 {
+		t.Errorf("%s: Expected %v but got %+v", nameTest, "initial state", state)
+		return
+	}
+
+	// an equally accurate location at the same instant is averaged with the state
+	next := kalmanUpdate(&state, 10.0002, 10, accuracy(10), now, 3, 20)
+	if math.Abs(next.Latitude-10.0001) > 1e-9 || math.Abs(next.Variance-50) > 1e-9 {
+		t.Errorf("%s: Expected %v %v but got %v %v", nameTest, 10.0001, 50, next.Latitude, next.Variance)
+		return
+	}
+
+	// a much less accurate location barely moves the state
+	noisy := kalmanUpdate(&state, 10.01, 10, accuracy(1000), now, 3, 20)
+	if noisy.Latitude-10 > 0.0001 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 10, noisy.Latitude)
+		return
+	}
+
+	// after a long time the state follows the new location
+	later := kalmanUpdate(&state, 11, 10, accuracy(10), now.Add(time.Hour), 3, 20)
+	if math.Abs(later.Latitude-11) > 0.01 || !later.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 11, later.Latitude)
+		return
+	}
+
+	// smoothing across the antimeridian stays near it
+	east := dto.LocationFilterState{Latitude: 0, Longitude: 179.9999, Variance: 100, UpdatedAt: now}
+	west := kalmanUpdate(&east, 0, -179.9999, accuracy(10), now, 3, 20)
+	if math.Abs(math.Abs(west.Longitude)-180) > 0.001 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 180, west.Longitude)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestNormalizeLongitude(t *testing.T) {
+	nameTest := "TestNormalizeLongitude"
+
+	type test struct {
+		longitude float64
+		answer    float64
+	}
+
+	tests := []test{
+		{0, 0},
+		{179, 179},
+		{180, -180},
+		{181, -179},
+		{-181, 179},
+		{359.5, -0.5},
+		{-540, -180},
+	}
+
+	for _, v := range tests {
+		if got := normalizeLongitude(v.longitude); math.Abs(got-v.answer) > 1e-9 {
+			t.Errorf("%s: %v Expected %v but got %v", nameTest, v.longitude, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
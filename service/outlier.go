@@ -0,0 +1,60 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+// Outlier policies. They decide what happens to a location whose implied speed from the
+// previous location of the device exceeds the maximum of its transport mode.
+const (
+	OutlierReject = "reject" // the location is rejected with ErrorImplausibleSpeedCode and not stored
+	OutlierFlag   = "flag"   // the location is stored flagged as outlier and excluded from distances
+	OutlierAccept = "accept" // the location is accumulated as any other
+)
+
+// outliers counts the locations detected as outliers.
+var outliers = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "location_history_outliers_total",
+	Help: "Number of locations whose implied speed exceeded the maximum of their transport mode.",
+}, []string{"transport_mode", "policy"})
+
+// IsOutlierPolicy returns true if policy is a known outlier policy.
+func IsOutlierPolicy(policy string) bool {
+	switch policy {
+	case OutlierReject, OutlierFlag, OutlierAccept:
+		return true
+	}
+
+	return false
+}
+
+// outlierLimits returns the outlier policy and the maximum speed in meters per second
+// configured for transportMode, falling back to the defaults of every mode.
+func outlierLimits(transportMode string) (string, float64) {
+	policy := config.Cfg.OutlierPolicy
+	if p, ok := config.Cfg.OutlierPolicies[transportMode]; ok {
+		policy = p
+	}
+
+	maxSpeed := config.Cfg.OutlierMaxSpeed
+	if s, ok := config.Cfg.OutlierMaxSpeeds[transportMode]; ok {
+		maxSpeed = s
+	}
+
+	return policy, maxSpeed
+}
+
+// impliedSpeed returns the speed in meters per second needed to travel distance
+// kilometers in elapsed. Elapsed times below a second count as one second, so
+// simultaneous locations far apart are still implausible.
+func impliedSpeed(distance float64, elapsed time.Duration) float64 {
+	seconds := elapsed.Seconds()
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return distance * 1000 / seconds
+}
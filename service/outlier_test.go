@@ -0,0 +1,85 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/config"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestImpliedSpeed(t *testing.T) {
+	nameTest := "TestImpliedSpeed"
+
+	type test struct {
+		distance float64
+		elapsed  time.Duration
+		answer   float64
+	}
+
+	tests := []test{
+		{0, time.Minute, 0},
+		{1.2, time.Minute, 20},
+		{36, time.Hour, 10},
+		{1, 0, 1000},
+		{1, -time.Minute, 1000},
+	}
+
+	for _, v := range tests {
+		if got := impliedSpeed(v.distance, v.elapsed); math.Abs(got-v.answer) > 1e-9 {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestOutlierLimits(t *testing.T) {
+	nameTest := "TestOutlierLimits"
+	defer func(policy string, policies map[string]string, maxSpeed float64, maxSpeeds map[string]float64) {
+		config.Cfg.OutlierPolicy = policy
+		config.Cfg.OutlierPolicies = policies
+		config.Cfg.OutlierMaxSpeed = maxSpeed
+		config.Cfg.OutlierMaxSpeeds = maxSpeeds
+	}(config.Cfg.OutlierPolicy, config.Cfg.OutlierPolicies, config.Cfg.OutlierMaxSpeed, config.Cfg.OutlierMaxSpeeds)
+
+	config.Cfg.OutlierPolicy = OutlierFlag
+	config.Cfg.OutlierPolicies = map[string]string{"plane": OutlierAccept, "walk": OutlierReject}
+	config.Cfg.OutlierMaxSpeed = 100
+	config.Cfg.OutlierMaxSpeeds = map[string]float64{"walk": 7, "plane": 300}
+
+	type test struct {
+		transportMode string
+		policy        string
+		maxSpeed      float64
+	}
+
+	tests := []test{
+		{"", OutlierFlag, 100},
+		{"car", OutlierFlag, 100},
+		{"walk", OutlierReject, 7},
+		{"plane", OutlierAccept, 300},
+	}
+
+	for _, v := range tests {
+		policy, maxSpeed := outlierLimits(v.transportMode)
+		if policy != v.policy || maxSpeed != v.maxSpeed {
+			t.Errorf("%s: %q Expected %v %v but got %v %v", nameTest, v.transportMode, v.policy, v.maxSpeed, policy, maxSpeed)
+			return
+		}
+	}
+
+	for _, p := range []string{OutlierReject, OutlierFlag, OutlierAccept} {
+		if !IsOutlierPolicy(p) {
+			t.Errorf("%s: Expected %v but got %v", nameTest, true, false)
+			return
+		}
+	}
+
+	if IsOutlierPolicy("drop") {
+		t.Errorf("%s: Expected %v but got %v", nameTest, false, true)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
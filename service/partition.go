@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+// droppedPartitions counts the location history partitions dropped by retention.
+var droppedPartitions = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "location_history_dropped_partitions_total",
+	Help: "Number of monthly location history partitions dropped by retention.",
+})
+
+// partitionCutoff returns the earliest retention cutoff of every tenant and username,
+// before which whole partitions are expired, or the zero date if some history is kept
+// forever. Usernames without a period of their own or of their tenant have the default
+// period, so the default is always considered.
+func partitionCutoff(now time.Time) time.Time {
+	period := config.Cfg.RetentionPeriod
+	if period <= 0 {
+		return time.Time{}
+	}
+
+	for _, p := range config.Cfg.RetentionPeriods {
+		if p <= 0 {
+			return time.Time{}
+		}
+
+		if p > period {
+			period = p
+		}
+	}
+
+	return retentionCutoff(period, now)
+}
+
+// MaintainPartitions implements business logic of the partition maintenance worker,
+// creating the monthly partitions of the location history from the current month to
+// config.Cfg.PartitionMonthsAhead months ahead. Returns the names of the partitions.
+func (s *LocationService) MaintainPartitions(ctx context.Context) ([]string, error) {
+	now := time.Now()
+	return s.locationHistoryRepository.CreatePartitions(ctx, now, now.AddDate(0, config.Cfg.PartitionMonthsAhead, 0))
+}
+
+// PrunePartitions implements business logic of dropping the monthly partitions of the
+// location history whose month ends before the retention cutoff of every tenant and
+// username. Partitions are only dropped by the delete retention mode, and their distances
+// are kept by DistanceRollup records. A dry run reports the partitions to drop without
+// dropping them. Returns the partitions dropped up to the error, if any.
+func (s *LocationService) PrunePartitions(ctx context.Context, dryRun bool) ([]dto.HistoryPartition, error) {
+	cutoff := partitionCutoff(time.Now())
+	if config.Cfg.RetentionMode != RetentionDelete || cutoff.IsZero() {
+		return nil, nil
+	}
+
+	partitions, err := s.locationHistoryRepository.GetPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []dto.HistoryPartition
+	for _, p := range partitions {
+		if p.Month.AddDate(0, 1, 0).After(cutoff) {
+			continue
+		}
+
+		if !dryRun {
+			var dropped bool
+			if p.Records, dropped, err = s.locationHistoryRepository.DropPartition(ctx, p); err != nil {
+				return expired, err
+			}
+
+			// another replica dropped it first
+			if !dropped {
+				continue
+			}
+
+			droppedPartitions.Inc()
+			prunedRecords.WithLabelValues(RetentionDelete).Add(float64(p.Records))
+			logger.FromContext(ctx, s.log).
+				WithField("partition", p.Name).
+				WithField("records", p.Records).
+				Info("history partition dropped")
+		}
+		expired = append(expired, p)
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+)
+
+// Current location policies. They decide which device of a username with several
+// devices drives the username's Location record.
+const (
+	PolicyMostRecent   = "most_recent"   // every reported location becomes the current location
+	PolicyMostAccurate = "most_accurate" // the most accurate recent location becomes the current location
+	PolicyPrimary      = "primary"       // only locations of the primary device become the current location
+)
+
+// IsCurrentLocationPolicy returns true if policy is a known current location policy.
+func IsCurrentLocationPolicy(policy string) bool {
+	switch policy {
+	case PolicyMostRecent, PolicyMostAccurate, PolicyPrimary:
+		return true
+	}
+
+	return false
+}
+
+// drivesCurrentLocation returns true if the location reported by request at now must
+// replace the current location l under policy. Usernames without a primary device behave
+// as under PolicyMostRecent. Under PolicyMostAccurate a location replaces the current
+// one if it comes from the same device, if it's at least as accurate, or if the current
+// one is older than window. Locations without accuracy are the least accurate.
+func drivesCurrentLocation(policy string, l *dto.Location, request dto.SaveLocationRequest, now time.Time, window time.Duration) bool {
+	switch policy {
+	case PolicyPrimary:
+		if l.PrimaryDeviceId != "" {
+			return request.DeviceId == l.PrimaryDeviceId
+		}
+	case PolicyMostAccurate:
+		if request.DeviceId == l.DeviceId || l.HorizontalAccuracy == nil || now.Sub(l.UpdatedAt) > window {
+			return true
+		}
+		return request.HorizontalAccuracy != nil && *request.HorizontalAccuracy <= *l.HorizontalAccuracy
+	}
+
+	return true
+}
+
+// exclusionReason returns the reason to exclude the location reported by request from
+// distance accumulation, or an empty string if it must be accumulated. Locations with a
+// horizontal accuracy worse than maxAccuracy are excluded, a zero maxAccuracy disables
+// the check.
+func exclusionReason(request dto.SaveLocationRequest, maxAccuracy float64) string {
+	if maxAccuracy > 0 && request.HorizontalAccuracy != nil && *request.HorizontalAccuracy > maxAccuracy {
+		return enums.ExcludedLowAccuracy
+	}
+
+	return ""
+}
+
+// isNotFound returns true if err is a not found error of the repository layer.
+func isNotFound(err error) bool {
+	httpErr, ok := errors.Cause(err).(*respKit.GenericHttpError)
+	return ok && httpErr.Status == http.StatusNotFound
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"fmt"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-common/enums"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	histEnums "github.com/oboadagd/location-history-mgmt/enums"
+	"testing"
+	"time"
+)
+
+func TestDrivesCurrentLocation(t *testing.T) {
+	nameTest := "TestDrivesCurrentLocation"
+
+	now := time.Now()
+	accuracy := func(v float64) *float64 { return &v }
+
+	withPrimary := &dto.Location{UserName: "usernamesample", DeviceId: "phone", PrimaryDeviceId: "phone", UpdatedAt: now}
+	withoutPrimary := &dto.Location{UserName: "usernamesample", DeviceId: "phone", UpdatedAt: now}
+	accurate := &dto.Location{UserName: "usernamesample", DeviceId: "phone", HorizontalAccuracy: accuracy(10), UpdatedAt: now}
+	staleAccurate := &dto.Location{UserName: "usernamesample", DeviceId: "phone", HorizontalAccuracy: accuracy(10), UpdatedAt: now.Add(-time.Hour)}
+
+	type test struct {
+		policy   string
+		location *dto.Location
+		deviceId string
+		accuracy *float64
+		answer   bool
+	}
+
+	tests := []test{
+		{PolicyMostRecent, withPrimary, "phone", nil, true},
+		{PolicyMostRecent, withPrimary, "watch", nil, true},
+		{PolicyPrimary, withPrimary, "phone", nil, true},
+		{PolicyPrimary, withPrimary, "watch", nil, false},
+		{PolicyPrimary, withPrimary, "", nil, false},
+		{PolicyPrimary, withoutPrimary, "watch", nil, true},
+		{PolicyMostAccurate, accurate, "phone", accuracy(500), true},
+		{PolicyMostAccurate, accurate, "watch", accuracy(5), true},
+		{PolicyMostAccurate, accurate, "watch", accuracy(10), true},
+		{PolicyMostAccurate, accurate, "watch", accuracy(50), false},
+		{PolicyMostAccurate, accurate, "watch", nil, false},
+		{PolicyMostAccurate, staleAccurate, "watch", accuracy(50), true},
+		{PolicyMostAccurate, withoutPrimary, "watch", nil, true},
+	}
+
+	for _, v := range tests {
+		req := dto.SaveLocationRequest{UserName: "usernamesample", DeviceId: v.deviceId}
+		req.HorizontalAccuracy = v.accuracy
+		if got := drivesCurrentLocation(v.policy, v.location, req, now, 5*time.Minute); got != v.answer {
+			t.Errorf("%s: %s %s Expected %v but got %v", nameTest, v.policy, v.deviceId, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestIsCurrentLocationPolicy(t *testing.T) {
+	nameTest := "TestIsCurrentLocationPolicy"
+
+	type test struct {
+		policy string
+		answer bool
+	}
+
+	tests := []test{
+		{PolicyMostRecent, true},
+		{PolicyMostAccurate, true},
+		{PolicyPrimary, true},
+		{"", false},
+		{"newest", false},
+	}
+
+	for _, v := range tests {
+		if got := IsCurrentLocationPolicy(v.policy); got != v.answer {
+			t.Errorf("%s: %q Expected %v but got %v", nameTest, v.policy, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestExclusionReason(t *testing.T) {
+	nameTest := "TestExclusionReason"
+	accuracy := func(v float64) *float64 { return &v }
+
+	type test struct {
+		accuracy    *float64
+		maxAccuracy float64
+		answer      string
+	}
+
+	tests := []test{
+		{nil, 100, ""},
+		{accuracy(5), 100, ""},
+		{accuracy(100), 100, ""},
+		{accuracy(2000), 100, histEnums.ExcludedLowAccuracy},
+		{accuracy(2000), 0, ""},
+	}
+
+	for _, v := range tests {
+		req := dto.SaveLocationRequest{UserName: "usernamesample"}
+		req.HorizontalAccuracy = v.accuracy
+		if got := exclusionReason(req, v.maxAccuracy); got != v.answer {
+			t.Errorf("%s: Expected %q but got %q", nameTest, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestIsNotFound(t *testing.T) {
+	nameTest := "TestIsNotFound"
+
+	type test struct {
+		err    error
+		answer bool
+	}
+
+	tests := []test{
+		{nil, false},
+		{fmt.Errorf("boom"), false},
+		{respKit.GenericBadRequestError(enums.ErrorUpdateLocationCode, "boom"), false},
+		{respKit.GenericNotFoundError(enums.ErrorUserNameNotFoundCode, "boom"), true},
+	}
+
+	for _, v := range tests {
+		if got := isNotFound(v.err); got != v.answer {
+			t.Errorf("%s: %v Expected %v but got %v", nameTest, v.err, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/logger"
+	"math"
+	"time"
+)
+
+// distanceTolerance is the difference in kilometers between a stored and a recomputed
+// distance above which the stored distance is wrong.
+const distanceTolerance = 1e-6
+
+// recomputeJob is the job name of the checkpoints of RecomputeDistances.
+const recomputeJob = "recompute-distances"
+
+// distanceChain recomputes the distances of the LocationHistory records of a device fed
+// in date order, as Save measures them.
+type distanceChain struct {
+	calculator DistanceCalculator   // algorithm of the recomputed distances
+	last       *dto.LocationHistory // last record without an exclusion reason, nil before the first one
+}
+
+// next returns the distance of lh from the last record of the chain without an exclusion
+// reason, and makes lh the last one unless it has a reason. Records with an exclusion
+// reason and the first record of the chain have zero distance.
+func (c *distanceChain) next(lh dto.LocationHistory) float64 {
+	if lh.ExcludedReason != "" {
+		return 0
+	}
+
+	last := c.last
+	c.last = &lh
+	if last == nil {
+		return 0
+	}
+
+	return segmentDistance(c.calculator, *last, lh)
+}
+
+// segmentDistance returns the distance in kilometers from record last to record lh,
+// measured with calculator. The smoothed coordinates of lh are measured when present,
+// from the smoothed coordinates of last if it has them.
+func segmentDistance(calculator DistanceCalculator, last dto.LocationHistory, lh dto.LocationHistory) float64 {
+	anchor := geo.NewPoint(last.Latitude, last.Longitude)
+	point := geo.NewPoint(lh.Latitude, lh.Longitude)
+	if lh.FilteredLatitude != nil && lh.FilteredLongitude != nil {
+		point = geo.NewPoint(*lh.FilteredLatitude, *lh.FilteredLongitude)
+		if last.FilteredLatitude != nil && last.FilteredLongitude != nil {
+			anchor = geo.NewPoint(*last.FilteredLatitude, *last.FilteredLongitude)
+		}
+	}
+
+	return calculator.Distance(anchor, point)
+}
+
+// prunedChecked returns true if the distance of record lh, next in chain, can be checked
+// given the horizon before which the history of its username was pruned. Records before
+// the horizon carry the distance of a downsampled hour, and the first record after it
+// may chain from a deleted record, so neither is checked.
+func prunedChecked(horizon time.Time, chain distanceChain, lh dto.LocationHistory) bool {
+	if horizon.IsZero() {
+		return true
+	}
+
+	return !lh.UpdatedAt.Before(horizon) && chain.last != nil
+}
+
+// recomputeJobName returns the job name of the checkpoints of request, so runs with
+// other options don't resume from them.
+func recomputeJobName(request dto.RecomputeDistancesRequest) string {
+	job := recomputeJob
+	if request.UserName != "" {
+		job += ":" + request.UserName
+	}
+	if request.DryRun {
+		job += ":dry-run"
+	}
+	return job
+}
+
+// RecomputeDistances implements business logic of checking the distances of the
+// LocationHistory records of a username, or of every username of the tenant if userName
+// is empty. Walks the records of every device in date order, recomputing their distances
+// as Save does with the distance algorithm configured for the tenant, and reports the
+// records whose stored distance differs and the usernames whose Location record diverges
+// from their history. Records pruned by retention aren't checked, as prunedChecked
+// decides. Unless the request is a dry run, it fixes the wrong distances and their
+// recorded algorithm, rebuilds the distance rollups of their usernames and rebuilds the
+// diverging Location records. A checkpoint is stored as each username completes, so a run
+// interrupted by an error resumes after the last completed username. Returns the findings
+// up to the error, if any.
+func (s *LocationService) RecomputeDistances(ctx context.Context, request dto.RecomputeDistancesRequest) (*dto.RecomputeDistancesResponse, error) {
+
+	job := recomputeJobName(request)
+	checkpoint, err := s.jobCheckpointRepository.Get(ctx, job)
+	if err != nil {
+		return &dto.RecomputeDistancesResponse{}, err
+	}
+
+	devices, err := s.locationHistoryRepository.GetUserDevices(ctx, request.UserName)
+	if err != nil {
+		return &dto.RecomputeDistancesResponse{}, err
+	}
+
+	resp := dto.RecomputeDistancesResponse{Fixed: !request.DryRun}
+	calculator := distanceCalculator(ctx)
+	wrong := 0 // wrong distances of the current username
+	for i, d := range devices {
+		if checkpoint != nil && d.UserName <= checkpoint.UserName {
+			continue
+		}
+
+		horizon, err := s.locationHistoryRepository.GetHorizon(ctx, d.UserName)
+		if err != nil {
+			return &resp, err
+		}
+
+		var discrepancies []dto.DistanceDiscrepancy
+		chain := distanceChain{calculator: calculator}
+		err = s.walkHistory(ctx, d, func(lh dto.LocationHistory) error {
+			resp.Records++
+			checked := prunedChecked(horizon, chain, lh)
+			if distance := chain.next(lh); checked && math.Abs(distance-lh.Distance) > distanceTolerance {
+				discrepancies = append(discrepancies, dto.DistanceDiscrepancy{
+					UserName:          lh.UserName,
+					DeviceId:          lh.DeviceId,
+					LocationHistoryId: lh.Id,
+					UpdatedAt:         lh.UpdatedAt,
+					Stored:            lh.Distance,
+					Recomputed:        distance,
+					Algorithm:         calculator.Name(),
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return &resp, err
+		}
+
+		resp.Discrepancies = append(resp.Discrepancies, discrepancies...)
+		wrong += len(discrepancies)
+		if !request.DryRun && len(discrepancies) > 0 {
+			if err := s.locationHistoryRepository.UpdateDistances(ctx, discrepancies); err != nil {
+				return &resp, err
+			}
+		}
+
+		// the username completes with its last device
+		if i+1 < len(devices) && devices[i+1].UserName == d.UserName {
+			continue
+		}
+
+		if err := s.completeRecompute(ctx, &resp, d.UserName, wrong, job); err != nil {
+			return &resp, err
+		}
+		wrong = 0
+	}
+
+	if err := s.jobCheckpointRepository.Delete(ctx, job); err != nil {
+		return &resp, err
+	}
+
+	return &resp, nil
+}
+
+// completeRecompute completes the recomputation of the distances of a username with
+// wrong distances, reconciling its distance rollups and Location record unless resp
+// isn't fixed, and stores the checkpoint of job.
+func (s *LocationService) completeRecompute(ctx context.Context, resp *dto.RecomputeDistancesResponse, userName string, wrong int, job string) error {
+	resp.UserNames++
+
+	if resp.Fixed && wrong > 0 {
+		if _, err := s.locationHistoryRepository.RebuildDistanceRollups(ctx, userName); err != nil {
+			return err
+		}
+	}
+
+	divergences, err := s.locationRepository.GetDivergences(ctx, userName)
+	if err != nil {
+		return err
+	}
+	resp.Divergences = append(resp.Divergences, divergences...)
+
+	if resp.Fixed && len(divergences) > 0 {
+		if _, err := s.locationRepository.RebuildFromHistory(ctx, userName); err != nil {
+			return err
+		}
+	}
+
+	logger.FromContext(ctx, s.log).
+		WithField(logger.FieldUserName, userName).
+		WithField("discrepancies", wrong).
+		WithField("divergences", len(divergences)).
+		WithField("fixed", resp.Fixed).
+		Info("distances recomputed")
+
+	return s.jobCheckpointRepository.Save(ctx, job, userName)
+}
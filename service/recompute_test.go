@@ -0,0 +1,110 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDistanceChain(t *testing.T) {
+	nameTest := "TestDistanceChain"
+
+	filtered := func(v float64) *float64 { return &v }
+
+	type test struct {
+		name   string
+		lh     dto.LocationHistory
+		answer float64 // kilometers
+	}
+
+	// each step north of 0.01 degrees is about 1.112 kilometers on the sphere and about
+	// 1.106 kilometers on the ellipsoid
+	tests := []test{
+		{"first", dto.LocationHistory{Latitude: 10, Longitude: 10}, 0},
+		{"north", dto.LocationHistory{Latitude: 10.01, Longitude: 10}, 1.112},
+		{"jitter", dto.LocationHistory{Latitude: 10.01, Longitude: 10.0001, ExcludedReason: enums.ExcludedJitter}, 0},
+		{"outlier", dto.LocationHistory{Latitude: 50, Longitude: 50, ExcludedReason: enums.ExcludedOutlier}, 0},
+		{"skips excluded", dto.LocationHistory{Latitude: 10.02, Longitude: 10}, 1.112},
+		{"smoothed from raw", dto.LocationHistory{Latitude: 10.05, Longitude: 10,
+			FilteredLatitude: filtered(10.03), FilteredLongitude: filtered(10)}, 1.112},
+		{"smoothed from smoothed", dto.LocationHistory{Latitude: 10.06, Longitude: 10,
+			FilteredLatitude: filtered(10.04), FilteredLongitude: filtered(10)}, 1.112},
+		{"ignores recorded algorithm", dto.LocationHistory{Latitude: 10.07, Longitude: 10,
+			DistanceAlgorithm: DistanceVincenty}, 1.112},
+	}
+
+	chain := distanceChain{calculator: calculatorNamed(DistanceHaversine)}
+	for _, v := range tests {
+		if d := chain.next(v.lh); math.Abs(d-v.answer) > 0.001 {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, d)
+			return
+		}
+	}
+
+	// the chain measures with its own algorithm
+	chain = distanceChain{calculator: calculatorNamed(DistanceVincenty)}
+	chain.next(dto.LocationHistory{Latitude: 10, Longitude: 10, DistanceAlgorithm: DistanceHaversine})
+	if d := chain.next(dto.LocationHistory{Latitude: 10.01, Longitude: 10, DistanceAlgorithm: DistanceHaversine}); math.Abs(d-1.106) > 0.001 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 1.106, d)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestRecomputeJobName(t *testing.T) {
+	nameTest := "TestRecomputeJobName"
+
+	type test struct {
+		request dto.RecomputeDistancesRequest
+		answer  string
+	}
+
+	tests := []test{
+		{dto.RecomputeDistancesRequest{}, "recompute-distances"},
+		{dto.RecomputeDistancesRequest{DryRun: true}, "recompute-distances:dry-run"},
+		{dto.RecomputeDistancesRequest{UserName: "usernamesample", DryRun: true}, "recompute-distances:usernamesample:dry-run"},
+	}
+
+	for _, v := range tests {
+		if job := recomputeJobName(v.request); job != v.answer {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, job)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestPrunedChecked(t *testing.T) {
+	nameTest := "TestPrunedChecked"
+
+	horizon := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	anchored := distanceChain{last: &dto.LocationHistory{UpdatedAt: horizon.Add(-time.Hour)}}
+
+	type test struct {
+		name    string
+		horizon time.Time
+		chain   distanceChain
+		lh      dto.LocationHistory
+		answer  bool
+	}
+
+	tests := []test{
+		{"never pruned", time.Time{}, distanceChain{}, dto.LocationHistory{UpdatedAt: horizon}, true},
+		{"before horizon", horizon, anchored, dto.LocationHistory{UpdatedAt: horizon.Add(-time.Minute)}, false},
+		{"after deleted record", horizon, distanceChain{}, dto.LocationHistory{UpdatedAt: horizon}, false},
+		{"after downsampled record", horizon, anchored, dto.LocationHistory{UpdatedAt: horizon}, true},
+	}
+
+	for _, v := range tests {
+		if c := prunedChecked(v.horizon, v.chain, v.lh); c != v.answer {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, c)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
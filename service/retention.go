@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/logger"
+	"github.com/oboadagd/location-history-mgmt/tenant"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+// Retention modes. They decide how LocationHistory records older than the retention
+// period of their username are pruned. Distances are kept by DistanceRollup records in
+// both modes, so GetDistanceTraveled still sums pruned hours, though only as whole hours.
+const (
+	RetentionDelete     = "delete"     // every record of an expired day is deleted
+	RetentionDownsample = "downsample" // only the last record of every hour of each device is kept, carrying the distance of the hour
+)
+
+// prunedRecords counts the LocationHistory records removed by retention.
+var prunedRecords = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "location_history_pruned_records_total",
+	Help: "Number of location history records removed by retention.",
+}, []string{"mode"})
+
+// retentionRuns counts the runs of the retention worker.
+var retentionRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "location_history_retention_runs_total",
+	Help: "Number of runs of the retention worker by result.",
+}, []string{"result"})
+
+// IsRetentionMode returns true if mode is a known retention mode.
+func IsRetentionMode(mode string) bool {
+	switch mode {
+	case RetentionDelete, RetentionDownsample:
+		return true
+	}
+
+	return false
+}
+
+// retentionPeriod returns the retention period configured for a username of a tenant,
+// falling back to the period of the tenant and then to the default of every tenant.
+func retentionPeriod(tenantId string, userName string) time.Duration {
+	if p, ok := config.Cfg.RetentionPeriods[tenantId+"/"+userName]; ok {
+		return p
+	}
+
+	if p, ok := config.Cfg.RetentionPeriods[tenantId]; ok {
+		return p
+	}
+
+	return config.Cfg.RetentionPeriod
+}
+
+// retentionCutoff returns the start of the UTC day of now minus period, before which
+// records are pruned, or the zero date if period is zero and records are kept forever.
+// Whole days are pruned, so the distance of a day is either fully in the history or
+// fully in the rollups.
+func retentionCutoff(period time.Duration, now time.Time) time.Time {
+	if period <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(-period).UTC().Truncate(24 * time.Hour)
+}
+
+// PruneHistory implements business logic of pruning the LocationHistory records of a
+// username, or of every username of the tenant if userName is empty, older than their
+// retention period as the retention mode configures. Days are pruned in date order, each
+// in its own transaction, so locks are held briefly and an interrupted run resumes where
+// it stopped. A dry run reports the records to prune without pruning them. Returns the
+// findings up to the error, if any.
+func (s *LocationService) PruneHistory(ctx context.Context, request dto.PruneHistoryRequest) (*dto.PruneHistoryResponse, error) {
+
+	userNames := []string{request.UserName}
+	if request.UserName == "" {
+		var err error
+		if userNames, err = s.locationRepository.GetUserNames(ctx); err != nil {
+			return &dto.PruneHistoryResponse{}, err
+		}
+	}
+
+	resp := dto.PruneHistoryResponse{Mode: config.Cfg.RetentionMode, Pruned: !request.DryRun}
+	tenantId := tenant.FromContext(ctx)
+	now := time.Now()
+	for _, userName := range userNames {
+		cutoff := retentionCutoff(retentionPeriod(tenantId, userName), now)
+		if cutoff.IsZero() {
+			continue
+		}
+
+		pruning, err := s.pruneUserName(ctx, userName, cutoff, resp.Mode, request.DryRun)
+		if pruning.Records > 0 {
+			resp.UserNames = append(resp.UserNames, pruning)
+			resp.Records += pruning.Records
+		}
+		if err != nil {
+			return &resp, err
+		}
+	}
+
+	return &resp, nil
+}
+
+// pruneUserName prunes the history of a username before cutoff with mode, or only
+// reports the days to prune on a dry run. Returns the days pruned up to the error, if any.
+func (s *LocationService) pruneUserName(ctx context.Context, userName string, cutoff time.Time, mode string, dryRun bool) (dto.UserPruning, error) {
+	pruning := dto.UserPruning{UserName: userName, Cutoff: cutoff}
+
+	days, err := s.locationHistoryRepository.GetPrunableDays(ctx, userName, cutoff, mode == RetentionDownsample)
+	if err != nil {
+		return pruning, err
+	}
+
+	if dryRun {
+		pruning.Days = days
+		for _, d := range days {
+			pruning.Records += d.Records
+		}
+		return pruning, nil
+	}
+
+	for _, d := range days {
+		n, err := s.locationHistoryRepository.PruneDay(ctx, userName, d.Day, mode == RetentionDownsample)
+		if err != nil {
+			return pruning, err
+		}
+
+		prunedRecords.WithLabelValues(mode).Add(float64(n))
+		pruning.Days = append(pruning.Days, dto.PrunableDay{Day: d.Day, Records: n})
+		pruning.Records += n
+	}
+
+	if pruning.Records > 0 {
+		logger.FromContext(ctx, s.log).
+			WithField(logger.FieldUserName, userName).
+			WithField("cutoff", cutoff).
+			WithField("days", len(pruning.Days)).
+			WithField("records", pruning.Records).
+			Info("history pruned")
+	}
+
+	return pruning, nil
+}
+
+// PruneTenants implements business logic of the retention worker, dropping the expired
+// partitions of the history with PrunePartitions and then pruning the history of every
+// tenant with PruneHistory. Returns the number of pruned records.
+func (s *LocationService) PruneTenants(ctx context.Context) (int, error) {
+	partitions, err := s.PrunePartitions(ctx, false)
+	pruned := 0
+	for _, p := range partitions {
+		pruned += p.Records
+	}
+	if err != nil {
+		retentionRuns.WithLabelValues("error").Inc()
+		return pruned, err
+	}
+
+	tenants, err := s.locationRepository.GetTenants(ctx)
+	if err != nil {
+		retentionRuns.WithLabelValues("error").Inc()
+		return pruned, err
+	}
+
+	for _, tenantId := range tenants {
+		resp, err := s.PruneHistory(tenant.WithTenant(ctx, tenantId), dto.PruneHistoryRequest{})
+		pruned += resp.Records
+		if err != nil {
+			retentionRuns.WithLabelValues("error").Inc()
+			return pruned, err
+		}
+	}
+
+	retentionRuns.WithLabelValues("success").Inc()
+	return pruned, nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/config"
+	"testing"
+	"time"
+)
+
+func TestRetentionPeriod(t *testing.T) {
+	nameTest := "TestRetentionPeriod"
+	defer func(period time.Duration, periods map[string]time.Duration) {
+		config.Cfg.RetentionPeriod = period
+		config.Cfg.RetentionPeriods = periods
+	}(config.Cfg.RetentionPeriod, config.Cfg.RetentionPeriods)
+
+	config.Cfg.RetentionPeriod = 90 * 24 * time.Hour
+	config.Cfg.RetentionPeriods = map[string]time.Duration{
+		"tenanta":         30 * 24 * time.Hour,
+		"tenanta/kept":    0,
+		"tenantb/shorter": 7 * 24 * time.Hour,
+	}
+
+	type test struct {
+		tenantId string
+		userName string
+		answer   time.Duration
+	}
+
+	// username periods override tenant periods, which override the default
+	tests := []test{
+		{"default", "usernamesample", 90 * 24 * time.Hour},
+		{"tenanta", "usernamesample", 30 * 24 * time.Hour},
+		{"tenanta", "kept", 0},
+		{"tenantb", "shorter", 7 * 24 * time.Hour},
+		{"tenantb", "usernamesample", 90 * 24 * time.Hour},
+	}
+
+	for _, v := range tests {
+		if p := retentionPeriod(v.tenantId, v.userName); p != v.answer {
+			t.Errorf("%s %s/%s: Expected %v but got %v", nameTest, v.tenantId, v.userName, v.answer, p)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestRetentionCutoff(t *testing.T) {
+	nameTest := "TestRetentionCutoff"
+
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	type test struct {
+		period time.Duration
+		answer time.Time
+	}
+
+	// cutoffs are truncated to the start of the UTC day
+	tests := []test{
+		{0, time.Time{}},
+		{24 * time.Hour, time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{16 * time.Hour, time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{30 * 24 * time.Hour, time.Date(2024, 2, 9, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, v := range tests {
+		if c := retentionCutoff(v.period, now); !c.Equal(v.answer) {
+			t.Errorf("%s %v: Expected %v but got %v", nameTest, v.period, v.answer, c)
+			return
+		}
+	}
+
+	// dates in other zones are cut in UTC
+	local := now.In(time.FixedZone("UTC+9", 9*3600))
+	if c := retentionCutoff(24*time.Hour, local); !c.Equal(time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("%s: Expected %v but got %v", nameTest, time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC), c)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestPartitionCutoff(t *testing.T) {
+	nameTest := "TestPartitionCutoff"
+	defer func(period time.Duration, periods map[string]time.Duration) {
+		config.Cfg.RetentionPeriod = period
+		config.Cfg.RetentionPeriods = periods
+	}(config.Cfg.RetentionPeriod, config.Cfg.RetentionPeriods)
+
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	type test struct {
+		name    string
+		period  time.Duration
+		periods map[string]time.Duration
+		answer  time.Time
+	}
+
+	// partitions expire with the longest period, and never if some history is kept forever
+	tests := []test{
+		{"kept forever", 0, map[string]time.Duration{"tenanta": 24 * time.Hour}, time.Time{}},
+		{"default", 24 * time.Hour, nil, time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"shorter tenant", 10 * 24 * time.Hour, map[string]time.Duration{"tenanta": 24 * time.Hour},
+			time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"longer username", 24 * time.Hour, map[string]time.Duration{"tenanta/usernamesample": 10 * 24 * time.Hour},
+			time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"username kept forever", 24 * time.Hour, map[string]time.Duration{"tenanta/usernamesample": 0}, time.Time{}},
+	}
+
+	for _, v := range tests {
+		config.Cfg.RetentionPeriod = v.period
+		config.Cfg.RetentionPeriods = v.periods
+		if c := partitionCutoff(now); !c.Equal(v.answer) {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, c)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxRouteRange is the longest range of a route.
+const maxRouteRange = 31 * 24 * time.Hour
+
+// earthRadius is the mean radius of the earth in meters, used to project routes.
+const earthRadius = 6371000.0
+
+// projectRoute returns the points of route projected to meters on a plane tangent at
+// its mean latitude. Longitudes are unwrapped, so routes crossing the antimeridian stay
+// continuous.
+func projectRoute(route []dto.RoutePoint) [][2]float64 {
+	lat0 := 0.0
+	for _, p := range route {
+		lat0 += p.Latitude
+	}
+	lat0 /= float64(len(route))
+
+	scale := earthRadius * math.Pi / 180
+	cos0 := math.Cos(lat0 * math.Pi / 180)
+	xy := make([][2]float64, len(route))
+	lng := 0.0
+	for i, p := range route {
+		if i == 0 {
+			lng = p.Longitude
+		} else {
+			d := math.Mod(p.Longitude-route[i-1].Longitude+540, 360) - 180
+			lng += d
+		}
+		xy[i] = [2]float64{lng * cos0 * scale, p.Latitude * scale}
+	}
+
+	return xy
+}
+
+// segmentOffset returns the distance from p to the segment from a to b.
+func segmentOffset(p [2]float64, a [2]float64, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = math.Max(0, math.Min(1, ((p[0]-a[0])*dx+(p[1]-a[1])*dy)/l))
+	}
+
+	return math.Hypot(p[0]-a[0]-t*dx, p[1]-a[1]-t*dy)
+}
+
+// routeImportance returns the importance in meters of every point of route, as the
+// Douglas-Peucker algorithm ranks them. A point is kept by a tolerance below its
+// importance, and the importance of a point never exceeds the one of the point that
+// split its segment, so keeping the most important points yields the Douglas-Peucker
+// simplification. The first and last points have infinite importance.
+func routeImportance(route []dto.RoutePoint) []float64 {
+	importance := make([]float64, len(route))
+	if len(route) == 0 {
+		return importance
+	}
+	importance[0], importance[len(route)-1] = math.Inf(1), math.Inf(1)
+
+	xy := projectRoute(route)
+	type segment struct {
+		start, end int
+		cap        float64 // importance of the point that split the segment
+	}
+	stack := []segment{{0, len(route) - 1, math.Inf(1)}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if s.end-s.start < 2 {
+			continue
+		}
+
+		farthest, offset := s.start+1, -1.0
+		for i := s.start + 1; i < s.end; i++ {
+			if d := segmentOffset(xy[i], xy[s.start], xy[s.end]); d > offset {
+				farthest, offset = i, d
+			}
+		}
+
+		importance[farthest] = math.Min(offset, s.cap)
+		stack = append(stack, segment{s.start, farthest, importance[farthest]}, segment{farthest, s.end, importance[farthest]})
+	}
+
+	return importance
+}
+
+// simplifiedPoints returns the indexes, in route order, of the points of route kept by
+// the Douglas-Peucker algorithm with tolerance meters, then limited to the maxPoints most
+// important ones. A zero tolerance and maxPoints keep every point.
+func simplifiedPoints(route []dto.RoutePoint, tolerance float64, maxPoints int) []int {
+	if tolerance == 0 && maxPoints == 0 || len(route) <= 2 {
+		kept := make([]int, len(route))
+		for i := range kept {
+			kept[i] = i
+		}
+		return kept
+	}
+
+	importance := routeImportance(route)
+	var kept []int
+	for i, v := range importance {
+		if v > tolerance {
+			kept = append(kept, i)
+		}
+	}
+
+	if maxPoints > 0 && len(kept) > maxPoints {
+		sort.SliceStable(kept, func(i, j int) bool { return importance[kept[i]] > importance[kept[j]] })
+		kept = kept[:maxPoints]
+		sort.Ints(kept)
+	}
+
+	return kept
+}
+
+// simplifyRoute returns the points of route kept by simplifiedPoints with tolerance
+// meters and maxPoints. A zero tolerance and maxPoints return route unchanged.
+func simplifyRoute(route []dto.RoutePoint, tolerance float64, maxPoints int) []dto.RoutePoint {
+	if tolerance == 0 && maxPoints == 0 {
+		return route
+	}
+
+	kept := simplifiedPoints(route, tolerance, maxPoints)
+	simplified := make([]dto.RoutePoint, len(kept))
+	for i, k := range kept {
+		simplified[i] = route[k]
+	}
+
+	return simplified
+}
+
+// encodePolyline returns route in encoded polyline format with precision 5, as defined
+// by the Google Maps Platform.
+func encodePolyline(route []dto.RoutePoint) string {
+	var b strings.Builder
+	var lat, lng int64
+	for _, p := range route {
+		nextLat := int64(math.Round(p.Latitude * 1e5))
+		nextLng := int64(math.Round(p.Longitude * 1e5))
+		encodePolylineValue(&b, nextLat-lat)
+		encodePolylineValue(&b, nextLng-lng)
+		lat, lng = nextLat, nextLng
+	}
+
+	return b.String()
+}
+
+// encodePolylineValue writes to b the coordinate delta v of an encoded polyline.
+func encodePolylineValue(b *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+
+	for u >= 0x20 {
+		b.WriteByte(byte(0x20|u&0x1f) + 63)
+		u >>= 5
+	}
+	b.WriteByte(byte(u) + 63)
+}
+
+// GetRoute implements business logic of drawing the route of a device of a username in
+// a time range, simplified by the requested tolerance and maximum number of points. When
+// no device is requested, the route of the device that traveled the most is drawn.
+// Routes skip the locations excluded as outliers or for low accuracy, while the total
+// distance is accumulated from every location of the range as GetDistanceTraveled does.
+// Returns username data not found if username has no locations in the range and
+// ErrorRouteRangeCode for ranges longer than maxRouteRange. If initial or final date has
+// empty value then time range defaults to 1 day.
+func (s *LocationService) GetRoute(ctx context.Context, request dto.GetRouteRequest) (*dto.GetRouteResponse, error) {
+
+	request.InitialDate, request.FinalDate = dateRange(request.InitialDate, request.FinalDate)
+	if request.FinalDate.Sub(request.InitialDate) > maxRouteRange {
+		return &dto.GetRouteResponse{}, respKit.GenericBadRequestError(enums.ErrorRouteRangeCode,
+			fmt.Sprintf(enums.ErrorRouteRangeMsg, maxRouteRange))
+	}
+
+	dt, err := s.locationHistoryRepository.GetDistanceByUserNameAndDateRange(ctx, dto.GetDistanceTraveledRequest{
+		UserName:    request.UserName,
+		DeviceId:    request.DeviceId,
+		InitialDate: request.InitialDate,
+		FinalDate:   request.FinalDate,
+	})
+	if err != nil {
+		return &dto.GetRouteResponse{}, err
+	}
+
+	track, err := s.locationHistoryRepository.GetTrackByUserNameAndDateRange(ctx, request.UserName, dt.DeviceId, request.InitialDate, request.FinalDate)
+	if err != nil {
+		return &dto.GetRouteResponse{}, err
+	}
+
+	route := make([]dto.RoutePoint, len(track))
+	for i, lh := range track {
+		route[i] = dto.RoutePoint{Latitude: lh.Latitude, Longitude: lh.Longitude, UpdatedAt: lh.UpdatedAt}
+	}
+
+	simplified := simplifyRoute(route, request.Tolerance, int(request.MaxPoints))
+	resp := dto.GetRouteResponse{
+		UserName:      request.UserName,
+		DeviceId:      dt.DeviceId,
+		PointCount:    uint64(len(simplified)),
+		TotalPoints:   uint64(len(route)),
+		TotalDistance: dt.TotalDistance,
+	}
+
+	if request.Encoded {
+		resp.Polyline = encodePolyline(simplified)
+	} else {
+		resp.Points = simplified
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"testing"
+)
+
+func TestEncodePolyline(t *testing.T) {
+	nameTest := "TestEncodePolyline"
+
+	// example of the polyline algorithm documentation of the Google Maps Platform
+	route := []dto.RoutePoint{
+		{Latitude: 38.5, Longitude: -120.2},
+		{Latitude: 40.7, Longitude: -120.95},
+		{Latitude: 43.252, Longitude: -126.453},
+	}
+
+	answer := "_p~iF~ps|U_ulLnnqC_mqNvxq`@"
+	if polyline := encodePolyline(route); polyline != answer {
+		t.Errorf("%s: Expected %v but got %v", nameTest, answer, polyline)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestSimplifyRoute(t *testing.T) {
+	nameTest := "TestSimplifyRoute"
+
+	// a walk north of two steps of about 111 meters, turning east at the third point for
+	// two more steps with a bump of about 4 meters to the north at the fourth point
+	route := []dto.RoutePoint{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0.001, Longitude: 0},
+		{Latitude: 0.002, Longitude: 0},
+		{Latitude: 0.00204, Longitude: 0.001},
+		{Latitude: 0.002, Longitude: 0.002},
+	}
+
+	// a straight walk east across the antimeridian
+	crossing := []dto.RoutePoint{
+		{Latitude: 0, Longitude: 179.998},
+		{Latitude: 0, Longitude: 179.999},
+		{Latitude: 0, Longitude: -180},
+		{Latitude: 0, Longitude: -179.999},
+	}
+
+	type test struct {
+		name      string
+		route     []dto.RoutePoint
+		tolerance float64
+		maxPoints int
+		answer    []int // indexes of the kept points
+	}
+
+	tests := []test{
+		{"unsimplified", route, 0, 0, []int{0, 1, 2, 3, 4}},
+		{"collinear point", route, 1, 0, []int{0, 2, 3, 4}},
+		{"bump", route, 10, 0, []int{0, 2, 4}},
+		{"corner", route, 200, 0, []int{0, 4}},
+		{"max points", route, 0, 3, []int{0, 2, 4}},
+		{"tolerance and max points", route, 1, 4, []int{0, 2, 3, 4}},
+		{"antimeridian", crossing, 1, 0, []int{0, 3}},
+	}
+
+	for _, v := range tests {
+		simplified := simplifyRoute(v.route, v.tolerance, v.maxPoints)
+		if len(simplified) != len(v.answer) {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, simplified)
+			return
+		}
+
+		for i, k := range v.answer {
+			if simplified[i] != v.route[k] {
+				t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.answer, simplified)
+				return
+			}
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
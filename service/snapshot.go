@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+)
+
+// radiusBoundingBox returns the smallest area made of latitude and longitude ranges that
+// contains the circle of radius kilometers around center.
+func radiusBoundingBox(center *geo.Point, radius float64) dto.BoundingBox {
+	return dto.BoundingBox{
+		MinLatitude:  center.PointAtDistanceAndBearing(radius, 180).Lat(),
+		MinLongitude: center.PointAtDistanceAndBearing(radius, 270).Lng(),
+		MaxLatitude:  center.PointAtDistanceAndBearing(radius, 0).Lat(),
+		MaxLongitude: center.PointAtDistanceAndBearing(radius, 90).Lng(),
+	}
+}
+
+// GetUsersByLocationAtTime implements business logic of getting the usernames whose last
+// location at a date was within a radius, by requested page. The last location of each
+// username is its later location reported up to the tolerance after the date, and no
+// older than the maximum staleness before it, which defaults to the configured one.
+func (s *LocationService) GetUsersByLocationAtTime(ctx context.Context, request dto.GetUsersByLocationAtTimeRequest) (*dto.GetUsersByLocationAtTimeResponse, error) {
+
+	maxStaleness := request.MaxStaleness
+	if maxStaleness == 0 {
+		maxStaleness = config.Cfg.SnapshotMaxStaleness
+	}
+
+	center := geo.NewPoint(request.Latitude, request.Longitude)
+	last, err := s.locationHistoryRepository.GetLastInAreaByDateRange(ctx, radiusBoundingBox(center, request.Radius),
+		request.At.Add(-maxStaleness), request.At.Add(request.Tolerance))
+	if err != nil {
+		return &dto.GetUsersByLocationAtTimeResponse{}, err
+	}
+
+	var users []dto.Location
+	for _, l := range last {
+		if center.GreatCircleDistance(geo.NewPoint(l.Latitude, l.Longitude)) <= request.Radius {
+			users = append(users, l)
+		}
+	}
+
+	totalItems := uint64(len(users))
+	start, end, totalPages, err := pageBounds(request.Page, request.ItemsLimit, totalItems)
+	if err != nil {
+		return &dto.GetUsersByLocationAtTimeResponse{}, err
+	}
+
+	return &dto.GetUsersByLocationAtTimeResponse{
+		Users:      users[start:end],
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}, nil
+}
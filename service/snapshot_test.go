@@ -0,0 +1,49 @@
+package service
+
+import (
+	geo "github.com/kellydunn/golang-geo"
+	"testing"
+)
+
+func TestRadiusBoundingBox(t *testing.T) {
+	nameTest := "TestRadiusBoundingBox"
+
+	type test struct {
+		latitude  float64
+		longitude float64
+		crosses   bool // the area crosses the antimeridian
+	}
+
+	tests := []test{
+		{10, 10, false},
+		{-33.9, 151.2, false},
+		{0, 179.99, true},
+	}
+
+	radius := 5.0
+	for _, v := range tests {
+		center := geo.NewPoint(v.latitude, v.longitude)
+		box := radiusBoundingBox(center, radius)
+
+		if (box.MinLongitude > box.MaxLongitude) != v.crosses {
+			t.Errorf("%s: Expected crossing %v but got %+v", nameTest, v.crosses, box)
+			return
+		}
+
+		// the points of the circle in every direction are within the area
+		for bearing := 0.0; bearing < 360; bearing += 45 {
+			p := center.PointAtDistanceAndBearing(radius*0.999, bearing)
+			inLongitude := p.Lng() >= box.MinLongitude && p.Lng() <= box.MaxLongitude
+			if v.crosses {
+				inLongitude = p.Lng() >= box.MinLongitude || p.Lng() <= box.MaxLongitude
+			}
+
+			if p.Lat() < box.MinLatitude || p.Lat() > box.MaxLatitude || !inLongitude {
+				t.Errorf("%s: Expected %v within %+v", nameTest, p, box)
+				return
+			}
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/logger"
+	"sort"
+	"time"
+)
+
+// defaultPlacesRange is the range of stays clustered into places when the request
+// doesn't set one.
+const defaultPlacesRange = 30 * 24 * time.Hour
+
+// advanceStay extends the current stay of a device, or nil if it has none, with location
+// p. Locations within radius meters of the centroid of the stay are added to it, and the
+// stay opens once it lasts minDuration. Otherwise an open stay is closed, and p starts a
+// new candidate stay, reusing the record of a discarded candidate. Returns the stay
+// closed by p, if any, and the current stay after p.
+func advanceStay(current *dto.Stay, p trackPoint, radius float64, minDuration time.Duration) (*dto.Stay, *dto.Stay) {
+	if !segmentable(p.reason) {
+		return nil, current
+	}
+
+	point := geo.NewPoint(p.latitude, p.longitude)
+	if current != nil && geo.NewPoint(current.Latitude, current.Longitude).GreatCircleDistance(point)*1000 <= radius {
+		current.PointCount++
+		current.Latitude += (p.latitude - current.Latitude) / float64(current.PointCount)
+		current.Longitude += (p.longitude - current.Longitude) / float64(current.PointCount)
+		current.DepartedAt = p.at
+		current.Duration = current.DepartedAt.Sub(current.ArrivedAt).Seconds()
+		if current.Status == enums.StayCandidate && current.DepartedAt.Sub(current.ArrivedAt) >= minDuration {
+			current.Status = enums.StayOpen
+		}
+		return nil, current
+	}
+
+	var closed *dto.Stay
+	if current != nil && current.Status == enums.StayOpen {
+		current.Status = enums.StayClosed
+		closed, current = current, nil
+	}
+
+	if current == nil {
+		current = &dto.Stay{
+			UserName: p.userName,
+			DeviceId: p.deviceId,
+		}
+	}
+
+	current.Status = enums.StayCandidate
+	current.Latitude = p.latitude
+	current.Longitude = p.longitude
+	current.ArrivedAt = p.at
+	current.DepartedAt = p.at
+	current.Duration = 0
+	current.PointCount = 1
+
+	return closed, current
+}
+
+// clusterPlaces clusters stays into places. Each stay joins the place with the nearest
+// centroid within radius meters, or starts a new place. Places are ordered by distinct
+// days with stays, then by visits and then by total duration.
+func clusterPlaces(stays []dto.Stay, radius float64) []dto.Place {
+	var places []dto.Place
+	var days []map[string]bool
+	for _, st := range stays {
+		point := geo.NewPoint(st.Latitude, st.Longitude)
+
+		nearest := -1
+		nearestDistance := radius
+		for i, pl := range places {
+			if d := geo.NewPoint(pl.Latitude, pl.Longitude).GreatCircleDistance(point) * 1000; d <= nearestDistance {
+				nearest, nearestDistance = i, d
+			}
+		}
+
+		if nearest < 0 {
+			places = append(places, dto.Place{
+				Latitude:       st.Latitude,
+				Longitude:      st.Longitude,
+				FirstArrivedAt: st.ArrivedAt,
+				LastDepartedAt: st.DepartedAt,
+			})
+			days = append(days, map[string]bool{})
+			nearest = len(places) - 1
+		}
+
+		pl := &places[nearest]
+		pl.Visits++
+		pl.Latitude += (st.Latitude - pl.Latitude) / float64(pl.Visits)
+		pl.Longitude += (st.Longitude - pl.Longitude) / float64(pl.Visits)
+		pl.TotalDuration += st.Duration
+		if st.ArrivedAt.Before(pl.FirstArrivedAt) {
+			pl.FirstArrivedAt = st.ArrivedAt
+		}
+		if st.DepartedAt.After(pl.LastDepartedAt) {
+			pl.LastDepartedAt = st.DepartedAt
+		}
+
+		for d := st.ArrivedAt; !d.After(st.DepartedAt); d = d.AddDate(0, 0, 1) {
+			days[nearest][d.Format("2006-01-02")] = true
+		}
+		days[nearest][st.DepartedAt.Format("2006-01-02")] = true
+		pl.Days = uint64(len(days[nearest]))
+	}
+
+	sort.SliceStable(places, func(i, j int) bool {
+		if places[i].Days != places[j].Days {
+			return places[i].Days > places[j].Days
+		}
+		if places[i].Visits != places[j].Visits {
+			return places[i].Visits > places[j].Visits
+		}
+		return places[i].TotalDuration > places[j].TotalDuration
+	})
+
+	return places
+}
+
+// trackStay advances the current stay of the device of p with location p and stores the
+// changed stays. Stays are derived data that BackfillStays can rebuild, so failures are
+// logged instead of failing the location.
+func (s *LocationService) trackStay(ctx context.Context, p trackPoint) {
+	if !segmentable(p.reason) {
+		return
+	}
+
+	entry := logger.FromContext(ctx, s.log).
+		WithField(logger.FieldUserName, p.userName).
+		WithField(logger.FieldDeviceID, p.deviceId)
+
+	current, err := s.stayRepository.GetCurrentByUserNameAndDevice(ctx, p.userName, p.deviceId)
+	if isNotFound(err) {
+		current = nil
+	} else if err != nil {
+		entry.WithError(err).Warn("stay detection failed")
+		return
+	}
+
+	closed, next := advanceStay(current, p, config.Cfg.StayRadius, config.Cfg.StayMinDuration)
+	for _, st := range []*dto.Stay{closed, next} {
+		if st == nil {
+			continue
+		}
+
+		if err := s.stayRepository.Save(ctx, st); err != nil {
+			entry.WithError(err).Warn("stay detection failed")
+			return
+		}
+	}
+}
+
+// ListStays implements business logic of listing the stays of a username, optionally of
+// one of its devices, that overlap a time range by requested page. If initial or final
+// date has empty value then time range defaults to 1 day.
+func (s *LocationService) ListStays(ctx context.Context, request dto.ListStaysRequest) (*dto.ListStaysResponse, error) {
+
+	request.InitialDate, request.FinalDate = dateRange(request.InitialDate, request.FinalDate)
+
+	ls, err := s.stayRepository.ListByUserNameAndDateRange(ctx, request)
+	if err != nil {
+		return &dto.ListStaysResponse{}, err
+	}
+
+	return ls, nil
+}
+
+// GetFrequentPlaces implements business logic of getting the places a username visits
+// most, clustering its stays, optionally of one of its devices, that overlap a time
+// range. If initial or final date has empty value then time range defaults to 30 days.
+func (s *LocationService) GetFrequentPlaces(ctx context.Context, request dto.GetFrequentPlacesRequest) (*dto.GetFrequentPlacesResponse, error) {
+
+	request.InitialDate, request.FinalDate = dateRangeOr(request.InitialDate, request.FinalDate, defaultPlacesRange)
+
+	stays, err := s.stayRepository.GetByUserNameAndDateRange(ctx, request.UserName, request.DeviceId, request.InitialDate, request.FinalDate)
+	if err != nil {
+		return &dto.GetFrequentPlacesResponse{}, err
+	}
+
+	places := clusterPlaces(stays, config.Cfg.PlaceRadius)
+	if uint64(len(places)) > request.Limit {
+		places = places[:request.Limit]
+	}
+
+	return &dto.GetFrequentPlacesResponse{
+		Username: request.UserName,
+		Places:   places,
+	}, nil
+}
+
+// BackfillStays implements business logic of rebuilding the stays of every device of a
+// username, or of every username of the tenant if userName is empty, from their
+// LocationHistory records. Existing stays of each device are replaced. Returns the
+// number of stored stays, not counting candidates.
+func (s *LocationService) BackfillStays(ctx context.Context, userName string) (int, error) {
+	return s.backfill(ctx, userName, "stays", s.backfillDeviceStays)
+}
+
+// backfillDeviceStays replaces the stays of device d with the stays detected from its
+// LocationHistory records in date order. Returns the number of stored stays, not
+// counting candidates.
+func (s *LocationService) backfillDeviceStays(ctx context.Context, d dto.UserDevice) (int, error) {
+	if err := s.stayRepository.DeleteByUserNameAndDevice(ctx, d.UserName, d.DeviceId); err != nil {
+		return 0, err
+	}
+
+	radius, minDuration := config.Cfg.StayRadius, config.Cfg.StayMinDuration
+
+	stored := 0
+	var current *dto.Stay
+	err := s.walkHistory(ctx, d, func(lh dto.LocationHistory) error {
+		p := newTrackPoint(lh.UserName, lh.DeviceId, lh.Latitude, lh.Longitude, lh.UpdatedAt,
+			lh.Distance, lh.ExcludedReason, lh.Speed, nil)
+
+		var closed *dto.Stay
+		closed, current = advanceStay(current, p, radius, minDuration)
+		if closed != nil {
+			if err := s.stayRepository.Save(ctx, closed); err != nil {
+				return err
+			}
+			stored++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return stored, err
+	}
+
+	if current != nil {
+		if err := s.stayRepository.Save(ctx, current); err != nil {
+			return stored, err
+		}
+		if current.Status == enums.StayOpen {
+			stored++
+		}
+	}
+
+	return stored, nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAdvanceStay(t *testing.T) {
+	nameTest := "TestAdvanceStay"
+
+	radius, minDuration := 100.0, 10*time.Minute
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	// 0.0005 degrees of latitude are about 55 meters
+	type test struct {
+		name      string
+		point     trackPoint
+		closed    bool      // p closes the open stay
+		status    string    // status of the current stay after p
+		points    int       // points of the current stay after p
+		arrivedAt time.Time // arrival of the current stay after p
+	}
+
+	tests := []test{
+		{"arrives", trackPoint{at: at(0), latitude: 10, longitude: 10}, false, enums.StayCandidate, 1, at(0)},
+		{"stays near", trackPoint{at: at(5), latitude: 10.0005, longitude: 10}, false, enums.StayCandidate, 2, at(0)},
+		{"outlier ignored", trackPoint{at: at(6), latitude: 11, longitude: 10, reason: enums.ExcludedOutlier}, false, enums.StayCandidate, 2, at(0)},
+		{"lasts minimum duration", trackPoint{at: at(10), latitude: 10, longitude: 10.0005, reason: enums.ExcludedJitter}, false, enums.StayOpen, 3, at(0)},
+		{"leaves", trackPoint{at: at(20), latitude: 10.01, longitude: 10}, true, enums.StayCandidate, 1, at(20)},
+		{"passes by", trackPoint{at: at(21), latitude: 10.02, longitude: 10}, false, enums.StayCandidate, 1, at(21)},
+	}
+
+	var current *dto.Stay
+	for _, v := range tests {
+		var closed *dto.Stay
+		closed, current = advanceStay(current, v.point, radius, minDuration)
+
+		if (closed != nil) != v.closed {
+			t.Errorf("%s %s: Expected closed %v but got %v", nameTest, v.name, v.closed, closed)
+			return
+		}
+
+		if closed != nil && (closed.Status != enums.StayClosed || closed.PointCount != 3 || closed.Duration != 600) {
+			t.Errorf("%s %s: Expected closed stay of %v points but got %+v", nameTest, v.name, 3, closed)
+			return
+		}
+
+		if current.Status != v.status || current.PointCount != v.points || !current.ArrivedAt.Equal(v.arrivedAt) {
+			t.Errorf("%s %s: Expected %v %v %v but got %v %v %v", nameTest, v.name,
+				v.status, v.points, v.arrivedAt, current.Status, current.PointCount, current.ArrivedAt)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestAdvanceStay_Centroid(t *testing.T) {
+	nameTest := "TestAdvanceStay_Centroid"
+
+	start := time.Now()
+	var current *dto.Stay
+	for i, lat := range []float64{10, 10.0002, 10.0004} {
+		_, current = advanceStay(current, trackPoint{at: start.Add(time.Duration(i) * time.Minute), latitude: lat, longitude: 10}, 100, time.Minute)
+	}
+
+	if math.Abs(current.Latitude-10.0002) > 1e-9 || current.Longitude != 10 {
+		t.Errorf("%s: Expected %v but got %v", nameTest, 10.0002, current.Latitude)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestClusterPlaces(t *testing.T) {
+	nameTest := "TestClusterPlaces"
+
+	day := func(d int, hour int) time.Time { return time.Date(2024, 1, d, hour, 0, 0, 0, time.UTC) }
+
+	// home is visited overnight on two days, the office on two days and a shop once
+	stays := []dto.Stay{
+		{Latitude: 10, Longitude: 10, ArrivedAt: day(1, 0), DepartedAt: day(1, 8), Duration: 8 * 3600},
+		{Latitude: 10.1, Longitude: 10, ArrivedAt: day(1, 9), DepartedAt: day(1, 17), Duration: 8 * 3600},
+		{Latitude: 10.0005, Longitude: 10, ArrivedAt: day(1, 18), DepartedAt: day(2, 8), Duration: 14 * 3600},
+		{Latitude: 10.1005, Longitude: 10, ArrivedAt: day(2, 9), DepartedAt: day(2, 12), Duration: 3 * 3600},
+		{Latitude: 10.1, Longitude: 10.0005, ArrivedAt: day(2, 13), DepartedAt: day(2, 17), Duration: 4 * 3600},
+		{Latitude: 10.2, Longitude: 10, ArrivedAt: day(2, 18), DepartedAt: day(2, 19), Duration: 3600},
+	}
+
+	places := clusterPlaces(stays, 200)
+
+	type test struct {
+		latitude float64
+		visits   uint64
+		days     uint64
+		duration float64
+	}
+
+	tests := []test{
+		{10.1, 3, 2, 15 * 3600},
+		{10, 2, 2, 22 * 3600},
+		{10.2, 1, 1, 3600},
+	}
+
+	if len(places) != len(tests) {
+		t.Errorf("%s: Expected %v but got %v", nameTest, len(tests), len(places))
+		return
+	}
+
+	for i, v := range tests {
+		pl := places[i]
+		if math.Abs(pl.Latitude-v.latitude) > 0.001 || pl.Visits != v.visits || pl.Days != v.days || pl.TotalDuration != v.duration {
+			t.Errorf("%s: Expected %+v but got %+v", nameTest, v, pl)
+			return
+		}
+	}
+
+	if !places[1].FirstArrivedAt.Equal(day(1, 0)) || !places[1].LastDepartedAt.Equal(day(2, 8)) {
+		t.Errorf("%s: Expected %v %v but got %v %v", nameTest, day(1, 0), day(2, 8), places[1].FirstArrivedAt, places[1].LastDepartedAt)
+		return
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestDateRangeOr(t *testing.T) {
+	nameTest := "TestDateRangeOr"
+
+	initial := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	final := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	type test struct {
+		initial time.Time
+		final   time.Time
+		span    time.Duration
+	}
+
+	tests := []test{
+		{initial, final, 24 * time.Hour},
+		{final, initial, 24 * time.Hour},
+		{time.Time{}, final, defaultPlacesRange},
+		{initial, time.Time{}, time.Hour},
+	}
+
+	for _, v := range tests {
+		start, end := dateRangeOr(v.initial, v.final, v.span)
+		if v.initial.IsZero() || v.final.IsZero() {
+			if end.Sub(start) != v.span {
+				t.Errorf("%s: Expected %v but got %v", nameTest, v.span, end.Sub(start))
+				return
+			}
+			continue
+		}
+
+		if !start.Equal(initial) || !end.Equal(final) {
+			t.Errorf("%s: Expected %v %v but got %v %v", nameTest, initial, final, start, end)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
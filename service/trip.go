@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"github.com/oboadagd/location-history-mgmt/logger"
+	"time"
+)
+
+// trackPoint is a location of a device as seen by trip and stay detection.
+type trackPoint struct {
+	userName  string     // username
+	deviceId  string     // device that reported the location
+	latitude  float64    // latitude coordinate
+	longitude float64    // longitude coordinate
+	at        time.Time  // date of the location
+	distance  float64    // traveled distance in kilometers from the last accumulated location
+	speed     float64    // speed in meters per second, reported by the device or implied by distance
+	reason    string     // reason to exclude the location from distance accumulation
+	from      *geo.Point // last accumulated location of the device. Nil for its first location
+	fromAt    time.Time  // date of the last accumulated location
+}
+
+// newTrackPoint returns the trackPoint of a location of a device that traveled distance
+// from its last accumulated location, last, or nil for the first location. Uses the
+// speed reported by the device when available.
+func newTrackPoint(userName string, deviceId string, latitude float64, longitude float64, at time.Time,
+	distance float64, reason string, reportedSpeed *float64, last *dto.GetLastByUserNameResponse) trackPoint {
+	p := trackPoint{
+		userName:  userName,
+		deviceId:  deviceId,
+		latitude:  latitude,
+		longitude: longitude,
+		at:        at,
+		distance:  distance,
+		reason:    reason,
+	}
+
+	if last != nil {
+		p.from = geo.NewPoint(last.Latitude, last.Longitude)
+		p.fromAt = last.UpdatedAt
+		p.speed = impliedSpeed(distance, at.Sub(last.UpdatedAt))
+	}
+
+	if reportedSpeed != nil {
+		p.speed = *reportedSpeed
+	}
+
+	return p
+}
+
+// segmentable returns true if locations excluded by reason take part in trip and stay
+// detection. Jitter is a device standing still, so it counts towards dwells and stays,
+// while inaccurate locations and outliers are ignored.
+func segmentable(reason string) bool {
+	return reason != enums.ExcludedLowAccuracy && reason != enums.ExcludedOutlier
+}
+
+// advanceTrip extends the open trip of a device, or nil if it has none, with location p.
+// The open trip is closed when p comes gap or more after its last location, or when p
+// doesn't move and the trip didn't move for dwell or more. A moving location opens a trip
+// from the last accumulated location, unless that location is gap or more old. Returns
+// the trip closed by p, if any, and the open trip after p, if any.
+func advanceTrip(open *dto.Trip, p trackPoint, dwell time.Duration, gap time.Duration) (*dto.Trip, *dto.Trip) {
+	if !segmentable(p.reason) {
+		return nil, open
+	}
+
+	var closed *dto.Trip
+	if open != nil && (p.at.Sub(open.LastPointAt) >= gap || p.distance == 0 && p.at.Sub(open.EndedAt) >= dwell) {
+		open.Status = enums.TripClosed
+		closed, open = open, nil
+	}
+
+	if p.distance == 0 {
+		if open != nil {
+			open.LastPointAt = p.at
+			open.PointCount++
+		}
+		return closed, open
+	}
+
+	if open == nil {
+		if p.from == nil || p.at.Sub(p.fromAt) >= gap {
+			return closed, nil
+		}
+
+		open = &dto.Trip{
+			UserName:       p.userName,
+			DeviceId:       p.deviceId,
+			Status:         enums.TripOpen,
+			StartedAt:      p.fromAt,
+			StartLatitude:  p.from.Lat(),
+			StartLongitude: p.from.Lng(),
+			PointCount:     1,
+		}
+	}
+
+	open.EndedAt = p.at
+	open.LastPointAt = p.at
+	open.EndLatitude = p.latitude
+	open.EndLongitude = p.longitude
+	open.Distance += p.distance
+	open.Duration = open.EndedAt.Sub(open.StartedAt).Seconds()
+	open.PointCount++
+	if p.speed > open.MaxSpeed {
+		open.MaxSpeed = p.speed
+	}
+
+	return closed, open
+}
+
+// tripStatus returns the status of trip at now. Open trips of devices that stopped
+// reporting locations gap or more ago are closed, although they are only stored as
+// closed with the next location of the device.
+func tripStatus(trip dto.Trip, now time.Time, gap time.Duration) string {
+	if trip.Status == enums.TripOpen && now.Sub(trip.LastPointAt) >= gap {
+		return enums.TripClosed
+	}
+
+	return trip.Status
+}
+
+// trackTrip advances the open trip of the device of p with location p and stores the
+// changed trips. Trips are derived data that BackfillTrips can rebuild, so failures are
+// logged instead of failing the location.
+func (s *LocationService) trackTrip(ctx context.Context, p trackPoint) {
+	if !segmentable(p.reason) {
+		return
+	}
+
+	entry := logger.FromContext(ctx, s.log).
+		WithField(logger.FieldUserName, p.userName).
+		WithField(logger.FieldDeviceID, p.deviceId)
+
+	open, err := s.tripRepository.GetOpenByUserNameAndDevice(ctx, p.userName, p.deviceId)
+	if isNotFound(err) {
+		open = nil
+	} else if err != nil {
+		entry.WithError(err).Warn("trip segmentation failed")
+		return
+	}
+
+	closed, next := advanceTrip(open, p, config.Cfg.TripDwellTime, config.Cfg.TripGapTime)
+	for _, t := range []*dto.Trip{closed, next} {
+		if t == nil {
+			continue
+		}
+
+		if err := s.tripRepository.Save(ctx, t); err != nil {
+			entry.WithError(err).Warn("trip segmentation failed")
+			return
+		}
+	}
+}
+
+// ListTrips implements business logic of listing the trips of a username, optionally of
+// one of its devices, that overlap a time range by requested page. If initial or final
+// date has empty value then time range defaults to 1 day.
+func (s *LocationService) ListTrips(ctx context.Context, request dto.ListTripsRequest) (*dto.ListTripsResponse, error) {
+
+	request.InitialDate, request.FinalDate = dateRange(request.InitialDate, request.FinalDate)
+
+	lt, err := s.tripRepository.ListByUserNameAndDateRange(ctx, request)
+	if err != nil {
+		return &dto.ListTripsResponse{}, err
+	}
+
+	now := time.Now()
+	for i := range lt.Trips {
+		lt.Trips[i].Status = tripStatus(lt.Trips[i], now, config.Cfg.TripGapTime)
+	}
+
+	return lt, nil
+}
+
+// GetTrip implements business logic of getting a trip of a username. Returns trip not
+// found if the username has no such trip.
+func (s *LocationService) GetTrip(ctx context.Context, request dto.GetTripRequest) (*dto.Trip, error) {
+
+	t, err := s.tripRepository.GetByUserNameAndId(ctx, request.UserName, request.TripId)
+	if err != nil {
+		return &dto.Trip{}, err
+	}
+
+	t.Status = tripStatus(*t, time.Now(), config.Cfg.TripGapTime)
+
+	return t, nil
+}
+
+// BackfillTrips implements business logic of rebuilding the trips of every device of a
+// username, or of every username of the tenant if userName is empty, from their
+// LocationHistory records. Existing trips of each device are replaced. Returns the
+// number of stored trips.
+func (s *LocationService) BackfillTrips(ctx context.Context, userName string) (int, error) {
+	return s.backfill(ctx, userName, "trips", s.backfillDeviceTrips)
+}
+
+// backfillDeviceTrips replaces the trips of device d with the trips segmented from its
+// LocationHistory records in date order. Returns the number of stored trips.
+func (s *LocationService) backfillDeviceTrips(ctx context.Context, d dto.UserDevice) (int, error) {
+	if err := s.tripRepository.DeleteByUserNameAndDevice(ctx, d.UserName, d.DeviceId); err != nil {
+		return 0, err
+	}
+
+	dwell, gap := config.Cfg.TripDwellTime, config.Cfg.TripGapTime
+
+	stored := 0
+	var open *dto.Trip
+	var last *dto.GetLastByUserNameResponse
+	err := s.walkHistory(ctx, d, func(lh dto.LocationHistory) error {
+		p := newTrackPoint(lh.UserName, lh.DeviceId, lh.Latitude, lh.Longitude, lh.UpdatedAt,
+			lh.Distance, lh.ExcludedReason, lh.Speed, last)
+
+		var closed *dto.Trip
+		closed, open = advanceTrip(open, p, dwell, gap)
+		if closed != nil {
+			if err := s.tripRepository.Save(ctx, closed); err != nil {
+				return err
+			}
+			stored++
+		}
+
+		if lh.ExcludedReason == "" {
+			last = &dto.GetLastByUserNameResponse{
+				Latitude:  lh.Latitude,
+				Longitude: lh.Longitude,
+				UpdatedAt: lh.UpdatedAt,
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return stored, err
+	}
+
+	if open != nil {
+		open.Status = tripStatus(*open, time.Now(), gap)
+		if err := s.tripRepository.Save(ctx, open); err != nil {
+			return stored, err
+		}
+		stored++
+	}
+
+	return stored, nil
+}
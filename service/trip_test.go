@@ -0,0 +1,186 @@
+package service
+
+import (
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/enums"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAdvanceTrip(t *testing.T) {
+	nameTest := "TestAdvanceTrip"
+
+	dwell, gap := 5*time.Minute, 30*time.Minute
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	from := func(minutes int) *geo.Point { return geo.NewPoint(10, 10+float64(minutes)*0.01) }
+
+	type test struct {
+		name     string
+		point    trackPoint
+		closed   bool    // p closes the open trip
+		open     bool    // an open trip remains after p
+		distance float64 // distance of the open trip after p
+		points   int     // points of the open trip after p
+	}
+
+	tests := []test{
+		{"first location", trackPoint{at: at(0)}, false, false, 0, 0},
+		{"stationary without trip", trackPoint{at: at(1), reason: enums.ExcludedJitter, from: from(0), fromAt: at(0)}, false, false, 0, 0},
+		{"starts moving", trackPoint{at: at(2), distance: 1, speed: 8, from: from(0), fromAt: at(0)}, false, true, 1, 2},
+		{"keeps moving", trackPoint{at: at(3), distance: 1, speed: 12, from: from(2), fromAt: at(2)}, false, true, 2, 3},
+		{"low accuracy ignored", trackPoint{at: at(4), reason: enums.ExcludedLowAccuracy}, false, true, 2, 3},
+		{"outlier ignored", trackPoint{at: at(4), distance: 0, reason: enums.ExcludedOutlier}, false, true, 2, 3},
+		{"stops within dwell", trackPoint{at: at(6), reason: enums.ExcludedJitter, from: from(3), fromAt: at(3)}, false, true, 2, 4},
+		{"dwell closes", trackPoint{at: at(9), reason: enums.ExcludedJitter, from: from(3), fromAt: at(3)}, true, false, 0, 0},
+		{"moves again", trackPoint{at: at(10), distance: 0.5, speed: 5, from: from(3), fromAt: at(3)}, false, true, 0.5, 2},
+		{"gap closes", trackPoint{at: at(50), distance: 3, speed: 1, from: from(10), fromAt: at(10)}, true, false, 0, 0},
+		{"moves after gap", trackPoint{at: at(51), distance: 1, speed: 16, from: from(50), fromAt: at(50)}, false, true, 1, 2},
+	}
+
+	var open *dto.Trip
+	for _, v := range tests {
+		var closed *dto.Trip
+		closed, open = advanceTrip(open, v.point, dwell, gap)
+
+		if (closed != nil) != v.closed {
+			t.Errorf("%s %s: Expected closed %v but got %v", nameTest, v.name, v.closed, closed)
+			return
+		}
+
+		if closed != nil && closed.Status != enums.TripClosed {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, enums.TripClosed, closed.Status)
+			return
+		}
+
+		if (open != nil) != v.open {
+			t.Errorf("%s %s: Expected open %v but got %v", nameTest, v.name, v.open, open)
+			return
+		}
+
+		if open == nil {
+			continue
+		}
+
+		if math.Abs(open.Distance-v.distance) > 1e-9 {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.distance, open.Distance)
+			return
+		}
+
+		if open.PointCount != v.points {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.name, v.points, open.PointCount)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestAdvanceTrip_Summary(t *testing.T) {
+	nameTest := "TestAdvanceTrip_Summary"
+
+	dwell, gap := 5*time.Minute, 30*time.Minute
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	_, open := advanceTrip(nil, trackPoint{at: start.Add(time.Minute), latitude: 10, longitude: 10.01, distance: 1, speed: 16,
+		from: geo.NewPoint(10, 10), fromAt: start}, dwell, gap)
+	_, open = advanceTrip(open, trackPoint{at: start.Add(3 * time.Minute), latitude: 10, longitude: 10.03, distance: 2, speed: 9,
+		from: geo.NewPoint(10, 10.01), fromAt: start.Add(time.Minute)}, dwell, gap)
+	closed, open := advanceTrip(open, trackPoint{at: start.Add(20 * time.Minute), latitude: 10, longitude: 10.03,
+		reason: enums.ExcludedJitter, from: geo.NewPoint(10, 10.03), fromAt: start.Add(3 * time.Minute)}, dwell, gap)
+
+	if open != nil || closed == nil {
+		t.Errorf("%s: Expected a closed trip but got %v and %v", nameTest, closed, open)
+		return
+	}
+
+	type test struct {
+		field  string
+		got    interface{}
+		answer interface{}
+	}
+
+	tests := []test{
+		{"startedAt", closed.StartedAt, start},
+		{"endedAt", closed.EndedAt, start.Add(3 * time.Minute)},
+		{"startLongitude", closed.StartLongitude, 10.0},
+		{"endLongitude", closed.EndLongitude, 10.03},
+		{"distance", closed.Distance, 3.0},
+		{"duration", closed.Duration, 180.0},
+		{"maxSpeed", closed.MaxSpeed, 16.0},
+		{"lastPointAt", closed.LastPointAt, start.Add(3 * time.Minute)},
+	}
+
+	for _, v := range tests {
+		if v.got != v.answer {
+			t.Errorf("%s %s: Expected %v but got %v", nameTest, v.field, v.answer, v.got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestTripStatus(t *testing.T) {
+	nameTest := "TestTripStatus"
+
+	now := time.Now()
+	gap := 30 * time.Minute
+
+	type test struct {
+		trip   dto.Trip
+		answer string
+	}
+
+	tests := []test{
+		{dto.Trip{Status: enums.TripOpen, LastPointAt: now.Add(-time.Minute)}, enums.TripOpen},
+		{dto.Trip{Status: enums.TripOpen, LastPointAt: now.Add(-time.Hour)}, enums.TripClosed},
+		{dto.Trip{Status: enums.TripClosed, LastPointAt: now.Add(-time.Minute)}, enums.TripClosed},
+	}
+
+	for _, v := range tests {
+		if got := tripStatus(v.trip, now, gap); got != v.answer {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
+
+func TestNewTrackPoint(t *testing.T) {
+	nameTest := "TestNewTrackPoint"
+
+	now := time.Now()
+	reported := 3.5
+	last := &dto.GetLastByUserNameResponse{Latitude: 10, Longitude: 10, UpdatedAt: now.Add(-time.Minute)}
+
+	type test struct {
+		reportedSpeed *float64
+		last          *dto.GetLastByUserNameResponse
+		answer        float64
+	}
+
+	tests := []test{
+		{nil, nil, 0},
+		{nil, last, 20},
+		{&reported, last, 3.5},
+	}
+
+	for _, v := range tests {
+		p := newTrackPoint("usernamesample", "", 10, 10.01, now, 1.2, "", v.reportedSpeed, v.last)
+		if math.Abs(p.speed-v.answer) > 1e-9 {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, p.speed)
+			return
+		}
+
+		if (p.from != nil) != (v.last != nil) {
+			t.Errorf("%s: Expected from %v but got %v", nameTest, v.last != nil, p.from != nil)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
@@ -0,0 +1,43 @@
+// Package tenant implements the tenant dimension of location-history-mgmt microservice.
+// Every Location and LocationHistory record belongs to a tenant, the customer
+// organisation that owns the username, and repositories scope every query by the
+// tenant carried by the request context.
+package tenant
+
+import (
+	"context"
+	"github.com/oboadagd/location-history-mgmt/config"
+	"regexp"
+)
+
+// Default is the tenant of requests when config.Cfg.DefaultTenant is not set.
+const Default = "default"
+
+// tenantIdRegex is the pattern of valid tenant ids.
+var tenantIdRegex = regexp.MustCompile("^[a-zA-Z0-9_-]{1,64}$")
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx that carries the tenant id.
+func WithTenant(ctx context.Context, tenantId string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantId)
+}
+
+// FromContext returns the tenant id carried by ctx, or the default tenant configured
+// in config.Cfg.DefaultTenant if ctx carries none.
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(tenantKey{}).(string); ok && id != "" {
+		return id
+	}
+
+	if config.Cfg.DefaultTenant != "" {
+		return config.Cfg.DefaultTenant
+	}
+
+	return Default
+}
+
+// IsValid returns true if tenantId matches the pattern of tenant ids.
+func IsValid(tenantId string) bool {
+	return tenantIdRegex.MatchString(tenantId)
+}
@@ -6,8 +6,12 @@ import (
 	"github.com/go-pg/pg/v10"
 	"github.com/go-pg/pg/v10/orm"
 	"github.com/kelseyhightower/envconfig"
-	"github.com/oboadagd/location-common/dto"
 	"github.com/oboadagd/location-common/recordtype"
+	"github.com/oboadagd/location-history-mgmt/config"
+	histDto "github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/logger"
+	"github.com/sirupsen/logrus"
+	"io"
 	"strings"
 )
 
@@ -15,6 +19,7 @@ import (
 func pgOptions() *pg.Options {
 
 	envconfig.Process("LIST", &recordtype.Cfg)
+	envconfig.Process("LIST", &config.Cfg)
 
 	return &pg.Options{
 		Addr:     fmt.Sprintf("%s:%d", recordtype.Cfg.DBHost, recordtype.Cfg.DBPort),
@@ -31,7 +36,7 @@ func GetTestDB() *pg.DB {
 // CreateSchema Schema in the mock DB still needs to be created
 func CreateSchema(db *pg.DB) error {
 
-	err := db.Model((*dto.Location)(nil)).CreateTable(&orm.CreateTableOptions{
+	err := db.Model((*histDto.Location)(nil)).CreateTable(&orm.CreateTableOptions{
 		// set Temp=True so no tables/data are actually created
 		Temp: true,
 	})
@@ -39,7 +44,71 @@ func CreateSchema(db *pg.DB) error {
 		return err
 	}
 
-	err = db.Model((*dto.LocationHistory)(nil)).CreateTable(&orm.CreateTableOptions{
+	err = db.Model((*histDto.LocationHistory)(nil)).CreateTable(&orm.CreateTableOptions{
+		// set Temp=True so no tables/data are actually created
+		Temp: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Model((*histDto.LocationFilterState)(nil)).CreateTable(&orm.CreateTableOptions{
+		// set Temp=True so no tables/data are actually created
+		Temp: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Model((*histDto.Trip)(nil)).CreateTable(&orm.CreateTableOptions{
+		// set Temp=True so no tables/data are actually created
+		Temp: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Model((*histDto.Stay)(nil)).CreateTable(&orm.CreateTableOptions{
+		// set Temp=True so no tables/data are actually created
+		Temp: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Model((*histDto.DistanceRollup)(nil)).CreateTable(&orm.CreateTableOptions{
+		// set Temp=True so no tables/data are actually created
+		Temp: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Model((*histDto.Encounter)(nil)).CreateTable(&orm.CreateTableOptions{
+		// set Temp=True so no tables/data are actually created
+		Temp: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Model((*histDto.JobCheckpoint)(nil)).CreateTable(&orm.CreateTableOptions{
+		// set Temp=True so no tables/data are actually created
+		Temp: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Model((*histDto.HistoryHorizon)(nil)).CreateTable(&orm.CreateTableOptions{
+		// set Temp=True so no tables/data are actually created
+		Temp: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Model((*histDto.ApiKey)(nil)).CreateTable(&orm.CreateTableOptions{
 		// set Temp=True so no tables/data are actually created
 		Temp: true,
 	})
@@ -53,7 +122,7 @@ func CreateSchema(db *pg.DB) error {
 // DropSchema Schema in the mock DB still needs to be dropped
 func DropSchema(db *pg.DB) error {
 
-	err := db.Model((*dto.Location)(nil)).DropTable(nil)
+	err := db.Model((*histDto.Location)(nil)).DropTable(nil)
 	if err != nil {
 		return err
 	}
@@ -61,9 +130,15 @@ func DropSchema(db *pg.DB) error {
 	return nil
 }
 
+// GetLogger returns a logger that discards every log line
+func GetLogger() *logrus.Logger {
+	l, _ := logger.NewWithWriter(io.Discard, "debug", logger.FormatJSON)
+	return l
+}
+
 // GetLocation returns an instanced *dto.SaveLocationRequest
-func GetLocation() *dto.SaveLocationRequest {
-	return &dto.SaveLocationRequest{
+func GetLocation() *histDto.SaveLocationRequest {
+	return &histDto.SaveLocationRequest{
 		UserName:  "usernamesample",
 		Latitude:  10,
 		Longitude: 10,
@@ -71,8 +146,8 @@ func GetLocation() *dto.SaveLocationRequest {
 }
 
 // GetLocation returns an instanced *dto.CreateLocationHistoryRequest
-func GetLocationHistory() *dto.CreateLocationHistoryRequest {
-	return &dto.CreateLocationHistoryRequest{
+func GetLocationHistory() *histDto.CreateLocationHistoryRequest {
+	return &histDto.CreateLocationHistoryRequest{
 		UserName:  "usernamesample",
 		Latitude:  10,
 		Longitude: 10,
@@ -24,6 +24,16 @@ const _ = grpc.SupportPackageIsVersion7
 type UserLocationServiceClient interface {
 	SaveLocation(ctx context.Context, in *SaveLocationRequest, opts ...grpc.CallOption) (*SaveLocationResponse, error)
 	GetUsersByLocationAndRadius(ctx context.Context, in *GetUsersByLocationAndRadiusRequest, opts ...grpc.CallOption) (*GetUsersByLocationAndRadiusResponse, error)
+	GetUsersByLocationAtTime(ctx context.Context, in *GetUsersByLocationAtTimeRequest, opts ...grpc.CallOption) (*GetUsersByLocationAtTimeResponse, error)
+	GetUsersInPolygon(ctx context.Context, in *GetUsersInPolygonRequest, opts ...grpc.CallOption) (*GetUsersInAreaResponse, error)
+	GetUsersInBoundingBox(ctx context.Context, in *GetUsersInBoundingBoxRequest, opts ...grpc.CallOption) (*GetUsersInAreaResponse, error)
+	GetLocationHistory(ctx context.Context, in *GetLocationHistoryRequest, opts ...grpc.CallOption) (*GetLocationHistoryResponse, error)
+	GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*GetRouteResponse, error)
+	ListTrips(ctx context.Context, in *ListTripsRequest, opts ...grpc.CallOption) (*ListTripsResponse, error)
+	GetTrip(ctx context.Context, in *GetTripRequest, opts ...grpc.CallOption) (*Trip, error)
+	ListStays(ctx context.Context, in *ListStaysRequest, opts ...grpc.CallOption) (*ListStaysResponse, error)
+	GetFrequentPlaces(ctx context.Context, in *GetFrequentPlacesRequest, opts ...grpc.CallOption) (*GetFrequentPlacesResponse, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
 }
 
 type userLocationServiceClient struct {
@@ -52,12 +62,112 @@ func (c *userLocationServiceClient) GetUsersByLocationAndRadius(ctx context.Cont
 	return out, nil
 }
 
+func (c *userLocationServiceClient) GetUsersByLocationAtTime(ctx context.Context, in *GetUsersByLocationAtTimeRequest, opts ...grpc.CallOption) (*GetUsersByLocationAtTimeResponse, error) {
+	out := new(GetUsersByLocationAtTimeResponse)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/GetUsersByLocationAtTime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLocationServiceClient) GetUsersInPolygon(ctx context.Context, in *GetUsersInPolygonRequest, opts ...grpc.CallOption) (*GetUsersInAreaResponse, error) {
+	out := new(GetUsersInAreaResponse)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/GetUsersInPolygon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLocationServiceClient) GetUsersInBoundingBox(ctx context.Context, in *GetUsersInBoundingBoxRequest, opts ...grpc.CallOption) (*GetUsersInAreaResponse, error) {
+	out := new(GetUsersInAreaResponse)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/GetUsersInBoundingBox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLocationServiceClient) GetLocationHistory(ctx context.Context, in *GetLocationHistoryRequest, opts ...grpc.CallOption) (*GetLocationHistoryResponse, error) {
+	out := new(GetLocationHistoryResponse)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/GetLocationHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLocationServiceClient) GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*GetRouteResponse, error) {
+	out := new(GetRouteResponse)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/GetRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLocationServiceClient) ListTrips(ctx context.Context, in *ListTripsRequest, opts ...grpc.CallOption) (*ListTripsResponse, error) {
+	out := new(ListTripsResponse)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/ListTrips", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLocationServiceClient) GetTrip(ctx context.Context, in *GetTripRequest, opts ...grpc.CallOption) (*Trip, error) {
+	out := new(Trip)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/GetTrip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLocationServiceClient) ListStays(ctx context.Context, in *ListStaysRequest, opts ...grpc.CallOption) (*ListStaysResponse, error) {
+	out := new(ListStaysResponse)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/ListStays", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLocationServiceClient) GetFrequentPlaces(ctx context.Context, in *GetFrequentPlacesRequest, opts ...grpc.CallOption) (*GetFrequentPlacesResponse, error) {
+	out := new(GetFrequentPlacesResponse)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/GetFrequentPlaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLocationServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	out := new(GetLeaderboardResponse)
+	err := c.cc.Invoke(ctx, "/userlocation.UserLocationService/GetLeaderboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserLocationServiceServer is the server API for UserLocationService service.
 // All implementations must embed UnimplementedUserLocationServiceServer
 // for forward compatibility
 type UserLocationServiceServer interface {
 	SaveLocation(context.Context, *SaveLocationRequest) (*SaveLocationResponse, error)
 	GetUsersByLocationAndRadius(context.Context, *GetUsersByLocationAndRadiusRequest) (*GetUsersByLocationAndRadiusResponse, error)
+	GetUsersByLocationAtTime(context.Context, *GetUsersByLocationAtTimeRequest) (*GetUsersByLocationAtTimeResponse, error)
+	GetUsersInPolygon(context.Context, *GetUsersInPolygonRequest) (*GetUsersInAreaResponse, error)
+	GetUsersInBoundingBox(context.Context, *GetUsersInBoundingBoxRequest) (*GetUsersInAreaResponse, error)
+	GetLocationHistory(context.Context, *GetLocationHistoryRequest) (*GetLocationHistoryResponse, error)
+	GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error)
+	ListTrips(context.Context, *ListTripsRequest) (*ListTripsResponse, error)
+	GetTrip(context.Context, *GetTripRequest) (*Trip, error)
+	ListStays(context.Context, *ListStaysRequest) (*ListStaysResponse, error)
+	GetFrequentPlaces(context.Context, *GetFrequentPlacesRequest) (*GetFrequentPlacesResponse, error)
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
 	mustEmbedUnimplementedUserLocationServiceServer()
 }
 
@@ -71,6 +181,36 @@ func (UnimplementedUserLocationServiceServer) SaveLocation(context.Context, *Sav
 func (UnimplementedUserLocationServiceServer) GetUsersByLocationAndRadius(context.Context, *GetUsersByLocationAndRadiusRequest) (*GetUsersByLocationAndRadiusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUsersByLocationAndRadius not implemented")
 }
+func (UnimplementedUserLocationServiceServer) GetUsersByLocationAtTime(context.Context, *GetUsersByLocationAtTimeRequest) (*GetUsersByLocationAtTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsersByLocationAtTime not implemented")
+}
+func (UnimplementedUserLocationServiceServer) GetUsersInPolygon(context.Context, *GetUsersInPolygonRequest) (*GetUsersInAreaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsersInPolygon not implemented")
+}
+func (UnimplementedUserLocationServiceServer) GetUsersInBoundingBox(context.Context, *GetUsersInBoundingBoxRequest) (*GetUsersInAreaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsersInBoundingBox not implemented")
+}
+func (UnimplementedUserLocationServiceServer) GetLocationHistory(context.Context, *GetLocationHistoryRequest) (*GetLocationHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLocationHistory not implemented")
+}
+func (UnimplementedUserLocationServiceServer) GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoute not implemented")
+}
+func (UnimplementedUserLocationServiceServer) ListTrips(context.Context, *ListTripsRequest) (*ListTripsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrips not implemented")
+}
+func (UnimplementedUserLocationServiceServer) GetTrip(context.Context, *GetTripRequest) (*Trip, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrip not implemented")
+}
+func (UnimplementedUserLocationServiceServer) ListStays(context.Context, *ListStaysRequest) (*ListStaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStays not implemented")
+}
+func (UnimplementedUserLocationServiceServer) GetFrequentPlaces(context.Context, *GetFrequentPlacesRequest) (*GetFrequentPlacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFrequentPlaces not implemented")
+}
+func (UnimplementedUserLocationServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
 func (UnimplementedUserLocationServiceServer) mustEmbedUnimplementedUserLocationServiceServer() {}
 
 // UnsafeUserLocationServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +260,186 @@ func _UserLocationService_GetUsersByLocationAndRadius_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserLocationService_GetUsersByLocationAtTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsersByLocationAtTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).GetUsersByLocationAtTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/GetUsersByLocationAtTime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).GetUsersByLocationAtTime(ctx, req.(*GetUsersByLocationAtTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLocationService_GetUsersInPolygon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsersInPolygonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).GetUsersInPolygon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/GetUsersInPolygon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).GetUsersInPolygon(ctx, req.(*GetUsersInPolygonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLocationService_GetUsersInBoundingBox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsersInBoundingBoxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).GetUsersInBoundingBox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/GetUsersInBoundingBox",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).GetUsersInBoundingBox(ctx, req.(*GetUsersInBoundingBoxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLocationService_GetLocationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLocationHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).GetLocationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/GetLocationHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).GetLocationHistory(ctx, req.(*GetLocationHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLocationService_GetRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).GetRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/GetRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).GetRoute(ctx, req.(*GetRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLocationService_ListTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTripsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).ListTrips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/ListTrips",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).ListTrips(ctx, req.(*ListTripsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLocationService_GetTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).GetTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/GetTrip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).GetTrip(ctx, req.(*GetTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLocationService_ListStays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).ListStays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/ListStays",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).ListStays(ctx, req.(*ListStaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLocationService_GetFrequentPlaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrequentPlacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).GetFrequentPlaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/GetFrequentPlaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).GetFrequentPlaces(ctx, req.(*GetFrequentPlacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLocationService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLocationServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userlocation.UserLocationService/GetLeaderboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLocationServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserLocationService_ServiceDesc is the grpc.ServiceDesc for UserLocationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,6 +455,46 @@ var UserLocationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUsersByLocationAndRadius",
 			Handler:    _UserLocationService_GetUsersByLocationAndRadius_Handler,
 		},
+		{
+			MethodName: "GetUsersByLocationAtTime",
+			Handler:    _UserLocationService_GetUsersByLocationAtTime_Handler,
+		},
+		{
+			MethodName: "GetUsersInPolygon",
+			Handler:    _UserLocationService_GetUsersInPolygon_Handler,
+		},
+		{
+			MethodName: "GetUsersInBoundingBox",
+			Handler:    _UserLocationService_GetUsersInBoundingBox_Handler,
+		},
+		{
+			MethodName: "GetLocationHistory",
+			Handler:    _UserLocationService_GetLocationHistory_Handler,
+		},
+		{
+			MethodName: "GetRoute",
+			Handler:    _UserLocationService_GetRoute_Handler,
+		},
+		{
+			MethodName: "ListTrips",
+			Handler:    _UserLocationService_ListTrips_Handler,
+		},
+		{
+			MethodName: "GetTrip",
+			Handler:    _UserLocationService_GetTrip_Handler,
+		},
+		{
+			MethodName: "ListStays",
+			Handler:    _UserLocationService_ListStays_Handler,
+		},
+		{
+			MethodName: "GetFrequentPlaces",
+			Handler:    _UserLocationService_GetFrequentPlaces_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _UserLocationService_GetLeaderboard_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "userlocation.proto",
@@ -0,0 +1,48 @@
+package grpcserver
+
+import (
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-history-mgmt/repository"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+// httpStatusCodes maps the http status of service layer errors to grpc status codes.
+var httpStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:                codes.InvalidArgument,
+	http.StatusUnauthorized:              codes.Unauthenticated,
+	http.StatusForbidden:                 codes.PermissionDenied,
+	http.StatusNotFound:                  codes.NotFound,
+	http.StatusUnprocessableEntity:       codes.FailedPrecondition,
+	http.StatusTooManyRequests:           codes.ResourceExhausted,
+	repository.StatusClientClosedRequest: codes.Canceled,
+	http.StatusGatewayTimeout:            codes.DeadlineExceeded,
+}
+
+// grpcError converts an error returned by the service layer to a grpc status error.
+// The error code of the service layer error is kept as status message prefix.
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var httpErr *respKit.GenericHttpError
+	if e, ok := errors.Cause(err).(*respKit.GenericHttpError); ok {
+		httpErr = e
+	} else {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	code, ok := httpStatusCodes[httpErr.Status]
+	if !ok {
+		code = codes.Internal
+	}
+
+	return status.Errorf(code, "%s: %s", httpErr.ErrorCode, httpErr.Error())
+}
@@ -0,0 +1,39 @@
+package grpcserver
+
+import (
+	"errors"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	"github.com/oboadagd/location-common/enums"
+	histEnums "github.com/oboadagd/location-history-mgmt/enums"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"testing"
+)
+
+func TestGrpcError(t *testing.T) {
+	nameTest := "TestGrpcError"
+
+	type test struct {
+		err    error
+		answer codes.Code
+	}
+
+	tests := []test{
+		{nil, codes.OK},
+		{respKit.GenericNotFoundError(enums.ErrorUserNameNotFoundCode, "not found"), codes.NotFound},
+		{respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, "bad request"), codes.InvalidArgument},
+		{respKit.NewGenericHttpError(http.StatusUnprocessableEntity, histEnums.ErrorImplausibleSpeedCode, errors.New("implausible")), codes.FailedPrecondition},
+		{status.Error(codes.Unauthenticated, "unauthenticated"), codes.Unauthenticated},
+		{errors.New("unexpected"), codes.Internal},
+	}
+
+	for _, v := range tests {
+		if got := status.Code(grpcError(v.err)); got != v.answer {
+			t.Errorf("%s: Expected %v but got %v", nameTest, v.answer, got)
+			return
+		}
+	}
+
+	t.Logf("%s Success", nameTest)
+}
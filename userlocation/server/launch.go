@@ -2,8 +2,9 @@ package grpcserver
 
 import (
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
+	"github.com/oboadagd/location-history-mgmt/logger"
 	"github.com/oboadagd/location-history-mgmt/service"
+	"github.com/sirupsen/logrus"
 	"net"
 
 	pb "github.com/oboadagd/location-history-mgmt/userlocation/proto"
@@ -16,7 +17,8 @@ var port string = "50061"           // host port of the local grpc server
 var addr string = host + ":" + port // host base url of the local grpc server
 
 // GrpcServe starts up the grpc server.
-func GrpcServe(locationService service.LocationServiceInterface, ctx echo.Context) error {
+// Every call is logged and then goes through interceptors in order.
+func GrpcServe(locationService service.LocationServiceInterface, ctx echo.Context, log *logrus.Logger, interceptors ...grpc.UnaryServerInterceptor) error {
 	lis, err := net.Listen("tcp", addr)
 
 	if err != nil {
@@ -26,12 +28,15 @@ func GrpcServe(locationService service.LocationServiceInterface, ctx echo.Contex
 	defer lis.Close()
 	log.Infof("grpc server on %s", lis.Addr().String())
 
-	opts := []grpc.ServerOption{}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{logger.UnaryServerInterceptor(log)}, interceptors...)...),
+	}
 
 	s := grpc.NewServer(opts...)
 	pb.RegisterUserLocationServiceServer(s, &Server{
 		LocationService: locationService,
 		Context:         ctx,
+		Log:             log,
 	})
 
 	defer s.Stop()
@@ -8,6 +8,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/oboadagd/location-history-mgmt/service"
 	pb "github.com/oboadagd/location-history-mgmt/userlocation/proto"
+	"github.com/sirupsen/logrus"
 )
 
 // Server is the representation of a grpc server state.
@@ -15,4 +16,5 @@ type Server struct {
 	pb.UserLocationServiceServer                                  // interface is the server API for UserLocationService
 	LocationService              service.LocationServiceInterface // interface is the local service layer
 	Context                      echo.Context                     // the context of the current HTTP request
+	Log                          *logrus.Logger                   // structured logger
 }
@@ -28,13 +28,19 @@ func init() {
 	lis = bufconn.Listen(bufSize)
 	s := grpc.NewServer()
 
-	locationRepository := repository.NewLocationRepository(db)
-	locationHistoryRepository := repository.NewLocationHistoryRepository(db)
-	locationService := service.NewLocationService(locationRepository, locationHistoryRepository)
+	locationRepository := repository.NewLocationRepository(db, testutils.GetLogger())
+	locationHistoryRepository := repository.NewLocationHistoryRepository(db, testutils.GetLogger())
+	locationFilterStateRepository := repository.NewLocationFilterStateRepository(db, testutils.GetLogger())
+	tripRepository := repository.NewTripRepository(db, testutils.GetLogger())
+	stayRepository := repository.NewStayRepository(db, testutils.GetLogger())
+	encounterRepository := repository.NewEncounterRepository(db, testutils.GetLogger())
+	jobCheckpointRepository := repository.NewJobCheckpointRepository(db, testutils.GetLogger())
+	locationService := service.NewLocationService(locationRepository, locationHistoryRepository, locationFilterStateRepository, tripRepository, stayRepository, encounterRepository, jobCheckpointRepository, testutils.GetLogger())
 
 	pb.RegisterUserLocationServiceServer(s, &Server{
 		LocationService: locationService,
 		Context:         nil,
+		Log:             testutils.GetLogger(),
 	})
 	go func() {
 		if err := s.Serve(lis); err != nil {
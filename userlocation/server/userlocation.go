@@ -2,34 +2,87 @@ package grpcserver
 
 import (
 	"context"
-	"github.com/labstack/gommon/log"
-	"github.com/oboadagd/location-common/dto"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	respKit "github.com/oboadagd/kit-go/middleware/responses"
+	commonDto "github.com/oboadagd/location-common/dto"
 	"github.com/oboadagd/location-common/enums"
+	"github.com/oboadagd/location-history-mgmt/auth"
+	"github.com/oboadagd/location-history-mgmt/dto"
+	"github.com/oboadagd/location-history-mgmt/logger"
 	pb "github.com/oboadagd/location-history-mgmt/userlocation/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// Page sizes of GetLocationHistory, ListTrips and ListStays requests.
+// defaultHistoryItemsLimit is used when the request doesn't set one.
+const (
+	defaultHistoryItemsLimit = 100
+	maxHistoryItemsLimit     = 1000
+)
+
+// Number of places returned by GetFrequentPlaces requests. defaultPlacesLimit is used
+// when the request doesn't set one.
+const (
+	defaultPlacesLimit = 10
+	maxPlacesLimit     = 100
 )
 
 func (s *Server) SaveLocation(ctx context.Context, req *pb.SaveLocationRequest) (*pb.SaveLocationResponse, error) {
 
-	log.Infof("GRPC SaveLocation started: %v", req)
+	entry := logger.FromContext(ctx, s.Log).WithField(logger.FieldUserName, req.UserName).WithField(logger.FieldDeviceID, req.DeviceId)
+	entry.WithFields(logger.Coordinates(s.Log, req.Latitude, req.Longitude)).Debug("GRPC SaveLocation started")
+
+	if err := auth.Authorize(ctx, req.UserName); err != nil {
+		entry.WithError(err).Warn("GRPC SaveLocation forbidden")
+		return &pb.SaveLocationResponse{}, grpcError(err)
+	}
 
 	inReq := dto.SaveLocationRequest{
-		UserName:  req.UserName,
-		Latitude:  req.Latitude,
-		Longitude: req.Longitude,
+		UserName:      req.UserName,
+		DeviceId:      req.DeviceId,
+		TransportMode: req.TransportMode,
+		Latitude:      req.Latitude,
+		Longitude:     req.Longitude,
+		Fix: dto.Fix{
+			HorizontalAccuracy: req.HorizontalAccuracy,
+			Altitude:           req.Altitude,
+			VerticalAccuracy:   req.VerticalAccuracy,
+			Speed:              req.Speed,
+			Heading:            req.Heading,
+			Source:             req.Source,
+		},
+	}
+
+	if err := validator.New().Var(inReq.TransportMode, "omitempty,oneof=walk bike car train plane"); err != nil {
+		entry.WithError(err).Warn("GRPC SaveLocation invalid transport mode")
+		return &pb.SaveLocationResponse{}, grpcError(respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error()))
+	}
+
+	if err := validator.New().Struct(inReq.Fix); err != nil {
+		entry.WithError(err).Warn("GRPC SaveLocation invalid fix")
+		return &pb.SaveLocationResponse{}, grpcError(respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error()))
 	}
 
 	if err := s.LocationService.Save(ctx, inReq); err != nil {
-		log.Fatalf("SaveLocation error: %+v ", err)
-		return &pb.SaveLocationResponse{}, err
+		entry.WithError(err).Error("GRPC SaveLocation failed")
+		return &pb.SaveLocationResponse{}, grpcError(err)
 	}
 
-	log.Infof("GRPC SaveLocation finished: ")
+	entry.Debug("GRPC SaveLocation finished")
 	return &pb.SaveLocationResponse{Message: enums.LocationCreated}, nil
 }
 
 func (s *Server) GetUsersByLocationAndRadius(ctx context.Context, req *pb.GetUsersByLocationAndRadiusRequest) (*pb.GetUsersByLocationAndRadiusResponse, error) {
 
-	log.Infof("GRPC GetUsersByLocationAndRadius started: %v", req)
+	entry := logger.FromContext(ctx, s.Log).WithField("radius", req.Radius)
+	entry.WithFields(logger.Coordinates(s.Log, req.Latitude, req.Longitude)).Debug("GRPC GetUsersByLocationAndRadius started")
+
+	if err := auth.AuthorizeScope(ctx, auth.ScopeAdmin, auth.ScopeService); err != nil {
+		entry.WithError(err).Warn("GRPC GetUsersByLocationAndRadius forbidden")
+		return &pb.GetUsersByLocationAndRadiusResponse{}, grpcError(err)
+	}
 
 	var pbResp = pb.GetUsersByLocationAndRadiusResponse{}
 
@@ -37,28 +90,631 @@ func (s *Server) GetUsersByLocationAndRadius(ctx context.Context, req *pb.GetUse
 		Latitude:   req.Latitude,
 		Longitude:  req.Longitude,
 		Radius:     req.Radius,
+		MaxAge:     req.MaxAge.AsDuration(),
 		Page:       req.Page,
 		ItemsLimit: req.ItemsLimit,
+		Cursor:     req.Cursor,
+	}
+
+	if inReq.MaxAge < 0 {
+		err := respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, "max age must not be negative")
+		return &pb.GetUsersByLocationAndRadiusResponse{}, grpcError(err)
 	}
 
 	resp, err := s.LocationService.GetUsersByLocationAndRadius(ctx, inReq)
 
 	if err != nil {
-		log.Fatalf("GRPC GetUsersByLocationAndRadius error, %+v ", err)
-		return &pb.GetUsersByLocationAndRadiusResponse{}, err
+		entry.WithError(err).Error("GRPC GetUsersByLocationAndRadius failed")
+		return &pb.GetUsersByLocationAndRadiusResponse{}, grpcError(err)
 	}
 
 	for _, u := range resp.Users {
-		pbResp.Users = append(pbResp.Users, &pb.Location{
-			UserName:  u.UserName,
-			Latitude:  u.Latitude,
-			Longitude: u.Longitude,
+		pbResp.Users = append(pbResp.Users, location(u))
+	}
+
+	pbResp.TotalPages = resp.TotalPages
+	pbResp.TotalItems = resp.TotalItems
+	pbResp.NextCursor = resp.NextCursor
+
+	entry.Debug("GRPC GetUsersByLocationAndRadius finished")
+	return &pbResp, nil
+}
+
+func (s *Server) GetUsersByLocationAtTime(ctx context.Context, req *pb.GetUsersByLocationAtTimeRequest) (*pb.GetUsersByLocationAtTimeResponse, error) {
+
+	entry := logger.FromContext(ctx, s.Log).WithField("radius", req.Radius)
+	entry.WithFields(logger.Coordinates(s.Log, req.Latitude, req.Longitude)).Debug("GRPC GetUsersByLocationAtTime started")
+
+	if err := auth.AuthorizeScope(ctx, auth.ScopeAdmin, auth.ScopeService); err != nil {
+		entry.WithError(err).Warn("GRPC GetUsersByLocationAtTime forbidden")
+		return &pb.GetUsersByLocationAtTimeResponse{}, grpcError(err)
+	}
+
+	inReq := dto.GetUsersByLocationAtTimeRequest{
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		Radius:       req.Radius,
+		At:           timestamp(req.At),
+		MaxStaleness: req.MaxStaleness.AsDuration(),
+		Tolerance:    req.Tolerance.AsDuration(),
+		Page:         req.Page,
+		ItemsLimit:   req.ItemsLimit,
+	}
+
+	if err := pageRequest(&inReq.Page, &inReq.ItemsLimit); err != nil {
+		return &pb.GetUsersByLocationAtTimeResponse{}, grpcError(err)
+	}
+
+	if err := validateRequest(inReq); err != nil {
+		entry.WithError(err).Warn("GRPC GetUsersByLocationAtTime invalid request")
+		return &pb.GetUsersByLocationAtTimeResponse{}, grpcError(err)
+	}
+
+	resp, err := s.LocationService.GetUsersByLocationAtTime(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC GetUsersByLocationAtTime failed")
+		return &pb.GetUsersByLocationAtTimeResponse{}, grpcError(err)
+	}
+
+	var pbResp = pb.GetUsersByLocationAtTimeResponse{}
+	for _, u := range resp.Users {
+		pbResp.Users = append(pbResp.Users, location(u))
+	}
+
+	pbResp.TotalPages = resp.TotalPages
+	pbResp.TotalItems = resp.TotalItems
+
+	entry.Debug("GRPC GetUsersByLocationAtTime finished")
+	return &pbResp, nil
+}
+
+func (s *Server) GetUsersInPolygon(ctx context.Context, req *pb.GetUsersInPolygonRequest) (*pb.GetUsersInAreaResponse, error) {
+
+	entry := logger.FromContext(ctx, s.Log).WithField("polygons", len(req.Polygons))
+	entry.Debug("GRPC GetUsersInPolygon started")
+
+	if err := auth.AuthorizeScope(ctx, auth.ScopeAdmin, auth.ScopeService); err != nil {
+		entry.WithError(err).Warn("GRPC GetUsersInPolygon forbidden")
+		return &pb.GetUsersInAreaResponse{}, grpcError(err)
+	}
+
+	inReq := dto.GetUsersInPolygonRequest{
+		MaxAge:     req.MaxAge.AsDuration(),
+		Page:       req.Page,
+		ItemsLimit: req.ItemsLimit,
+	}
+
+	for _, p := range req.Polygons {
+		var polygon dto.Polygon
+		for _, r := range p.Rings {
+			var ring [][2]float64
+			for _, pos := range r.Positions {
+				ring = append(ring, [2]float64{pos.Longitude, pos.Latitude})
+			}
+			polygon = append(polygon, ring)
+		}
+		inReq.Polygons = append(inReq.Polygons, polygon)
+	}
+
+	if len(inReq.Polygons) == 0 {
+		err := respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, "polygons are required")
+		return &pb.GetUsersInAreaResponse{}, grpcError(err)
+	}
+
+	if inReq.MaxAge < 0 {
+		err := respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, "max age must not be negative")
+		return &pb.GetUsersInAreaResponse{}, grpcError(err)
+	}
+
+	if err := pageRequest(&inReq.Page, &inReq.ItemsLimit); err != nil {
+		return &pb.GetUsersInAreaResponse{}, grpcError(err)
+	}
+
+	resp, err := s.LocationService.GetUsersInPolygon(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC GetUsersInPolygon failed")
+		return &pb.GetUsersInAreaResponse{}, grpcError(err)
+	}
+
+	entry.Debug("GRPC GetUsersInPolygon finished")
+	return usersInArea(resp), nil
+}
+
+func (s *Server) GetUsersInBoundingBox(ctx context.Context, req *pb.GetUsersInBoundingBoxRequest) (*pb.GetUsersInAreaResponse, error) {
+
+	entry := logger.FromContext(ctx, s.Log)
+	entry.Debug("GRPC GetUsersInBoundingBox started")
+
+	if err := auth.AuthorizeScope(ctx, auth.ScopeAdmin, auth.ScopeService); err != nil {
+		entry.WithError(err).Warn("GRPC GetUsersInBoundingBox forbidden")
+		return &pb.GetUsersInAreaResponse{}, grpcError(err)
+	}
+
+	if req.Area == nil {
+		err := respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, "area is required")
+		return &pb.GetUsersInAreaResponse{}, grpcError(err)
+	}
+
+	inReq := dto.GetUsersInBoundingBoxRequest{
+		Area: dto.BoundingBox{
+			MinLatitude:  req.Area.MinLatitude,
+			MinLongitude: req.Area.MinLongitude,
+			MaxLatitude:  req.Area.MaxLatitude,
+			MaxLongitude: req.Area.MaxLongitude,
+		},
+		MaxAge:     req.MaxAge.AsDuration(),
+		Page:       req.Page,
+		ItemsLimit: req.ItemsLimit,
+	}
+
+	if err := pageRequest(&inReq.Page, &inReq.ItemsLimit); err != nil {
+		return &pb.GetUsersInAreaResponse{}, grpcError(err)
+	}
+
+	if err := validator.New().Struct(inReq); err != nil {
+		entry.WithError(err).Warn("GRPC GetUsersInBoundingBox invalid request")
+		return &pb.GetUsersInAreaResponse{}, grpcError(respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error()))
+	}
+
+	resp, err := s.LocationService.GetUsersInBoundingBox(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC GetUsersInBoundingBox failed")
+		return &pb.GetUsersInAreaResponse{}, grpcError(err)
+	}
+
+	entry.Debug("GRPC GetUsersInBoundingBox finished")
+	return usersInArea(resp), nil
+}
+
+// pageRequest defaults page to 1 and itemsLimit to defaultHistoryItemsLimit when unset.
+// Returns a bad request error if itemsLimit exceeds maxHistoryItemsLimit.
+func pageRequest(page *uint64, itemsLimit *uint64) error {
+	if *page == 0 {
+		*page = 1
+	}
+
+	if *itemsLimit == 0 {
+		*itemsLimit = defaultHistoryItemsLimit
+	}
+
+	if *itemsLimit > maxHistoryItemsLimit {
+		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode,
+			fmt.Sprintf("items limit %d exceeds the maximum of %d", *itemsLimit, maxHistoryItemsLimit))
+	}
+
+	return nil
+}
+
+// validateRequest applies the validations specified in the tags of req, including the
+// username pattern and coordinate decimals validations of the rest api.
+func validateRequest(req interface{}) error {
+	vtr := validator.New()
+	if err := vtr.RegisterValidation("patternazAZ09", commonDto.IsPatternUserName); err != nil {
+		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	if err := vtr.RegisterValidation("maxDecimals", commonDto.IsMaxDecimals); err != nil {
+		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	if err := vtr.Struct(req); err != nil {
+		return respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error())
+	}
+
+	return nil
+}
+
+// usersInArea returns resp as a grpc GetUsersInAreaResponse message.
+func usersInArea(resp *dto.GetUsersInAreaResponse) *pb.GetUsersInAreaResponse {
+	var pbResp = pb.GetUsersInAreaResponse{}
+	for _, u := range resp.Users {
+		pbResp.Users = append(pbResp.Users, location(u))
+	}
+
+	pbResp.TotalPages = resp.TotalPages
+	pbResp.TotalItems = resp.TotalItems
+
+	return &pbResp
+}
+
+func (s *Server) GetLocationHistory(ctx context.Context, req *pb.GetLocationHistoryRequest) (*pb.GetLocationHistoryResponse, error) {
+
+	entry := logger.FromContext(ctx, s.Log).WithField(logger.FieldUserName, req.UserName).WithField(logger.FieldDeviceID, req.DeviceId)
+	entry.Debug("GRPC GetLocationHistory started")
+
+	if err := auth.Authorize(ctx, req.UserName); err != nil {
+		entry.WithError(err).Warn("GRPC GetLocationHistory forbidden")
+		return &pb.GetLocationHistoryResponse{}, grpcError(err)
+	}
+
+	inReq := dto.GetLocationHistoryRequest{
+		UserName:    req.UserName,
+		DeviceId:    req.DeviceId,
+		InitialDate: timestamp(req.InitialDate),
+		FinalDate:   timestamp(req.FinalDate),
+		Page:        req.Page,
+		ItemsLimit:  req.ItemsLimit,
+		Tolerance:   req.Tolerance,
+		MaxPoints:   req.MaxPoints,
+		Encoded:     req.Encoded,
+	}
+
+	if err := pageRequest(&inReq.Page, &inReq.ItemsLimit); err != nil {
+		return &pb.GetLocationHistoryResponse{}, grpcError(err)
+	}
+
+	if err := validateRequest(inReq); err != nil {
+		entry.WithError(err).Warn("GRPC GetLocationHistory invalid request")
+		return &pb.GetLocationHistoryResponse{}, grpcError(err)
+	}
+
+	resp, err := s.LocationService.GetLocationHistory(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC GetLocationHistory failed")
+		return &pb.GetLocationHistoryResponse{}, grpcError(err)
+	}
+
+	var pbResp = pb.GetLocationHistoryResponse{}
+	for _, l := range resp.Locations {
+		pbResp.Locations = append(pbResp.Locations, &pb.LocationHistory{
+			UserName:           l.UserName,
+			DeviceId:           l.DeviceId,
+			Latitude:           l.Latitude,
+			Longitude:          l.Longitude,
+			Distance:           l.Distance,
+			UpdatedAt:          timestamppb.New(l.UpdatedAt),
+			HorizontalAccuracy: l.HorizontalAccuracy,
+			Altitude:           l.Altitude,
+			VerticalAccuracy:   l.VerticalAccuracy,
+			Speed:              l.Speed,
+			Heading:            l.Heading,
+			Source:             l.Source,
+			ExcludedReason:     l.ExcludedReason,
+			TransportMode:      l.TransportMode,
+			DistanceAlgorithm:  l.DistanceAlgorithm,
+		})
+	}
+
+	pbResp.Polyline = resp.Polyline
+	pbResp.TotalPages = resp.TotalPages
+	pbResp.TotalItems = resp.TotalItems
+
+	entry.Debug("GRPC GetLocationHistory finished")
+	return &pbResp, nil
+}
+
+func (s *Server) GetRoute(ctx context.Context, req *pb.GetRouteRequest) (*pb.GetRouteResponse, error) {
+
+	entry := logger.FromContext(ctx, s.Log).WithField(logger.FieldUserName, req.UserName).WithField(logger.FieldDeviceID, req.DeviceId)
+	entry.Debug("GRPC GetRoute started")
+
+	if err := auth.Authorize(ctx, req.UserName); err != nil {
+		entry.WithError(err).Warn("GRPC GetRoute forbidden")
+		return &pb.GetRouteResponse{}, grpcError(err)
+	}
+
+	inReq := dto.GetRouteRequest{
+		UserName:    req.UserName,
+		DeviceId:    req.DeviceId,
+		InitialDate: timestamp(req.InitialDate),
+		FinalDate:   timestamp(req.FinalDate),
+		Tolerance:   req.Tolerance,
+		MaxPoints:   req.MaxPoints,
+		Encoded:     req.Encoded,
+	}
+
+	if err := validator.New().StructPartial(inReq, "Tolerance", "MaxPoints"); err != nil {
+		entry.WithError(err).Warn("GRPC GetRoute invalid request")
+		return &pb.GetRouteResponse{}, grpcError(respKit.GenericBadRequestError(enums.ErrorRequestBodyCode, err.Error()))
+	}
+
+	resp, err := s.LocationService.GetRoute(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC GetRoute failed")
+		return &pb.GetRouteResponse{}, grpcError(err)
+	}
+
+	pbResp := pb.GetRouteResponse{
+		UserName:      resp.UserName,
+		DeviceId:      resp.DeviceId,
+		Polyline:      resp.Polyline,
+		PointCount:    resp.PointCount,
+		TotalPoints:   resp.TotalPoints,
+		TotalDistance: resp.TotalDistance,
+	}
+
+	for _, p := range resp.Points {
+		pbResp.Points = append(pbResp.Points, &pb.RoutePoint{
+			Latitude:  p.Latitude,
+			Longitude: p.Longitude,
+			UpdatedAt: timestamppb.New(p.UpdatedAt),
 		})
 	}
 
+	entry.Debug("GRPC GetRoute finished")
+	return &pbResp, nil
+}
+
+func (s *Server) ListTrips(ctx context.Context, req *pb.ListTripsRequest) (*pb.ListTripsResponse, error) {
+
+	entry := logger.FromContext(ctx, s.Log).WithField(logger.FieldUserName, req.UserName).WithField(logger.FieldDeviceID, req.DeviceId)
+	entry.Debug("GRPC ListTrips started")
+
+	if err := auth.Authorize(ctx, req.UserName); err != nil {
+		entry.WithError(err).Warn("GRPC ListTrips forbidden")
+		return &pb.ListTripsResponse{}, grpcError(err)
+	}
+
+	inReq := dto.ListTripsRequest{
+		UserName:    req.UserName,
+		DeviceId:    req.DeviceId,
+		InitialDate: timestamp(req.InitialDate),
+		FinalDate:   timestamp(req.FinalDate),
+		Page:        req.Page,
+		ItemsLimit:  req.ItemsLimit,
+	}
+
+	if err := pageRequest(&inReq.Page, &inReq.ItemsLimit); err != nil {
+		return &pb.ListTripsResponse{}, grpcError(err)
+	}
+
+	resp, err := s.LocationService.ListTrips(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC ListTrips failed")
+		return &pb.ListTripsResponse{}, grpcError(err)
+	}
+
+	var pbResp = pb.ListTripsResponse{}
+	for _, t := range resp.Trips {
+		pbResp.Trips = append(pbResp.Trips, trip(t))
+	}
+
 	pbResp.TotalPages = resp.TotalPages
 	pbResp.TotalItems = resp.TotalItems
 
-	log.Infof("GRPC GetUsersByLocationAndRadius finished: ")
+	entry.Debug("GRPC ListTrips finished")
 	return &pbResp, nil
 }
+
+func (s *Server) GetTrip(ctx context.Context, req *pb.GetTripRequest) (*pb.Trip, error) {
+
+	entry := logger.FromContext(ctx, s.Log).WithField(logger.FieldUserName, req.UserName).WithField("trip_id", req.TripId)
+	entry.Debug("GRPC GetTrip started")
+
+	if err := auth.Authorize(ctx, req.UserName); err != nil {
+		entry.WithError(err).Warn("GRPC GetTrip forbidden")
+		return &pb.Trip{}, grpcError(err)
+	}
+
+	inReq := dto.GetTripRequest{
+		UserName: req.UserName,
+		TripId:   req.TripId,
+	}
+
+	resp, err := s.LocationService.GetTrip(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC GetTrip failed")
+		return &pb.Trip{}, grpcError(err)
+	}
+
+	entry.Debug("GRPC GetTrip finished")
+	return trip(*resp), nil
+}
+
+func (s *Server) ListStays(ctx context.Context, req *pb.ListStaysRequest) (*pb.ListStaysResponse, error) {
+
+	entry := logger.FromContext(ctx, s.Log).WithField(logger.FieldUserName, req.UserName).WithField(logger.FieldDeviceID, req.DeviceId)
+	entry.Debug("GRPC ListStays started")
+
+	if err := auth.Authorize(ctx, req.UserName); err != nil {
+		entry.WithError(err).Warn("GRPC ListStays forbidden")
+		return &pb.ListStaysResponse{}, grpcError(err)
+	}
+
+	inReq := dto.ListStaysRequest{
+		UserName:    req.UserName,
+		DeviceId:    req.DeviceId,
+		InitialDate: timestamp(req.InitialDate),
+		FinalDate:   timestamp(req.FinalDate),
+		Page:        req.Page,
+		ItemsLimit:  req.ItemsLimit,
+	}
+
+	if err := pageRequest(&inReq.Page, &inReq.ItemsLimit); err != nil {
+		return &pb.ListStaysResponse{}, grpcError(err)
+	}
+
+	resp, err := s.LocationService.ListStays(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC ListStays failed")
+		return &pb.ListStaysResponse{}, grpcError(err)
+	}
+
+	var pbResp = pb.ListStaysResponse{}
+	for _, st := range resp.Stays {
+		pbResp.Stays = append(pbResp.Stays, &pb.Stay{
+			Id:         st.Id,
+			UserName:   st.UserName,
+			DeviceId:   st.DeviceId,
+			Status:     st.Status,
+			Latitude:   st.Latitude,
+			Longitude:  st.Longitude,
+			ArrivedAt:  timestamppb.New(st.ArrivedAt),
+			DepartedAt: timestamppb.New(st.DepartedAt),
+			Duration:   st.Duration,
+			PointCount: uint64(st.PointCount),
+		})
+	}
+
+	pbResp.TotalPages = resp.TotalPages
+	pbResp.TotalItems = resp.TotalItems
+
+	entry.Debug("GRPC ListStays finished")
+	return &pbResp, nil
+}
+
+func (s *Server) GetFrequentPlaces(ctx context.Context, req *pb.GetFrequentPlacesRequest) (*pb.GetFrequentPlacesResponse, error) {
+
+	entry := logger.FromContext(ctx, s.Log).WithField(logger.FieldUserName, req.UserName).WithField(logger.FieldDeviceID, req.DeviceId)
+	entry.Debug("GRPC GetFrequentPlaces started")
+
+	if err := auth.Authorize(ctx, req.UserName); err != nil {
+		entry.WithError(err).Warn("GRPC GetFrequentPlaces forbidden")
+		return &pb.GetFrequentPlacesResponse{}, grpcError(err)
+	}
+
+	inReq := dto.GetFrequentPlacesRequest{
+		UserName:    req.UserName,
+		DeviceId:    req.DeviceId,
+		InitialDate: timestamp(req.InitialDate),
+		FinalDate:   timestamp(req.FinalDate),
+		Limit:       req.Limit,
+	}
+
+	if inReq.Limit == 0 {
+		inReq.Limit = defaultPlacesLimit
+	}
+
+	if inReq.Limit > maxPlacesLimit {
+		err := respKit.GenericBadRequestError(enums.ErrorRequestBodyCode,
+			fmt.Sprintf("limit %d exceeds the maximum of %d", inReq.Limit, maxPlacesLimit))
+		return &pb.GetFrequentPlacesResponse{}, grpcError(err)
+	}
+
+	resp, err := s.LocationService.GetFrequentPlaces(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC GetFrequentPlaces failed")
+		return &pb.GetFrequentPlacesResponse{}, grpcError(err)
+	}
+
+	var pbResp = pb.GetFrequentPlacesResponse{}
+	for _, pl := range resp.Places {
+		pbResp.Places = append(pbResp.Places, &pb.Place{
+			Latitude:       pl.Latitude,
+			Longitude:      pl.Longitude,
+			Visits:         pl.Visits,
+			Days:           pl.Days,
+			TotalDuration:  pl.TotalDuration,
+			FirstArrivedAt: timestamppb.New(pl.FirstArrivedAt),
+			LastDepartedAt: timestamppb.New(pl.LastDepartedAt),
+		})
+	}
+
+	entry.Debug("GRPC GetFrequentPlaces finished")
+	return &pbResp, nil
+}
+
+func (s *Server) GetLeaderboard(ctx context.Context, req *pb.GetLeaderboardRequest) (*pb.GetLeaderboardResponse, error) {
+
+	userName, err := auth.OwnUserName(ctx, req.UserName)
+	if err != nil {
+		return &pb.GetLeaderboardResponse{}, grpcError(err)
+	}
+
+	entry := logger.FromContext(ctx, s.Log).WithField(logger.FieldUserName, userName)
+	entry.Debug("GRPC GetLeaderboard started")
+
+	if req.Area != nil || len(req.UserNames) > 0 {
+		if err := auth.AuthorizeScope(ctx, auth.ScopeAdmin, auth.ScopeService); err != nil {
+			entry.WithError(err).Warn("GRPC GetLeaderboard forbidden")
+			return &pb.GetLeaderboardResponse{}, grpcError(err)
+		}
+	}
+
+	inReq := dto.GetLeaderboardRequest{
+		UserName:    userName,
+		UserNames:   req.UserNames,
+		InitialDate: timestamp(req.InitialDate),
+		FinalDate:   timestamp(req.FinalDate),
+		Page:        req.Page,
+		ItemsLimit:  req.ItemsLimit,
+	}
+
+	if req.Area != nil {
+		inReq.Area = &dto.BoundingBox{
+			MinLatitude:  req.Area.MinLatitude,
+			MinLongitude: req.Area.MinLongitude,
+			MaxLatitude:  req.Area.MaxLatitude,
+			MaxLongitude: req.Area.MaxLongitude,
+		}
+	}
+
+	if err := pageRequest(&inReq.Page, &inReq.ItemsLimit); err != nil {
+		return &pb.GetLeaderboardResponse{}, grpcError(err)
+	}
+
+	if err := validateRequest(inReq); err != nil {
+		entry.WithError(err).Warn("GRPC GetLeaderboard invalid request")
+		return &pb.GetLeaderboardResponse{}, grpcError(err)
+	}
+
+	resp, err := s.LocationService.GetLeaderboard(ctx, inReq)
+	if err != nil {
+		entry.WithError(err).Error("GRPC GetLeaderboard failed")
+		return &pb.GetLeaderboardResponse{}, grpcError(err)
+	}
+
+	var pbResp = pb.GetLeaderboardResponse{}
+	for _, e := range resp.Entries {
+		pbResp.Entries = append(pbResp.Entries, leaderboardEntry(e))
+	}
+
+	if resp.Own != nil {
+		pbResp.Own = leaderboardEntry(*resp.Own)
+	}
+
+	pbResp.TotalPages = resp.TotalPages
+	pbResp.TotalItems = resp.TotalItems
+
+	entry.Debug("GRPC GetLeaderboard finished")
+	return &pbResp, nil
+}
+
+// leaderboardEntry returns e as a grpc LeaderboardEntry message.
+func leaderboardEntry(e dto.LeaderboardEntry) *pb.LeaderboardEntry {
+	return &pb.LeaderboardEntry{
+		Rank:     e.Rank,
+		UserName: e.Username,
+		Distance: e.Distance,
+	}
+}
+
+// location returns l as a grpc Location message.
+func location(l dto.Location) *pb.Location {
+	return &pb.Location{
+		UserName:  l.UserName,
+		Latitude:  l.Latitude,
+		Longitude: l.Longitude,
+		DeviceId:  l.DeviceId,
+		UpdatedAt: timestamppb.New(l.UpdatedAt),
+	}
+}
+
+// trip returns t as a grpc Trip message.
+func trip(t dto.Trip) *pb.Trip {
+	return &pb.Trip{
+		Id:             t.Id,
+		UserName:       t.UserName,
+		DeviceId:       t.DeviceId,
+		Status:         t.Status,
+		StartedAt:      timestamppb.New(t.StartedAt),
+		EndedAt:        timestamppb.New(t.EndedAt),
+		StartLatitude:  t.StartLatitude,
+		StartLongitude: t.StartLongitude,
+		EndLatitude:    t.EndLatitude,
+		EndLongitude:   t.EndLongitude,
+		Distance:       t.Distance,
+		Duration:       t.Duration,
+		MaxSpeed:       t.MaxSpeed,
+		PointCount:     uint64(t.PointCount),
+	}
+}
+
+// timestamp returns ts as time, or the empty time if ts isn't set.
+func timestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}